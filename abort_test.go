@@ -0,0 +1,53 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestAbortInfo 测试中断来源和原因记录到统计
+func TestAbortInfo(t *testing.T) {
+	var stats *ExecutionStats
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+			stats = pipeCtx.Stats()
+		}).
+		AddHook(processHook).
+		AddNamedHook("gate", func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			pipeCtx.AbortWithReason("cache hit")
+			pipeCtx.AbortWithReason("ignored")
+			return nil
+		}).
+		AddHook(processHook)
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	info := stats.AbortInfo
+	if info == nil {
+		t.Fatal("Expected AbortInfo to be recorded")
+	}
+	if info.Hook != "gate" || info.Index != 1 || info.Reason != "cache hit" || info.At.IsZero() {
+		t.Errorf("Unexpected AbortInfo: %+v", info)
+	}
+}
+
+// BenchmarkIsAbortedContended 并行阶段中中断检查与共享数据写入交替进行
+func BenchmarkIsAbortedContended(b *testing.B) {
+	pipeCtx := NewPipeContext[TestOption, TestPayload, TestResult]("bench", nil, &TestPayload{}, nil)
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%4 == 0 {
+				pipeCtx.Set("progress", i)
+			} else if pipeCtx.IsAborted() {
+				b.Fatal("unexpected abort")
+			}
+			i++
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+)
+
+// PlainFunc 不依赖管道的普通函数
+// 只依赖标准 context.Context，直接读取 Payload 并写入 Result
+type PlainFunc[Payload any, Result any] func(ctx context.Context, payload *Payload, result *Result) error
+
+// ServiceMethod 已有服务方法的通用签名
+type ServiceMethod[In any, Out any] func(ctx context.Context, in In) (Out, error)
+
+// Input 从 PipeContext 中提取服务方法的入参
+type Input[Option any, Payload any, Result any, In any] func(pipeCtx *PipeContext[Option, Payload, Result]) (In, error)
+
+// Output 将服务方法的返回值写回 PipeContext
+type Output[Option any, Payload any, Result any, Out any] func(pipeCtx *PipeContext[Option, Payload, Result], out Out)
+
+// HookFromFunc 将普通函数适配为 HookHandler
+// 便于将不感知管道的旧代码直接接入管道
+func HookFromFunc[C Context, Option any, Payload any, Result any](
+	fn PlainFunc[Payload, Result],
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+		return fn(ctx, pipeCtx.Payload, pipeCtx.Result)
+	}
+}
+
+// HookFromMethod 将已有服务方法适配为 HookHandler
+// in 负责从 Payload 字段或共享数据中读取入参，out 负责将返回值写入 Result 字段或共享数据
+func HookFromMethod[C Context, Option any, Payload any, Result any, In any, Out any](
+	method ServiceMethod[In, Out],
+	in Input[Option, Payload, Result, In],
+	out Output[Option, Payload, Result, Out],
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+		arg, err := in(pipeCtx)
+		if err != nil {
+			return err
+		}
+
+		ret, err := method(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		out(pipeCtx, ret)
+		return nil
+	}
+}
+
+// FromPayload 从 Payload 字段读取入参
+func FromPayload[Option any, Payload any, Result any, In any](
+	selector func(payload *Payload) In,
+) Input[Option, Payload, Result, In] {
+	return func(pipeCtx *PipeContext[Option, Payload, Result]) (In, error) {
+		return selector(pipeCtx.Payload), nil
+	}
+}
+
+// FromKey 从共享数据读取入参（key 不存在或类型不匹配时返回错误）
+func FromKey[Option any, Payload any, Result any, In any](key string) Input[Option, Payload, Result, In] {
+	return func(pipeCtx *PipeContext[Option, Payload, Result]) (In, error) {
+		var zero In
+
+		val, ok := pipeCtx.Get(key)
+		if !ok {
+			return zero, fmt.Errorf("input key '%s' not found", key)
+		}
+
+		in, ok := val.(In)
+		if !ok {
+			return zero, fmt.Errorf("input key '%s' has type %T, want %T", key, val, zero)
+		}
+
+		return in, nil
+	}
+}
+
+// ToResult 将返回值写入 Result 字段
+func ToResult[Option any, Payload any, Result any, Out any](
+	setter func(result *Result, out Out),
+) Output[Option, Payload, Result, Out] {
+	return func(pipeCtx *PipeContext[Option, Payload, Result], out Out) {
+		setter(pipeCtx.Result, out)
+	}
+}
+
+// ToKey 将返回值写入共享数据
+func ToKey[Option any, Payload any, Result any, Out any](key string) Output[Option, Payload, Result, Out] {
+	return func(pipeCtx *PipeContext[Option, Payload, Result], out Out) {
+		pipeCtx.Set(key, out)
+	}
+}
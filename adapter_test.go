@@ -0,0 +1,72 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestHookFromFunc 测试普通函数适配
+func TestHookFromFunc(t *testing.T) {
+	legacy := func(ctx context.Context, payload *TestPayload, result *TestResult) error {
+		result.Output = append(result.Output, payload.Data)
+		return nil
+	}
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddNamedHook("legacy", HookFromFunc[sylph.Context, TestOption](legacy))
+
+	result, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1, Data: "legacy"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.Output) != 1 || result.Output[0] != "legacy" {
+		t.Errorf("Expected output [legacy], got %v", result.Output)
+	}
+}
+
+// TestHookFromMethod 测试服务方法适配
+func TestHookFromMethod(t *testing.T) {
+	upper := func(ctx context.Context, in string) (string, error) {
+		return strings.ToUpper(in), nil
+	}
+
+	length := func(ctx context.Context, in string) (int, error) {
+		if in == "" {
+			return 0, errors.New("empty input")
+		}
+		return len(in), nil
+	}
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddNamedHook("upper", HookFromMethod[sylph.Context](
+			upper,
+			FromPayload[TestOption, TestPayload, TestResult](func(p *TestPayload) string { return p.Data }),
+			ToKey[TestOption, TestPayload, TestResult, string]("upper"),
+		)).
+		AddNamedHook("length", HookFromMethod[sylph.Context](
+			length,
+			FromKey[TestOption, TestPayload, TestResult, string]("upper"),
+			ToResult[TestOption, TestPayload](func(r *TestResult, n int) {
+				r.Metadata = map[string]any{"length": n}
+			}),
+		))
+
+	result, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1, Data: "abc"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.Metadata["length"] != 3 {
+		t.Errorf("Expected length 3, got %v", result.Metadata["length"])
+	}
+
+	_, err = pipeline.Execute(newMockContext(), &TestPayload{UserID: 1})
+	if err == nil {
+		t.Fatal("Expected error for empty input, got nil")
+	}
+}
@@ -0,0 +1,260 @@
+package pipeline
+
+import (
+	"maps"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AggregateStats 多次执行的累计统计（按 Hook 名称汇总），可并发记录
+type AggregateStats struct {
+	PipelineName string
+
+	mu            sync.Mutex
+	executions    int
+	failures      int
+	failureKinds  map[FailureKind]int
+	labels        map[string]string
+	zombies       int
+	totalDuration time.Duration
+	hooks         map[string]*HookAggregate
+}
+
+// HookAggregate 单个 Hook 的累计统计
+type HookAggregate struct {
+	Name          string             // Hook 名称
+	Calls         int                // 执行次数（不含跳过）
+	Errors        int                // 失败次数
+	ErrorClasses  map[ErrorClass]int // 按错误分类的失败次数
+	Skipped       int                // 跳过次数
+	Fallbacks     int                // 走降级处理的次数
+	Panics        int                // 因 panic 失败的次数
+	Deprecated    int                // 弃用 Hook 的执行次数
+	TotalDuration time.Duration      // 累计耗时
+	TotalHandler  time.Duration      // Hook 本体累计耗时（见 HookStat.Handler）
+	MaxDuration   time.Duration      // 最大耗时
+
+	Variants map[string]VariantAggregate // 实验步骤各变体的统计（见 Experiment）
+	Profile  ProfileAggregate            // 剖析采样的按层累计耗时（见 WithProfiling）
+}
+
+// ProfileAggregate 剖析采样的累计耗时
+type ProfileAggregate struct {
+	Samples int                      // 采样次数
+	Handler time.Duration            // Hook 本体累计耗时
+	Layers  map[string]time.Duration // 各层包装自身的累计耗时（按层名称）
+}
+
+// VariantAggregate 实验变体的累计统计
+type VariantAggregate struct {
+	Calls         int           // 执行次数
+	Errors        int           // 失败次数
+	TotalDuration time.Duration // 累计耗时
+}
+
+// ErrorRate 错误率（0~1）
+func (v VariantAggregate) ErrorRate() float64 {
+	if v.Calls == 0 {
+		return 0
+	}
+	return float64(v.Errors) / float64(v.Calls)
+}
+
+// FailureKind 失败的错误分类与严重程度
+type FailureKind struct {
+	Class    ErrorClass
+	Severity Severity
+}
+
+// MeanDuration 平均耗时
+func (h HookAggregate) MeanDuration() time.Duration {
+	if h.Calls == 0 {
+		return 0
+	}
+	return h.TotalDuration / time.Duration(h.Calls)
+}
+
+// MiddlewareDuration 中间件等包装的累计耗时
+func (h HookAggregate) MiddlewareDuration() time.Duration {
+	return max(h.TotalDuration-h.TotalHandler, 0)
+}
+
+// clone 复制统计，避免调用方共享内部的分类计数
+func (h *HookAggregate) clone() HookAggregate {
+	c := *h
+	c.ErrorClasses = maps.Clone(h.ErrorClasses)
+	c.Variants = maps.Clone(h.Variants)
+	c.Profile.Layers = maps.Clone(h.Profile.Layers)
+	return c
+}
+
+// ErrorRate 错误率（0~1）
+func (h HookAggregate) ErrorRate() float64 {
+	if h.Calls == 0 {
+		return 0
+	}
+	return float64(h.Errors) / float64(h.Calls)
+}
+
+// NewAggregateStats 创建累计统计
+func NewAggregateStats(pipelineName string) *AggregateStats {
+	return &AggregateStats{
+		PipelineName: pipelineName,
+		failureKinds: make(map[FailureKind]int),
+		hooks:        make(map[string]*HookAggregate),
+	}
+}
+
+// Record 累计一次执行的统计
+func (a *AggregateStats) Record(stats *ExecutionStats) {
+	if stats == nil {
+		return
+	}
+
+	stats.mu.Lock()
+	hookStats := append([]HookStat(nil), stats.HookStats...)
+	success, total := stats.Success, stats.TotalDuration
+	kind := FailureKind{Class: stats.ErrorClass, Severity: stats.Severity}
+	labels := maps.Clone(stats.Labels)
+	zombies := stats.Zombies
+	stats.mu.Unlock()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.executions++
+	a.zombies += zombies
+	if len(labels) > 0 {
+		a.labels = labels
+	}
+	if !success {
+		a.failures++
+		a.failureKinds[kind]++
+	}
+	a.totalDuration += total
+
+	for _, stat := range hookStats {
+		h, ok := a.hooks[stat.Name]
+		if !ok {
+			h = &HookAggregate{Name: stat.Name}
+			a.hooks[stat.Name] = h
+		}
+
+		if stat.Skipped {
+			h.Skipped++
+			continue
+		}
+		h.Calls++
+		if stat.Error != nil {
+			h.Errors++
+			if h.ErrorClasses == nil {
+				h.ErrorClasses = make(map[ErrorClass]int)
+			}
+			h.ErrorClasses[stat.Class]++
+		}
+		if stat.Fallback {
+			h.Fallbacks++
+		}
+		if stat.Panicked {
+			h.Panics++
+		}
+		if stat.Deprecated {
+			h.Deprecated++
+		}
+		h.TotalDuration += stat.Duration
+		h.TotalHandler += stat.Handler
+		if stat.Duration > h.MaxDuration {
+			h.MaxDuration = stat.Duration
+		}
+		if stat.Variant != "" {
+			if h.Variants == nil {
+				h.Variants = make(map[string]VariantAggregate)
+			}
+			v := h.Variants[stat.Variant]
+			v.Calls++
+			if stat.Error != nil {
+				v.Errors++
+			}
+			v.TotalDuration += stat.Duration
+			h.Variants[stat.Variant] = v
+		}
+		if stat.Profile != nil {
+			h.Profile.Samples++
+			h.Profile.Handler += stat.Profile.Handler
+			for _, layer := range stat.Profile.Layers {
+				if h.Profile.Layers == nil {
+					h.Profile.Layers = make(map[string]time.Duration)
+				}
+				h.Profile.Layers[layer.Name] += layer.Duration
+			}
+		}
+	}
+}
+
+// Executions 执行次数
+func (a *AggregateStats) Executions() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.executions
+}
+
+// Failures 失败次数
+func (a *AggregateStats) Failures() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.failures
+}
+
+// Labels 最近一次执行记录的管道标签（副本）
+func (a *AggregateStats) Labels() map[string]string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return maps.Clone(a.labels)
+}
+
+// FailuresByKind 按错误分类与严重程度统计的失败次数
+func (a *AggregateStats) FailuresByKind() map[FailureKind]int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return maps.Clone(a.failureKinds)
+}
+
+// Zombies 累计被放弃的僵尸 Hook goroutine 数量
+func (a *AggregateStats) Zombies() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.zombies
+}
+
+// TotalDuration 累计执行耗时
+func (a *AggregateStats) TotalDuration() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.totalDuration
+}
+
+// Hook 获取指定 Hook 的累计统计
+func (a *AggregateStats) Hook(name string) (HookAggregate, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	h, ok := a.hooks[name]
+	if !ok {
+		return HookAggregate{}, false
+	}
+	return h.clone(), true
+}
+
+// Hooks 按名称排序返回所有 Hook 的累计统计
+func (a *AggregateStats) Hooks() []HookAggregate {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	hooks := make([]HookAggregate, 0, len(a.hooks))
+	for _, h := range a.hooks {
+		hooks = append(hooks, h.clone())
+	}
+	sort.Slice(hooks, func(i, j int) bool { return hooks[i].Name < hooks[j].Name })
+	return hooks
+}
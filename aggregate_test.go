@@ -0,0 +1,67 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// recordRuns 向累计统计写入 n 次执行，failed 次失败
+func recordRuns(agg *AggregateStats, hook string, n, failed int, d time.Duration) {
+	for i := 0; i < n; i++ {
+		stats := NewExecutionStats(agg.PipelineName)
+		stat := HookStat{Name: hook, Duration: d}
+		if i < failed {
+			stat.Error = errors.New("failed")
+		}
+		stats.AddHookStat(stat)
+		stats.MarkEnd(stat.Error)
+		agg.Record(stats)
+	}
+}
+
+// TestAggregateStats 测试累计统计
+func TestAggregateStats(t *testing.T) {
+	agg := NewAggregateStats("test")
+	recordRuns(agg, "load", 4, 1, 10*time.Millisecond)
+
+	if agg.Executions() != 4 || agg.Failures() != 1 {
+		t.Errorf("Expected 4 executions and 1 failure, got %d and %d", agg.Executions(), agg.Failures())
+	}
+
+	h, ok := agg.Hook("load")
+	if !ok {
+		t.Fatal("Expected hook aggregate")
+	}
+	if h.MeanDuration() != 10*time.Millisecond || h.ErrorRate() != 0.25 {
+		t.Errorf("Expected mean 10ms and error rate 0.25, got %v and %v", h.MeanDuration(), h.ErrorRate())
+	}
+}
+
+// TestCompareStats 测试耗时与错误率回归检测
+func TestCompareStats(t *testing.T) {
+	baseline := NewAggregateStats("test")
+	recordRuns(baseline, "load", 20, 0, 10*time.Millisecond)
+	recordRuns(baseline, "save", 20, 0, 10*time.Millisecond)
+	recordRuns(baseline, "notify", 20, 0, 10*time.Millisecond)
+
+	current := NewAggregateStats("test")
+	recordRuns(current, "load", 20, 0, 20*time.Millisecond)
+	recordRuns(current, "save", 20, 5, 10*time.Millisecond)
+	recordRuns(current, "notify", 20, 0, 11*time.Millisecond)
+
+	report := CompareStats(baseline, current)
+	if len(report.Regressions) != 2 {
+		t.Fatalf("Expected 2 regressions, got %v", report)
+	}
+	if report.Regressions[0].Hook != "load" || report.Regressions[0].Kind != RegressionLatency {
+		t.Errorf("Expected latency regression on load, got %v", report.Regressions[0])
+	}
+	if report.Regressions[1].Hook != "save" || report.Regressions[1].Kind != RegressionErrorRate {
+		t.Errorf("Expected error rate regression on save, got %v", report.Regressions[1])
+	}
+
+	if CompareStats(baseline, baseline).Regressed() {
+		t.Error("Expected no regression against itself")
+	}
+}
@@ -0,0 +1,95 @@
+package pipeline
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// WithConcurrency 设置 ExecuteAndForget 的最大并发执行数（默认 GOMAXPROCS）
+// 需在第一次 ExecuteAndForget 之前调用
+func (p *Pipeline[C, Option, Payload, Result]) WithConcurrency(n int) *Pipeline[C, Option, Payload, Result] {
+	p.concurrency = n
+	return p
+}
+
+// DefaultQueueSize ExecuteAndForget 默认的排队上限
+const DefaultQueueSize = 1024
+
+// WithQueueSize 设置 ExecuteAndForget 等待工作槽位的最大排队数（默认 DefaultQueueSize）
+// 运行中与排队中的执行总数超过 并发数+排队数 时，新提交的执行以 ErrQueueFull 拒绝。
+// 需在第一次 ExecuteAndForget 之前调用
+func (p *Pipeline[C, Option, Payload, Result]) WithQueueSize(n int) *Pipeline[C, Option, Payload, Result] {
+	p.queueSize = n
+	return p
+}
+
+// ExecuteAndForget 在管道的工作池中异步执行，立即返回（如需要直接返回 202 的接口）
+// 执行完成后调用 onDone（可为 nil）；管道已关闭时 onDone 收到 ErrPipelineClosed，
+// 排队已满时 onDone 立即收到 ErrQueueFull（均在调用方 goroutine 中同步回调）。
+// ctx 会在执行期间继续使用，调用方应传入不随请求结束而取消的上下文
+func (p *Pipeline[C, Option, Payload, Result]) ExecuteAndForget(
+	ctx C,
+	payload *Payload,
+	onDone func(result *Result, err error),
+) {
+	if onDone == nil {
+		onDone = func(*Result, error) {}
+	}
+
+	p.lifecycle.mu.Lock()
+	if p.lifecycle.closed.Load() {
+		p.lifecycle.mu.Unlock()
+		onDone(nil, fmt.Errorf("%w: %s", ErrPipelineClosed, p.Name))
+		return
+	}
+	if p.lifecycle.workers == nil {
+		n := p.concurrency
+		if n <= 0 {
+			n = runtime.GOMAXPROCS(0)
+		}
+		queue := p.queueSize
+		if queue < 0 {
+			queue = 0
+		} else if queue == 0 {
+			queue = DefaultQueueSize
+		}
+		p.lifecycle.workers = make(chan struct{}, n)
+		p.lifecycle.pending = make(chan struct{}, n+queue)
+	}
+	workers := p.lifecycle.workers
+	select {
+	case p.lifecycle.pending <- struct{}{}:
+	default:
+		p.lifecycle.mu.Unlock()
+		onDone(nil, fmt.Errorf("%w: %s", ErrQueueFull, p.Name))
+		return
+	}
+	pending := p.lifecycle.pending
+	p.lifecycle.inflight.Add(1)
+	p.lifecycle.mu.Unlock()
+
+	go func() {
+		defer p.lifecycle.inflight.Done()
+		defer func() { <-pending }()
+
+		workers <- struct{}{}
+		defer func() { <-workers }()
+
+		result, err := p.executeRecovered(ctx, payload, nil)
+		onDone(result, err)
+	}()
+}
+
+// executeRecovered 执行已接受的异步任务，将 panic 转换为错误，避免后台 goroutine 崩溃进程
+func (p *Pipeline[C, Option, Payload, Result]) executeRecovered(
+	ctx C,
+	payload *Payload,
+	stream *resultStream[Result],
+) (result *Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("pipeline '%s' panic: %v", p.Name, r)
+		}
+	}()
+	return p.execute(ctx, payload, true, stream)
+}
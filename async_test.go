@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestExecuteAndForget 测试异步执行的并发限制与关闭等待
+func TestExecuteAndForget(t *testing.T) {
+	var running, peak, done atomic.Int32
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		WithConcurrency(2).
+		AddHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		})
+
+	for i := 0; i < 6; i++ {
+		pipeline.ExecuteAndForget(newMockContext(), &TestPayload{}, func(result *TestResult, err error) {
+			if err == nil {
+				done.Add(1)
+			}
+		})
+	}
+
+	if err := pipeline.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	if done.Load() != 6 {
+		t.Errorf("Expected all accepted executions to finish before Close returns, got %d", done.Load())
+	}
+	if peak.Load() > 2 {
+		t.Errorf("Expected at most 2 concurrent executions, got %d", peak.Load())
+	}
+
+	var rejected error
+	pipeline.ExecuteAndForget(newMockContext(), &TestPayload{}, func(result *TestResult, err error) {
+		rejected = err
+	})
+	if !errors.Is(rejected, ErrPipelineClosed) {
+		t.Errorf("Expected ErrPipelineClosed after Close, got %v", rejected)
+	}
+}
+
+// TestExecuteAndForgetQueueFull 测试排队已满时拒绝新的异步执行
+func TestExecuteAndForgetQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		WithConcurrency(1).
+		WithQueueSize(1).
+		AddHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			<-release
+			return nil
+		})
+
+	var done atomic.Int32
+	var rejected error
+	for i := 0; i < 3; i++ {
+		pipeline.ExecuteAndForget(newMockContext(), &TestPayload{}, func(result *TestResult, err error) {
+			if err != nil {
+				rejected = err
+				return
+			}
+			done.Add(1)
+		})
+	}
+	if !errors.Is(rejected, ErrQueueFull) {
+		t.Errorf("Expected ErrQueueFull for the third execution, got %v", rejected)
+	}
+
+	close(release)
+	if err := pipeline.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+	if done.Load() != 2 {
+		t.Errorf("Expected 2 accepted executions to finish, got %d", done.Load())
+	}
+}
@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// passMiddleware 直接调用内层的测试中间件
+func passMiddleware(next HookHandler[sylph.Context, TestOption, TestPayload, TestResult]) HookHandler[sylph.Context, TestOption, TestPayload, TestResult] {
+	return func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		return next(ctx, pipeCtx)
+	}
+}
+
+// BenchmarkExecute_NHooks_MMiddlewares 执行开销基准：N 个空 Hook、M 个直通中间件；
+// NoStats 变体关闭 Hook 统计，无中间件时走快速路径
+func BenchmarkExecute_NHooks_MMiddlewares(b *testing.B) {
+	for _, hooks := range []int{1, 4, 16, 64} {
+		for _, middlewares := range []int{0, 1, 4} {
+			b.Run(fmt.Sprintf("%dHooks_%dMiddlewares", hooks, middlewares), func(b *testing.B) {
+				benchmarkNHooks(b, hooks, middlewares, false)
+			})
+			b.Run(fmt.Sprintf("%dHooks_%dMiddlewares_NoStats", hooks, middlewares), func(b *testing.B) {
+				benchmarkNHooks(b, hooks, middlewares, true)
+			})
+		}
+	}
+}
+
+// benchmarkNHooks 执行 hooks 个空 Hook、middlewares 个直通中间件的管道
+func benchmarkNHooks(b *testing.B, hooks, middlewares int, noStats bool) {
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("bench")
+	for i := range hooks {
+		pipeline.AddNamedHook(fmt.Sprintf("hook%d", i), func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			return nil
+		})
+	}
+	for i := range middlewares {
+		pipeline.UseNamed(fmt.Sprintf("middleware%d", i), passMiddleware)
+	}
+	if noStats {
+		pipeline.WithoutStats()
+	}
+	ctx := newMockContext()
+	payload := &TestPayload{}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := pipeline.Execute(ctx, payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package pipeline
+
+// BranchCondition 分支条件
+type BranchCondition[C Context, Option any, Payload any, Result any] func(
+	ctx C,
+	pipeCtx *PipeContext[Option, Payload, Result],
+) bool
+
+// SwitchSelector 多路分支选择器，返回命中的 case 名称
+type SwitchSelector[C Context, Option any, Payload any, Result any] func(
+	ctx C,
+	pipeCtx *PipeContext[Option, Payload, Result],
+) string
+
+// BranchBuilder If/Else 分支构建器
+type BranchBuilder[C Context, Option any, Payload any, Result any] struct {
+	cond     BranchCondition[C, Option, Payload, Result]
+	thenHook []HookHandler[C, Option, Payload, Result]
+	elseHook []HookHandler[C, Option, Payload, Result]
+}
+
+// Branch 创建 If/Else 分支
+func Branch[C Context, Option any, Payload any, Result any](
+	cond BranchCondition[C, Option, Payload, Result],
+) *BranchBuilder[C, Option, Payload, Result] {
+	return &BranchBuilder[C, Option, Payload, Result]{cond: cond}
+}
+
+// Then 条件成立时执行的 Hook
+func (b *BranchBuilder[C, Option, Payload, Result]) Then(
+	handlers ...HookHandler[C, Option, Payload, Result],
+) *BranchBuilder[C, Option, Payload, Result] {
+	b.thenHook = append(b.thenHook, handlers...)
+	return b
+}
+
+// Else 条件不成立时执行的 Hook
+func (b *BranchBuilder[C, Option, Payload, Result]) Else(
+	handlers ...HookHandler[C, Option, Payload, Result],
+) *BranchBuilder[C, Option, Payload, Result] {
+	b.elseHook = append(b.elseHook, handlers...)
+	return b
+}
+
+// Build 构建分支 Hook
+func (b *BranchBuilder[C, Option, Payload, Result]) Build(name string) *Hook[C, Option, Payload, Result] {
+	thenGroup := newHookGroup(name, "then", b.thenHook)
+	elseGroup := newHookGroup(name, "else", b.elseHook)
+	cond := b.cond
+
+	return &Hook[C, Option, Payload, Result]{
+		Name: name,
+		Handler: func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+			if cond(ctx, pipeCtx) {
+				return thenGroup.run(ctx, pipeCtx)
+			}
+			return elseGroup.run(ctx, pipeCtx)
+		},
+		kind:   "branch",
+		groups: []hookGroup[C, Option, Payload, Result]{thenGroup, elseGroup},
+	}
+}
+
+// SwitchBuilder 多路分支构建器
+type SwitchBuilder[C Context, Option any, Payload any, Result any] struct {
+	selector    SwitchSelector[C, Option, Payload, Result]
+	cases       []string
+	caseHooks   map[string][]HookHandler[C, Option, Payload, Result]
+	defaultHook []HookHandler[C, Option, Payload, Result]
+}
+
+// Switch 创建多路分支
+func Switch[C Context, Option any, Payload any, Result any](
+	selector SwitchSelector[C, Option, Payload, Result],
+) *SwitchBuilder[C, Option, Payload, Result] {
+	return &SwitchBuilder[C, Option, Payload, Result]{
+		selector:  selector,
+		caseHooks: make(map[string][]HookHandler[C, Option, Payload, Result]),
+	}
+}
+
+// Case 选择器返回 value 时执行的 Hook
+func (s *SwitchBuilder[C, Option, Payload, Result]) Case(
+	value string,
+	handlers ...HookHandler[C, Option, Payload, Result],
+) *SwitchBuilder[C, Option, Payload, Result] {
+	if _, exists := s.caseHooks[value]; !exists {
+		s.cases = append(s.cases, value)
+	}
+	s.caseHooks[value] = append(s.caseHooks[value], handlers...)
+	return s
+}
+
+// Default 没有命中任何 case 时执行的 Hook
+func (s *SwitchBuilder[C, Option, Payload, Result]) Default(
+	handlers ...HookHandler[C, Option, Payload, Result],
+) *SwitchBuilder[C, Option, Payload, Result] {
+	s.defaultHook = append(s.defaultHook, handlers...)
+	return s
+}
+
+// Build 构建多路分支 Hook
+func (s *SwitchBuilder[C, Option, Payload, Result]) Build(name string) *Hook[C, Option, Payload, Result] {
+	groups := make([]hookGroup[C, Option, Payload, Result], 0, len(s.cases)+1)
+	index := make(map[string]int, len(s.cases))
+	for _, value := range s.cases {
+		index[value] = len(groups)
+		groups = append(groups, newHookGroup(name, "case "+value, s.caseHooks[value]))
+	}
+	defaultGroup := newHookGroup(name, "default", s.defaultHook)
+	groups = append(groups, defaultGroup)
+	selector := s.selector
+
+	return &Hook[C, Option, Payload, Result]{
+		Name: name,
+		Handler: func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+			if i, ok := index[selector(ctx, pipeCtx)]; ok {
+				return groups[i].run(ctx, pipeCtx)
+			}
+			return defaultGroup.run(ctx, pipeCtx)
+		},
+		kind:   "switch",
+		groups: groups,
+	}
+}
+
+// AddBranch 添加 If/Else 分支
+func (p *Pipeline[C, Option, Payload, Result]) AddBranch(
+	name string,
+	branch *BranchBuilder[C, Option, Payload, Result],
+) *Pipeline[C, Option, Payload, Result] {
+	return p.AddHookWithOptions(branch.Build(name))
+}
+
+// AddSwitch 添加多路分支
+func (p *Pipeline[C, Option, Payload, Result]) AddSwitch(
+	name string,
+	sw *SwitchBuilder[C, Option, Payload, Result],
+) *Pipeline[C, Option, Payload, Result] {
+	return p.AddHookWithOptions(sw.Build(name))
+}
@@ -0,0 +1,80 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+func appendHook(name string) HookHandler[sylph.Context, TestOption, TestPayload, TestResult] {
+	return func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		pipeCtx.Result.Output = append(pipeCtx.Result.Output, name)
+		return nil
+	}
+}
+
+// TestBranch 测试 If/Else 分支
+func TestBranch(t *testing.T) {
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddBranch("vip", Branch(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) bool {
+			return pipeCtx.Payload.UserID > 100
+		}).
+			Then(appendHook("a"), appendHook("b")).
+			Else(appendHook("c"))).
+		AddHook(appendHook("end"))
+
+	cases := []struct {
+		userID   int
+		expected string
+	}{
+		{userID: 101, expected: "a,b,end"},
+		{userID: 1, expected: "c,end"},
+	}
+
+	for _, tc := range cases {
+		result, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: tc.userID})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := strings.Join(result.Output, ","); got != tc.expected {
+			t.Errorf("UserID %d: expected %s, got %s", tc.userID, tc.expected, got)
+		}
+	}
+}
+
+// TestSwitch 测试多路分支
+func TestSwitch(t *testing.T) {
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddSwitch("channel", Switch(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) string {
+			return pipeCtx.Payload.Data
+		}).
+			Case("sms", appendHook("sms")).
+			Case("email", appendHook("email"), appendHook("archive")).
+			Default(appendHook("noop")))
+
+	cases := map[string]string{
+		"sms":   "sms",
+		"email": "email,archive",
+		"push":  "noop",
+	}
+
+	for data, expected := range cases {
+		result, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1, Data: data})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := strings.Join(result.Output, ","); got != expected {
+			t.Errorf("Data %s: expected %s, got %s", data, expected, got)
+		}
+	}
+
+	desc := pipeline.Describe()
+	for _, want := range []string{"[0] channel (switch)", "case sms:", "case email:", "default:"} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("Expected Describe to contain %q, got:\n%s", want, desc)
+		}
+	}
+}
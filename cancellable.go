@@ -0,0 +1,133 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RunCancellable 在独立 goroutine 中执行不支持取消的阻塞调用（如不接受 ctx 的第三方 SDK），
+// ctx 先结束时立即返回 ctx.Err()。fn 本身不会被中止，取消后其结果被丢弃；能传入 ctx 的调用应直接传入
+func RunCancellable(ctx context.Context, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	panicked := make(chan any, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				panicked <- r
+			}
+		}()
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case r := <-panicked:
+		panic(r)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Sleep 等待 d，ctx 先结束时返回 ctx.Err()（替代 Hook 中的 time.Sleep）
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CancelCheck 循环中的周期性取消检查：每调用 every 次 Check 检查一次 ctx，降低高频循环的开销
+//
+//	check := pipe.NewCancelCheck(ctx, 100)
+//	for _, row := range rows {
+//	    if err := check.Check(); err != nil {
+//	        return err
+//	    }
+//	    ...
+//	}
+type CancelCheck struct {
+	ctx   context.Context
+	every int
+	n     int
+}
+
+// NewCancelCheck 创建周期性取消检查，every < 1 时每次都检查
+func NewCancelCheck(ctx context.Context, every int) *CancelCheck {
+	if every < 1 {
+		every = 1
+	}
+	return &CancelCheck{ctx: ctx, every: every}
+}
+
+// Check 每 every 次调用检查一次 ctx，已取消时返回 ctx.Err()
+func (c *CancelCheck) Check() error {
+	c.n++
+	if c.n < c.every {
+		return nil
+	}
+	c.n = 0
+	return c.ctx.Err()
+}
+
+// WithCancelGrace 开启取消监控：执行上下文取消后 Hook 超过 grace 仍未返回时，
+// 当前 Hook 以 ErrHookNotCancelled 失败，执行不再等待它（其 goroutine 在后台继续运行直到自行结束，记为僵尸 Hook）
+// 开启后每个 Hook 在独立 goroutine 中执行；grace <= 0 表示关闭（默认）
+func (p *Pipeline[C, Option, Payload, Result]) WithCancelGrace(grace time.Duration) *Pipeline[C, Option, Payload, Result] {
+	p.cancelGrace = grace
+	return p
+}
+
+// cancelGuard 监控 Handler 对取消的响应，ctx 结束后超过 grace 仍未返回时放弃等待
+func cancelGuard[C Context, Option any, Payload any, Result any](
+	handler HookHandler[C, Option, Payload, Result],
+	grace time.Duration,
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+		done := make(chan error, 1)
+		panicked := make(chan any, 1)
+		exited := make(chan struct{})
+		go func() {
+			defer close(exited)
+			defer func() {
+				if r := recover(); r != nil {
+					panicked <- r
+				}
+			}()
+			done <- handler(ctx, pipeCtx)
+		}()
+
+		select {
+		case err := <-done:
+			return err
+		case r := <-panicked:
+			panic(r)
+		case <-ctx.Done():
+		}
+
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+
+		select {
+		case err := <-done:
+			return err
+		case r := <-panicked:
+			panic(r)
+		case <-timer.C:
+		}
+
+		pipeCtx.reportZombie(ErrHookNotCancelled, exited)
+		name, _ := pipeCtx.CurrentHook()
+		return fmt.Errorf("%w: hook '%s' still running %v after cancellation", ErrHookNotCancelled, name, grace)
+	}
+}
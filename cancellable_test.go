@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRunCancellable 测试取消时不等待阻塞调用返回
+func TestRunCancellable(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	err := RunCancellable(ctx, func() error {
+		<-release
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	errFailed := errors.New("failed")
+	if err := RunCancellable(context.Background(), func() error { return errFailed }); !errors.Is(err, errFailed) {
+		t.Errorf("Expected fn error, got %v", err)
+	}
+}
+
+// TestCancelCheck 测试周期性取消检查
+func TestCancelCheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	check := NewCancelCheck(ctx, 3)
+	var checked int
+	for i := 0; i < 6; i++ {
+		if check.Check() != nil {
+			checked++
+		}
+	}
+	if checked != 2 {
+		t.Errorf("Expected 2 checks in 6 calls, got %d", checked)
+	}
+
+	if err := Sleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Sleep to return on cancellation, got %v", err)
+	}
+}
+
+// TestCancelGrace 测试取消后仍不返回的 Hook 使执行失败
+func TestCancelGrace(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		WithCancelGrace(20*time.Millisecond).
+		AddNamedHook("stuck", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			cancel()
+			<-release
+			return nil
+		})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := pipeline.ExecuteStd(ctx, &TestPayload{})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrHookNotCancelled) {
+			t.Errorf("Expected ErrHookNotCancelled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected execution to give up on the stuck hook")
+	}
+}
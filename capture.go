@@ -0,0 +1,51 @@
+package pipeline
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// maxResultSummary PipeError.Result 摘要的最大长度（字节）
+const maxResultSummary = 1024
+
+// errorCapture 失败时捕获的上下文配置
+type errorCapture struct {
+	keys []string // 捕获的共享数据键
+}
+
+// WithErrorContextCapture 执行失败时将指定的共享数据键和 Result 摘要记录到 PipeError（Data、Result），
+// 便于日志中直接看到失败时的状态。不存在的键不记录；WithRedactedKeys 指定的键和实现 Redactor 的值脱敏后记录
+func (p *Pipeline[C, Option, Payload, Result]) WithErrorContextCapture(keys ...string) *Pipeline[C, Option, Payload, Result] {
+	if p.errorCapture == nil {
+		p.errorCapture = &errorCapture{}
+	}
+	p.errorCapture.keys = append(p.errorCapture.keys, keys...)
+	return p
+}
+
+// capture 将共享数据和 Result 摘要记录到 PipeError（c 为 nil 时为空操作）
+func (c *errorCapture) capture(state *sharedState, result any, pipeErr *PipeError) {
+	if c == nil {
+		return
+	}
+
+	if len(c.keys) > 0 {
+		data := make(map[string]any, len(c.keys))
+		for _, key := range c.keys {
+			if value, ok := state.loadData(key); ok {
+				data[key] = state.redact(key, value)
+			}
+		}
+		pipeErr.Data = data
+	}
+
+	summary := fmt.Sprintf("%+v", result)
+	if len(summary) > maxResultSummary {
+		cut := maxResultSummary
+		for cut > 0 && !utf8.RuneStart(summary[cut]) {
+			cut--
+		}
+		summary = summary[:cut] + "..."
+	}
+	pipeErr.Result = summary
+}
@@ -0,0 +1,58 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestErrorContextCapture 测试失败时捕获共享数据和 Result 摘要
+func TestErrorContextCapture(t *testing.T) {
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("capture").
+		WithRedactedKeys("card").
+		WithErrorContextCapture("user", "card", "missing").
+		AddNamedHook("load", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			pipeCtx.Set("user", "alice")
+			pipeCtx.Set("card", "4111-1111")
+			pipeCtx.Set("other", 1)
+			pipeCtx.Result.Output = append(pipeCtx.Result.Output, "loaded")
+			return nil
+		}).
+		AddNamedHook("charge", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			return errors.New("declined")
+		})
+
+	_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	var pipeErr *PipeError
+	if !errors.As(err, &pipeErr) {
+		t.Fatalf("Expected PipeError, got %v", err)
+	}
+
+	expected := map[string]any{"user": "alice", "card": Redacted}
+	if !reflect.DeepEqual(pipeErr.Data, expected) {
+		t.Errorf("Expected data %v, got %v", expected, pipeErr.Data)
+	}
+	if !strings.Contains(pipeErr.Result, "Output:[loaded]") {
+		t.Errorf("Expected result summary, got %q", pipeErr.Result)
+	}
+}
+
+// TestErrorContextCaptureDisabled 测试未开启时不捕获
+func TestErrorContextCaptureDisabled(t *testing.T) {
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("capture").
+		AddNamedHook("fail", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			pipeCtx.Set("user", "alice")
+			return errors.New("boom")
+		})
+
+	_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	var pipeErr *PipeError
+	if !errors.As(err, &pipeErr) {
+		t.Fatalf("Expected PipeError, got %v", err)
+	}
+	if pipeErr.Data != nil || pipeErr.Result != "" {
+		t.Errorf("Expected no captured context, got %v %q", pipeErr.Data, pipeErr.Result)
+	}
+}
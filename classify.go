@@ -0,0 +1,163 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrorClass 错误分类
+type ErrorClass string
+
+const (
+	// ClassUnknown 未分类
+	ClassUnknown ErrorClass = ""
+	// ClassTransient 临时错误（超时、限流等），可重试
+	ClassTransient ErrorClass = "transient"
+	// ClassPermanent 永久错误，重试无意义
+	ClassPermanent ErrorClass = "permanent"
+	// ClassValidation 输入校验错误，重试无意义
+	ClassValidation ErrorClass = "validation"
+	// ClassDownstream 下游依赖错误，可重试
+	ClassDownstream ErrorClass = "downstream"
+)
+
+// Retryable 该类错误是否值得重试（未分类的错误视为可重试）
+func (c ErrorClass) Retryable() bool {
+	return c != ClassPermanent && c != ClassValidation
+}
+
+// Label 指标标签中使用的分类名称（未分类为 unknown）
+func (c ErrorClass) Label() string {
+	if c == ClassUnknown {
+		return "unknown"
+	}
+	return string(c)
+}
+
+// Severity 该类错误的默认严重程度：临时与校验错误为 warning，其余为 error
+func (c ErrorClass) Severity() Severity {
+	switch c {
+	case ClassTransient, ClassValidation:
+		return SeverityWarning
+	default:
+		return SeverityError
+	}
+}
+
+// Severity 错误严重程度，用于告警分级与指标标签
+type Severity string
+
+const (
+	// SeverityInfo 预期内的失败，仅记录
+	SeverityInfo Severity = "info"
+	// SeverityWarning 需要关注但通常可自行恢复
+	SeverityWarning Severity = "warning"
+	// SeverityError 需要处理的失败
+	SeverityError Severity = "error"
+	// SeverityCritical 需要立即处理的失败
+	SeverityCritical Severity = "critical"
+)
+
+// ErrorClassifier 错误分类器
+type ErrorClassifier interface {
+	Classify(err error) ErrorClass
+}
+
+// ErrorClassifierFunc 函数形式的错误分类器
+type ErrorClassifierFunc func(err error) ErrorClass
+
+// Classify 实现 ErrorClassifier
+func (f ErrorClassifierFunc) Classify(err error) ErrorClass {
+	return f(err)
+}
+
+// classified 携带分类的错误
+type classified struct {
+	class ErrorClass
+	err   error
+}
+
+func (e *classified) Error() string {
+	return e.err.Error()
+}
+
+func (e *classified) Unwrap() error {
+	return e.err
+}
+
+// Classify 为错误标注分类，DefaultClassifier 会优先使用该分类
+func Classify(err error, class ErrorClass) error {
+	if err == nil {
+		return nil
+	}
+	return &classified{class: class, err: err}
+}
+
+// severe 携带严重程度的错误
+type severe struct {
+	severity Severity
+	err      error
+}
+
+func (e *severe) Error() string {
+	return e.err.Error()
+}
+
+func (e *severe) Unwrap() error {
+	return e.err
+}
+
+// WithSeverity 为错误标注严重程度，覆盖分类的默认严重程度
+func WithSeverity(err error, severity Severity) error {
+	if err == nil {
+		return nil
+	}
+	return &severe{severity: severity, err: err}
+}
+
+// SeverityOf 错误的严重程度：优先使用 WithSeverity 标注，否则取分类的默认严重程度
+func SeverityOf(err error, class ErrorClass) Severity {
+	var s *severe
+	if errors.As(err, &s) {
+		return s.severity
+	}
+	return class.Severity()
+}
+
+// DefaultClassifier 默认错误分类器
+// 优先使用 Classify 标注的分类，其次识别超时（transient）和校验错误（validation）
+var DefaultClassifier ErrorClassifier = ErrorClassifierFunc(func(err error) ErrorClass {
+	var c *classified
+	if errors.As(err, &c) {
+		return c.class
+	}
+
+	var verr *ValidationError
+	switch {
+	case errors.As(err, &verr):
+		return ClassValidation
+	case errors.Is(err, ErrHookTimeout), errors.Is(err, context.DeadlineExceeded):
+		return ClassTransient
+	}
+	return ClassUnknown
+})
+
+// WithErrorClassifier 设置错误分类器（默认 DefaultClassifier）
+// 分类结果写入 PipeError.Class、HookStat.Class 与 ExecutionStats.ErrorClass（进而成为指标标签），
+// 并供重试中间件判断是否重试
+func (p *Pipeline[C, Option, Payload, Result]) WithErrorClassifier(classifier ErrorClassifier) *Pipeline[C, Option, Payload, Result] {
+	p.classifier = classifier
+	return p
+}
+
+// ClassifyError 使用管道配置的分类器对错误分类
+func (p *PipeContext[Option, Payload, Result]) ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ClassUnknown
+	}
+	classifier := p.state.classifier
+	if classifier == nil {
+		classifier = DefaultClassifier
+	}
+	return classifier.Classify(err)
+}
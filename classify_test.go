@@ -0,0 +1,76 @@
+package pipeline
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestErrorClassification 测试错误分类写入 PipeError
+func TestErrorClassification(t *testing.T) {
+	errDeclined := errors.New("card declined")
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			return Classify(errDeclined, ClassPermanent)
+		})
+
+	_, err := pipeline.Execute(newMockContext(), &TestPayload{})
+	var pipeErr *PipeError
+	if !errors.As(err, &pipeErr) || pipeErr.Class != ClassPermanent || !errors.Is(err, errDeclined) {
+		t.Fatalf("Expected permanent PipeError wrapping errDeclined, got %v", err)
+	}
+	if pipeErr.Class.Retryable() {
+		t.Error("Expected permanent errors not to be retryable")
+	}
+
+	pipeline.WithErrorClassifier(ErrorClassifierFunc(func(err error) ErrorClass { return ClassDownstream }))
+	_, err = pipeline.Execute(newMockContext(), &TestPayload{})
+	if !errors.As(err, &pipeErr) || pipeErr.Class != ClassDownstream {
+		t.Errorf("Expected custom classifier result, got %v", err)
+	}
+}
+
+// TestErrorClassInStats 测试分类与严重程度写入执行统计和 OpenMetrics 标签
+func TestErrorClassInStats(t *testing.T) {
+	errDown := errors.New("inventory unavailable")
+	metrics := NewOpenMetricsSink()
+
+	var stats *ExecutionStats
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("order").
+		AddNamedHook("reserve", func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			return WithSeverity(Classify(errDown, ClassDownstream), SeverityCritical)
+		}).
+		WithStatsSink(metrics, StatsSinkFunc(func(s *ExecutionStats) { stats = s }))
+
+	_, err := pipeline.Execute(newMockContext(), &TestPayload{})
+	var pipeErr *PipeError
+	if !errors.As(err, &pipeErr) || pipeErr.Severity != SeverityCritical {
+		t.Fatalf("Expected critical PipeError, got %v", err)
+	}
+	if stats.ErrorClass != ClassDownstream || stats.Severity != SeverityCritical {
+		t.Errorf("Expected downstream/critical stats, got %s/%s", stats.ErrorClass, stats.Severity)
+	}
+	if stats.HookStats[0].Class != ClassDownstream {
+		t.Errorf("Expected hook stat class downstream, got %s", stats.HookStats[0].Class)
+	}
+
+	var buf strings.Builder
+	if err := WriteOpenMetrics(&buf, metrics.set.list()...); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`pipeline_failures_total{pipeline="order",class="downstream",severity="critical"} 1`,
+		`pipeline_hook_errors_by_class_total{pipeline="order",hook="reserve",class="downstream"} 1`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, buf.String())
+		}
+	}
+
+	if ClassTransient.Severity() != SeverityWarning || ClassPermanent.Severity() != SeverityError {
+		t.Error("Unexpected default severity")
+	}
+}
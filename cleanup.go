@@ -0,0 +1,65 @@
+package pipeline
+
+import (
+	"fmt"
+	"io"
+)
+
+// CleanupHookName 清理函数出错时传给 OnError 的 Hook 名称
+const CleanupHookName = "cleanup"
+
+// Defer 注册清理函数，管道结束后（成功、失败、中断或 panic）按后进先出顺序执行
+func (p *PipeContext[Option, Payload, Result]) Defer(fn func()) {
+	p.addCleanup(func() error {
+		fn()
+		return nil
+	})
+}
+
+// TrackCloser 注册需要在管道结束后关闭的资源，Close 错误通过 OnError 上报
+func (p *PipeContext[Option, Payload, Result]) TrackCloser(closer io.Closer) {
+	p.addCleanup(closer.Close)
+}
+
+// addCleanup 添加清理函数（并发安全）
+func (p *PipeContext[Option, Payload, Result]) addCleanup(fn func() error) {
+	p.state.mu.Lock()
+	defer p.state.mu.Unlock()
+	p.state.cleanups = append(p.state.cleanups, fn)
+}
+
+// runCleanups 按后进先出顺序执行清理函数，返回其中的错误
+// 单个清理函数 panic 不影响其余清理函数执行
+func (p *PipeContext[Option, Payload, Result]) runCleanups() []error {
+	p.state.mu.Lock()
+	cleanups := p.state.cleanups
+	p.state.cleanups = nil
+	p.state.mu.Unlock()
+
+	var errs []error
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		if err := safeCleanup(cleanups[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// safeCleanup 执行清理函数并将 panic 转换为错误
+func safeCleanup(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cleanup panic: %v", r)
+		}
+	}()
+	return fn()
+}
+
+// cleanup 执行清理函数并将错误交给 OnError 钩子
+func (p *Pipeline[C, Option, Payload, Result]) cleanup(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) {
+	for _, err := range pipeCtx.runCleanups() {
+		for _, errFn := range p.onError {
+			errFn(ctx, CleanupHookName, err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+type testCloser struct {
+	err    error
+	closed *[]string
+	name   string
+}
+
+func (c *testCloser) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return c.err
+}
+
+// TestCleanupOrder 测试清理函数按后进先出执行并上报错误
+func TestCleanupOrder(t *testing.T) {
+	var (
+		closed     []string
+		errHook    string
+		cleanupErr error
+	)
+	closeErr := errors.New("close failed")
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		OnError(func(ctx sylph.Context, hookName string, err error) {
+			errHook, cleanupErr = hookName, err
+		}).
+		AddHook(
+			func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+				pipeCtx.TrackCloser(&testCloser{name: "db", err: closeErr, closed: &closed})
+				pipeCtx.Defer(func() { closed = append(closed, "tmp") })
+				return nil
+			},
+			func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+				pipeCtx.Abort()
+				return nil
+			},
+		)
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(closed) != 2 || closed[0] != "tmp" || closed[1] != "db" {
+		t.Errorf("Expected cleanup order [tmp db], got %v", closed)
+	}
+	if errHook != CleanupHookName || !errors.Is(cleanupErr, closeErr) {
+		t.Errorf("Expected cleanup error reported, got %s: %v", errHook, cleanupErr)
+	}
+}
+
+// TestCleanupOnPanic 测试 Hook panic 时仍执行清理
+func TestCleanupOnPanic(t *testing.T) {
+	var cleaned bool
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			pipeCtx.Defer(func() { cleaned = true })
+			panic("boom")
+		})
+
+	func() {
+		defer func() { _ = recover() }()
+		_, _ = pipeline.Execute(newMockContext(), &TestPayload{})
+	}()
+
+	if !cleaned {
+		t.Error("Expected cleanup to run after panic")
+	}
+}
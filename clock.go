@@ -0,0 +1,110 @@
+package pipeline
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Clock 时间源，Hook 通过 pipeCtx.Now() 读取，测试和重放时可替换为固定时间
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc 函数形式的 Clock
+type ClockFunc func() time.Time
+
+// Now 实现 Clock
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock 系统时间（默认）
+var SystemClock Clock = ClockFunc(time.Now)
+
+// FixedClock 总是返回 t 的时间源
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// WithClock 设置 pipeCtx.Now() 使用的时间源（可被 ContextWithClock 按次覆盖）
+func (p *Pipeline[C, Option, Payload, Result]) WithClock(clock Clock) *Pipeline[C, Option, Payload, Result] {
+	p.clock = clock
+	return p
+}
+
+// WithSeed 固定 pipeCtx.Rand() 的随机种子，每次执行产生相同的随机序列（可被 ContextWithSeed 按次覆盖）
+// 未设置时每次执行随机生成种子，记录在 ExecutionStats.Seed 和 execution_started 事件中
+func (p *Pipeline[C, Option, Payload, Result]) WithSeed(seed uint64) *Pipeline[C, Option, Payload, Result] {
+	p.seed = &seed
+	return p
+}
+
+// clockKey ContextWithClock 使用的上下文键
+type clockKey struct{}
+
+// seedKey ContextWithSeed 使用的上下文键
+type seedKey struct{}
+
+// ContextWithClock 为使用该上下文的执行指定时间源（优先于 WithClock）
+func ContextWithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+// ContextWithSeed 为使用该上下文的执行指定随机种子（优先于 WithSeed），用于重放记录的执行
+func ContextWithSeed(ctx context.Context, seed uint64) context.Context {
+	return context.WithValue(ctx, seedKey{}, seed)
+}
+
+// executionClock 本次执行的时间源
+func (p *Pipeline[C, Option, Payload, Result]) executionClock(ctx C) Clock {
+	if clock, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return clock
+	}
+	if p.clock != nil {
+		return p.clock
+	}
+	return SystemClock
+}
+
+// executionSeed 本次执行的随机种子
+func (p *Pipeline[C, Option, Payload, Result]) executionSeed(ctx C) uint64 {
+	if seed, ok := ctx.Value(seedKey{}).(uint64); ok {
+		return seed
+	}
+	if p.seed != nil {
+		return *p.seed
+	}
+	return rand.Uint64()
+}
+
+// Now 返回本次执行时间源的当前时间（默认为系统时间）
+func (p *PipeContext[Option, Payload, Result]) Now() time.Time {
+	if p.state.clock == nil {
+		return time.Now()
+	}
+	return p.state.clock.Now()
+}
+
+// Rand 返回由本次执行的种子（ExecutionStats.Seed）确定的随机数生成器，种子相同时产生相同的序列。
+// 并发安全；并行分支交替取数时序列的分配取决于调度，需要可重现时应只在单个 Hook 中使用
+func (p *PipeContext[Option, Payload, Result]) Rand() *rand.Rand {
+	p.state.randOnce.Do(func() {
+		p.state.rand = rand.New(&lockedSource{src: rand.NewPCG(p.state.seed, p.state.seed)})
+	})
+	return p.state.rand
+}
+
+// lockedSource 并发安全的随机源（rand.Rand 本身没有其他状态）
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+// Uint64 实现 rand.Source
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
@@ -0,0 +1,72 @@
+package pipeline
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// newDicePipeline 创建使用 pipeCtx.Rand() 和 pipeCtx.Now() 的管道
+func newDicePipeline(stats **ExecutionStats) *SimplePipeline[TestPayload, TestResult] {
+	return NewSimplePipeline[TestPayload, TestResult]("dice").
+		AddHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			for i := 0; i < 3; i++ {
+				pipeCtx.Result.Output = append(pipeCtx.Result.Output, strconv.Itoa(pipeCtx.Rand().IntN(1000)))
+			}
+			pipeCtx.Result.Metadata = map[string]any{"at": pipeCtx.Now()}
+			return nil
+		}).
+		OnAfterExecute(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult], err error) {
+			*stats = pipeCtx.Stats()
+		})
+}
+
+// TestClockAndSeed 测试固定时间源与种子使执行可重现
+func TestClockAndSeed(t *testing.T) {
+	var stats *ExecutionStats
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	pipeline := newDicePipeline(&stats).WithClock(FixedClock(at)).WithSeed(42)
+
+	first, err := pipeline.Execute(WrapContext(context.Background()), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, _ := pipeline.Execute(WrapContext(context.Background()), &TestPayload{})
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected identical results, got %v and %v", first, second)
+	}
+	if first.Metadata["at"] != at || stats.Seed != 42 {
+		t.Errorf("Unexpected time %v or seed %d", first.Metadata["at"], stats.Seed)
+	}
+}
+
+// TestContextWithSeed 测试按记录的种子重放执行
+func TestContextWithSeed(t *testing.T) {
+	var stats *ExecutionStats
+	store := NewMemoryEventStore()
+	pipeline := newDicePipeline(&stats).WithEventStore(store)
+
+	original, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	events, _ := store.Query(context.Background(), EventQuery{
+		ExecutionID: stats.ExecutionID,
+		Types:       []ExecutionEventType{EventExecutionStarted},
+	})
+	if len(events) != 1 || events[0].Seed != stats.Seed {
+		t.Fatalf("Expected seed %d in start event, got %+v", stats.Seed, events)
+	}
+
+	at := events[0].At
+	ctx := ContextWithClock(ContextWithSeed(context.Background(), events[0].Seed), FixedClock(at))
+	replayed, err := pipeline.ExecuteStd(ctx, &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(replayed.Output, original.Output) || replayed.Metadata["at"] != at {
+		t.Errorf("Expected replay to reproduce %v, got %v at %v", original.Output, replayed.Output, replayed.Metadata["at"])
+	}
+}
@@ -0,0 +1,85 @@
+package pipeline
+
+import "reflect"
+
+// DeepCopy 基于反射深拷贝值
+// 支持指针、切片、数组、map、结构体（仅深拷贝导出字段，未导出字段按值复制）
+// 保留指针共享关系和循环引用；函数、通道等按引用复制
+func DeepCopy[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+
+	dst := new(T)
+	visited := make(map[uintptr]reflect.Value)
+	copyValue(reflect.ValueOf(dst).Elem(), reflect.ValueOf(v).Elem(), visited)
+	return dst
+}
+
+// copyValue 将 src 深拷贝到 dst（dst 必须可设置）
+func copyValue(dst, src reflect.Value, visited map[uintptr]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		if cp, ok := visited[src.Pointer()]; ok {
+			dst.Set(cp)
+			return
+		}
+		cp := reflect.New(src.Type().Elem())
+		visited[src.Pointer()] = cp
+		copyValue(cp.Elem(), src.Elem(), visited)
+		dst.Set(cp)
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		cp := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			copyValue(cp.Index(i), src.Index(i), visited)
+		}
+		dst.Set(cp)
+
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i), visited)
+		}
+
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		cp := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			val := reflect.New(src.Type().Elem()).Elem()
+			copyValue(val, iter.Value(), visited)
+			cp.SetMapIndex(iter.Key(), val)
+		}
+		dst.Set(cp)
+
+	case reflect.Struct:
+		// 先整体复制（包含未导出字段），再逐个深拷贝导出字段
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if !src.Type().Field(i).IsExported() {
+				continue
+			}
+			copyValue(dst.Field(i), src.Field(i), visited)
+		}
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := src.Elem()
+		cp := reflect.New(elem.Type()).Elem()
+		copyValue(cp, elem, visited)
+		dst.Set(cp)
+
+	default:
+		dst.Set(src)
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Config 代码生成配置
+type Config struct {
+	Package string   // 生成文件的包名
+	Type    string   // 管道名称前缀（如 Order，生成 OrderPipeline、OrderHookValidate）
+	Context string   // 上下文类型（默认 pipe.Context）
+	Option  string   // Option 类型（默认 pipe.NoOption）
+	Payload string   // Payload 类型
+	Result  string   // Result 类型
+	Hooks   []string // 按执行顺序排列的 Hook 函数名
+	Keys    []string // 共享数据键
+	Imports []string // 类型所需的额外导入路径
+
+	TestContext string // 测试骨架中构造上下文的表达式（默认 pipe.WrapContext(context.Background())）
+}
+
+// constant 生成的常量
+type constant struct {
+	Name  string
+	Value string
+}
+
+// Generate 生成强类型管道构造代码（已 gofmt）
+func Generate(cfg Config) ([]byte, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Config
+		HookConsts []constant
+		KeyConsts  []constant
+		Hooks      []constant // Name 为常量名，Value 为函数名
+	}{Config: cfg}
+
+	for _, fn := range cfg.Hooks {
+		name := cfg.Type + "Hook" + exportName(fn)
+		data.HookConsts = append(data.HookConsts, constant{Name: name, Value: kebabCase(fn)})
+		data.Hooks = append(data.Hooks, constant{Name: name, Value: fn})
+	}
+	for _, key := range cfg.Keys {
+		data.KeyConsts = append(data.KeyConsts, constant{Name: cfg.Type + "Key" + exportName(key), Value: key})
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// GenerateTests 为每个 Hook 生成表驱动测试骨架（已 gofmt）
+// 骨架用于手工补充用例，生成后由使用者维护
+func GenerateTests(cfg Config) ([]byte, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if len(cfg.Hooks) == 0 {
+		return nil, fmt.Errorf("-hooks is required to generate tests")
+	}
+
+	data := struct {
+		Config
+		StdContext bool
+		Hooks      []constant // Name 为测试函数名，Value 为 Hook 函数名
+	}{Config: cfg}
+
+	if data.TestContext == "" {
+		if cfg.Context != "pipe.Context" {
+			return nil, fmt.Errorf("-testctx is required when -context is %s", cfg.Context)
+		}
+		data.TestContext = "pipe.WrapContext(context.Background())"
+		data.StdContext = true
+	}
+	for _, fn := range cfg.Hooks {
+		data.Hooks = append(data.Hooks, constant{Name: "Test" + exportName(fn), Value: fn})
+	}
+
+	var buf bytes.Buffer
+	if err := testTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated tests: %w", err)
+	}
+	return src, nil
+}
+
+// validate 校验并补全默认值
+func (cfg *Config) validate() error {
+	if cfg.Context == "" {
+		cfg.Context = "pipe.Context"
+	}
+	if cfg.Option == "" {
+		cfg.Option = "pipe.NoOption"
+	}
+
+	switch {
+	case cfg.Package == "":
+		return fmt.Errorf("package is required (run via go:generate or pass -package)")
+	case !token.IsIdentifier(cfg.Type):
+		return fmt.Errorf("invalid -type %q", cfg.Type)
+	case cfg.Payload == "" || cfg.Result == "":
+		return fmt.Errorf("-payload and -result are required")
+	}
+
+	seen := make(map[string]bool)
+	for _, fn := range cfg.Hooks {
+		if !token.IsIdentifier(fn) {
+			return fmt.Errorf("invalid hook function %q", fn)
+		}
+		if seen[exportName(fn)] {
+			return fmt.Errorf("duplicate hook %q", fn)
+		}
+		seen[exportName(fn)] = true
+	}
+	for _, key := range cfg.Keys {
+		if exportName(key) == "" {
+			return fmt.Errorf("invalid data key %q", key)
+		}
+	}
+	return nil
+}
+
+// exportName 将名称转换为导出标识符片段（user_id -> UserId，order.total -> OrderTotal）
+func exportName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// kebabCase 将函数名转换为 Hook 名称（ValidateOrder -> validate-order）
+func kebabCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by pipelinegen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	pipe "github.com/sylphbyte/pipeline"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{if .HookConsts}}
+// {{.Type}} 管道的 Hook 名称
+const (
+{{- range .HookConsts}}
+	{{.Name}} = "{{.Value}}"
+{{- end}}
+)
+{{end}}{{if .KeyConsts}}
+// {{.Type}} 管道的共享数据键
+const (
+{{- range .KeyConsts}}
+	{{.Name}} = "{{.Value}}"
+{{- end}}
+)
+{{end}}
+// {{.Type}}Pipeline {{.Type}} 管道类型
+type {{.Type}}Pipeline = pipe.Pipeline[{{.Context}}, {{.Option}}, {{.Payload}}, {{.Result}}]
+
+// {{.Type}}Context {{.Type}} 管道的 PipeContext 类型
+type {{.Type}}Context = pipe.PipeContext[{{.Option}}, {{.Payload}}, {{.Result}}]
+
+// {{.Type}}Handler {{.Type}} 管道的 Hook 函数类型
+type {{.Type}}Handler = pipe.HookHandler[{{.Context}}, {{.Option}}, {{.Payload}}, {{.Result}}]
+
+// New{{.Type}}Pipeline 创建 {{.Type}} 管道{{if .Hooks}}，按声明顺序注册 Hook{{end}}
+func New{{.Type}}Pipeline(name string) *{{.Type}}Pipeline {
+	return pipe.NewPipeline[{{.Context}}, {{.Option}}, {{.Payload}}, {{.Result}}](name){{range .Hooks}}.
+		AddNamedHook({{.Name}}, {{.Value}}){{end}}
+}
+`))
+
+var testTemplate = template.Must(template.New("test").Parse(`// Code generated by pipelinegen as a starting point; edit freely.
+
+package {{.Package}}
+
+import (
+{{- if .StdContext}}
+	"context"
+{{- end}}
+	"reflect"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{range .Hooks}}
+func {{.Name}}(t *testing.T) {
+	tests := []struct {
+		name     string
+		option   {{$.Option}}
+		payload  {{$.Payload}}
+		data     map[string]any // 执行前写入的共享数据
+		want     {{$.Result}}
+		wantData map[string]any // 执行后期望的共享数据
+		wantErr  bool
+	}{
+		// TODO: 添加测试用例
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := {{$.TestContext}}
+			pc := pipe.NewPipeContext[{{$.Option}}, {{$.Payload}}, {{$.Result}}]("{{.Value}}", &tt.option, &tt.payload, nil)
+			for key, value := range tt.data {
+				pc.Set(key, value)
+			}
+
+			err := {{.Value}}(ctx, pc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("{{.Value}}() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(*pc.Result, tt.want) {
+				t.Errorf("result = %+v, want %+v", *pc.Result, tt.want)
+			}
+			for key, want := range tt.wantData {
+				if got, _ := pc.Get(key); !reflect.DeepEqual(got, want) {
+					t.Errorf("data[%q] = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+{{end}}`))
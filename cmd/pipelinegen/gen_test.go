@@ -0,0 +1,120 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate(Config{
+		Package: "orders",
+		Type:    "Order",
+		Payload: "OrderPayload",
+		Result:  "OrderResult",
+		Hooks:   []string{"ValidateOrder", "enrichUser"},
+		Keys:    []string{"user", "order.total"},
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "order_pipeline_gen.go", src, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, src)
+	}
+
+	code := string(src)
+	for _, want := range []string{
+		"// Code generated by pipelinegen. DO NOT EDIT.",
+		"package orders",
+		`OrderHookValidateOrder = "validate-order"`,
+		`OrderHookEnrichUser    = "enrich-user"`,
+		`OrderKeyUser       = "user"`,
+		`OrderKeyOrderTotal = "order.total"`,
+		"type OrderPipeline = pipe.Pipeline[pipe.Context, pipe.NoOption, OrderPayload, OrderResult]",
+		"AddNamedHook(OrderHookValidateOrder, ValidateOrder)",
+		"AddNamedHook(OrderHookEnrichUser, enrichUser)",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q\n%s", want, code)
+		}
+	}
+}
+
+func TestGenerateInvalidConfig(t *testing.T) {
+	base := Config{Package: "orders", Type: "Order", Payload: "P", Result: "R"}
+
+	cases := map[string]func(*Config){
+		"no package":     func(c *Config) { c.Package = "" },
+		"bad type":       func(c *Config) { c.Type = "1Order" },
+		"no payload":     func(c *Config) { c.Payload = "" },
+		"bad hook":       func(c *Config) { c.Hooks = []string{"pkg.Hook"} },
+		"duplicate hook": func(c *Config) { c.Hooks = []string{"validate", "Validate"} },
+		"bad key":        func(c *Config) { c.Keys = []string{"--"} },
+	}
+	for name, mutate := range cases {
+		cfg := base
+		mutate(&cfg)
+		if _, err := Generate(cfg); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestKebabCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"Validate":      "validate",
+		"enrichUser":    "enrich-user",
+		"LoadHTTPCache": "load-http-cache",
+	} {
+		if got := kebabCase(in); got != want {
+			t.Errorf("kebabCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerateTests(t *testing.T) {
+	cfg := Config{
+		Package: "orders",
+		Type:    "Order",
+		Payload: "OrderPayload",
+		Result:  "OrderResult",
+		Hooks:   []string{"Validate", "enrichUser"},
+	}
+
+	src, err := GenerateTests(cfg)
+	if err != nil {
+		t.Fatalf("GenerateTests: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "order_hooks_test.go", src, 0); err != nil {
+		t.Fatalf("generated tests do not parse: %v\n%s", err, src)
+	}
+
+	code := string(src)
+	for _, want := range []string{
+		"func TestValidate(t *testing.T)",
+		"func TestEnrichUser(t *testing.T)",
+		"err := enrichUser(ctx, pc)",
+		"ctx := pipe.WrapContext(context.Background())",
+		`"context"`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated tests missing %q\n%s", want, code)
+		}
+	}
+
+	cfg.Context = "sylph.Context"
+	if _, err := GenerateTests(cfg); err == nil {
+		t.Error("expected error for custom context without -testctx")
+	}
+
+	cfg.TestContext = "newTestContext()"
+	src, err = GenerateTests(cfg)
+	if err != nil {
+		t.Fatalf("GenerateTests with -testctx: %v", err)
+	}
+	if code := string(src); !strings.Contains(code, "ctx := newTestContext()") || strings.Contains(code, `"context"`) {
+		t.Errorf("unexpected custom context scaffolding\n%s", code)
+	}
+}
@@ -0,0 +1,121 @@
+// Command pipelinegen 为管道生成强类型的构造代码：类型别名、Hook 名称和共享数据键常量，
+// 以及按声明顺序注册 Hook 的构造函数，减少大型代码库中的泛型样板代码。
+// 指定 -tests 时同时为每个 Hook 生成表驱动测试骨架。
+//
+// 通过 go:generate 使用：
+//
+//	//go:generate go run github.com/sylphbyte/pipeline/cmd/pipelinegen -type Order -payload OrderPayload -result OrderResult -hooks Validate,Enrich,Persist -keys user,total
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+)
+
+func main() {
+	var (
+		cfg     Config
+		hooks   string
+		keys    string
+		imports string
+		output  string
+		tests   bool
+	)
+	flag.StringVar(&cfg.Package, "package", os.Getenv("GOPACKAGE"), "生成文件的包名（默认 $GOPACKAGE）")
+	flag.StringVar(&cfg.Type, "type", "", "管道名称前缀，如 Order")
+	flag.StringVar(&cfg.Context, "context", "pipe.Context", "上下文类型")
+	flag.StringVar(&cfg.Option, "option", "pipe.NoOption", "Option 类型")
+	flag.StringVar(&cfg.Payload, "payload", "", "Payload 类型")
+	flag.StringVar(&cfg.Result, "result", "", "Result 类型")
+	flag.StringVar(&hooks, "hooks", "", "按执行顺序排列的 Hook 函数名，逗号分隔")
+	flag.StringVar(&keys, "keys", "", "共享数据键，逗号分隔")
+	flag.StringVar(&imports, "imports", "", "类型所需的额外导入路径，逗号分隔（如 github.com/sylphbyte/sylph）")
+	flag.StringVar(&output, "output", "", "输出文件（默认 <type>_pipeline_gen.go）")
+	flag.BoolVar(&tests, "tests", false, "同时为每个 Hook 生成表驱动测试骨架（<type>_hooks_test.go，已存在时跳过）")
+	flag.StringVar(&cfg.TestContext, "testctx", "", "测试骨架中构造上下文的表达式（自定义 -context 时必填）")
+	flag.Parse()
+
+	cfg.Hooks = splitList(hooks)
+	cfg.Keys = splitList(keys)
+	cfg.Imports = splitList(imports)
+	if output == "" {
+		output = strings.ToLower(cfg.Type) + "_pipeline_gen.go"
+	}
+
+	if err := checkHooks(".", cfg.Hooks); err != nil {
+		fail(err)
+	}
+	src, err := Generate(cfg)
+	if err != nil {
+		fail(err)
+	}
+	if err := os.WriteFile(output, src, 0o644); err != nil {
+		fail(err)
+	}
+
+	if tests {
+		if err := writeTests(cfg, strings.ToLower(cfg.Type)+"_hooks_test.go"); err != nil {
+			fail(err)
+		}
+	}
+}
+
+// writeTests 生成测试骨架；文件已存在时不覆盖（骨架生成后由使用者维护）
+func writeTests(cfg Config, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		fmt.Fprintf(os.Stderr, "pipelinegen: %s exists, skipping test scaffolding\n", path)
+		return nil
+	}
+
+	src, err := GenerateTests(cfg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, src, 0o644)
+}
+
+// checkHooks 确认 Hook 函数在当前目录的包中声明，尽早发现拼写错误
+func checkHooks(dir string, hooks []string) error {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return err
+	}
+
+	declared := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+					declared[fn.Name.Name] = true
+				}
+			}
+		}
+	}
+	for _, hook := range hooks {
+		if !declared[hook] {
+			return fmt.Errorf("hook function %s not found in package", hook)
+		}
+	}
+	return nil
+}
+
+// splitList 解析逗号分隔的列表
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "pipelinegen:", err)
+	os.Exit(1)
+}
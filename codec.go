@@ -0,0 +1,131 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Codec Payload/Result 的序列化编解码器
+// 供检查点、录制回放、死信等需要持久化执行数据的场景使用
+type Codec[T any] interface {
+	Encode(v *T) ([]byte, error)
+	Decode(data []byte) (*T, error)
+}
+
+// Migration 将旧版本的数据升级到下一个版本
+type Migration func(data json.RawMessage) (json.RawMessage, error)
+
+// envelope 带版本标记的序列化外壳
+type envelope struct {
+	Type    string          `json:"type"`
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// JSONCodec 带类型与版本标记的 JSON 编解码器
+// 解码时校验类型名，旧版本数据依次经过注册的 Migration 升级到当前版本
+type JSONCodec[T any] struct {
+	version    int
+	strict     bool
+	migrations map[int]Migration
+}
+
+// NewJSONCodec 创建 JSON 编解码器，version 为当前结构体版本
+func NewJSONCodec[T any](version int) *JSONCodec[T] {
+	return &JSONCodec[T]{
+		version:    version,
+		migrations: make(map[int]Migration),
+	}
+}
+
+// Strict 解码时拒绝未知字段
+func (c *JSONCodec[T]) Strict() *JSONCodec[T] {
+	c.strict = true
+	return c
+}
+
+// WithMigration 注册从 from 版本升级到 from+1 版本的迁移函数
+func (c *JSONCodec[T]) WithMigration(from int, fn Migration) *JSONCodec[T] {
+	c.migrations[from] = fn
+	return c
+}
+
+// Version 当前版本
+func (c *JSONCodec[T]) Version() int {
+	return c.version
+}
+
+// Encode 实现 Codec
+func (c *JSONCodec[T]) Encode(v *T) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope{Type: typeName[T](), Version: c.version, Data: data})
+}
+
+// Decode 实现 Codec
+func (c *JSONCodec[T]) Decode(data []byte) (*T, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, err
+	}
+
+	if env.Type != typeName[T]() {
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrSchemaMismatch, typeName[T](), env.Type)
+	}
+	if env.Version > c.version {
+		return nil, fmt.Errorf("%w: %s version %d is newer than %d", ErrSchemaMismatch, env.Type, env.Version, c.version)
+	}
+
+	raw := env.Data
+	for v := env.Version; v < c.version; v++ {
+		migrate, ok := c.migrations[v]
+		if !ok {
+			return nil, fmt.Errorf("%w: no migration for %s version %d", ErrSchemaMismatch, env.Type, v)
+		}
+
+		var err error
+		if raw, err = migrate(raw); err != nil {
+			return nil, fmt.Errorf("migrate %s from version %d: %w", env.Type, v, err)
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if c.strict {
+		dec.DisallowUnknownFields()
+	}
+
+	out := new(T)
+	if err := dec.Decode(out); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSchemaMismatch, err)
+	}
+	return out, nil
+}
+
+// typeName 序列化使用的类型名
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// WithCodecs 注册 Payload 和 Result 的编解码器（nil 表示不设置）
+func (p *Pipeline[C, Option, Payload, Result]) WithCodecs(
+	payload Codec[Payload],
+	result Codec[Result],
+) *Pipeline[C, Option, Payload, Result] {
+	p.payloadCodec = payload
+	p.resultCodec = result
+	return p
+}
+
+// PayloadCodec 获取 Payload 编解码器，未注册时返回 nil
+func (p *Pipeline[C, Option, Payload, Result]) PayloadCodec() Codec[Payload] {
+	return p.payloadCodec
+}
+
+// ResultCodec 获取 Result 编解码器，未注册时返回 nil
+func (p *Pipeline[C, Option, Payload, Result]) ResultCodec() Codec[Result] {
+	return p.resultCodec
+}
@@ -0,0 +1,44 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// TestJSONCodecMigration 测试版本化 JSON 编解码与迁移
+func TestJSONCodecMigration(t *testing.T) {
+	v1 := []byte(`{"type":"pipeline.TestPayload","version":1,"data":{"UserID":7,"Body":"x"}}`)
+
+	codec := NewJSONCodec[TestPayload](2).
+		Strict().
+		WithMigration(1, func(data json.RawMessage) (json.RawMessage, error) {
+			var old map[string]any
+			if err := json.Unmarshal(data, &old); err != nil {
+				return nil, err
+			}
+			old["Data"] = old["Body"]
+			delete(old, "Body")
+			return json.Marshal(old)
+		})
+
+	payload, err := codec.Decode(v1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if payload.UserID != 7 || payload.Data != "x" {
+		t.Errorf("Expected migrated payload, got %+v", payload)
+	}
+
+	data, err := codec.Encode(payload)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if roundTrip, err := codec.Decode(data); err != nil || *roundTrip != *payload {
+		t.Errorf("Expected round trip, got %+v, %v", roundTrip, err)
+	}
+
+	if _, err := NewJSONCodec[TestResult](1).Decode(data); !errors.Is(err, ErrSchemaMismatch) {
+		t.Errorf("Expected ErrSchemaMismatch for wrong type, got %v", err)
+	}
+}
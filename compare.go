@@ -0,0 +1,103 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RegressionKind 回归类型
+type RegressionKind string
+
+const (
+	RegressionLatency   RegressionKind = "latency"    // 平均耗时回归
+	RegressionErrorRate RegressionKind = "error_rate" // 错误率回归
+)
+
+// Thresholds 回归判定阈值
+type Thresholds struct {
+	LatencyIncrease float64 // 平均耗时相对增幅（0.2 表示增加 20%）
+	ErrorRateDelta  float64 // 错误率绝对增量（0.01 表示增加 1 个百分点）
+	MinCalls        int     // 基线与当前执行次数均不少于该值才参与比较
+}
+
+// DefaultThresholds CompareStats 使用的默认阈值
+var DefaultThresholds = Thresholds{
+	LatencyIncrease: 0.2,
+	ErrorRateDelta:  0.01,
+	MinCalls:        10,
+}
+
+// Regression 单个 Hook 的回归
+type Regression struct {
+	Hook     string         // Hook 名称
+	Kind     RegressionKind // 回归类型
+	Baseline float64        // 基线值（耗时为毫秒，错误率为 0~1）
+	Current  float64        // 当前值
+}
+
+func (r Regression) String() string {
+	if r.Kind == RegressionLatency {
+		return fmt.Sprintf("%s: latency %.2fms -> %.2fms", r.Hook, r.Baseline, r.Current)
+	}
+	return fmt.Sprintf("%s: error rate %.2f%% -> %.2f%%", r.Hook, r.Baseline*100, r.Current*100)
+}
+
+// Report 统计对比报告
+type Report struct {
+	Regressions []Regression // 按 Hook 名称排序
+}
+
+// Regressed 是否存在回归
+func (r Report) Regressed() bool {
+	return len(r.Regressions) > 0
+}
+
+func (r Report) String() string {
+	if !r.Regressed() {
+		return "no regressions"
+	}
+	lines := make([]string, len(r.Regressions))
+	for i, reg := range r.Regressions {
+		lines[i] = reg.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CompareStats 使用 DefaultThresholds 对比基线与当前统计，找出耗时或错误率回归的 Hook
+// 用于发布新版本管道后的金丝雀分析
+func CompareStats(baseline, current *AggregateStats) Report {
+	return CompareStatsWith(baseline, current, DefaultThresholds)
+}
+
+// CompareStatsWith 使用指定阈值对比统计，仅比较两边都存在的 Hook
+func CompareStatsWith(baseline, current *AggregateStats, th Thresholds) Report {
+	var report Report
+
+	for _, cur := range current.Hooks() {
+		base, ok := baseline.Hook(cur.Name)
+		if !ok || base.Calls < th.MinCalls || cur.Calls < th.MinCalls {
+			continue
+		}
+
+		baseMean, curMean := base.MeanDuration(), cur.MeanDuration()
+		if float64(curMean) > float64(baseMean)*(1+th.LatencyIncrease) {
+			report.Regressions = append(report.Regressions, Regression{
+				Hook:     cur.Name,
+				Kind:     RegressionLatency,
+				Baseline: millis(baseMean),
+				Current:  millis(curMean),
+			})
+		}
+
+		if cur.ErrorRate()-base.ErrorRate() > th.ErrorRateDelta {
+			report.Regressions = append(report.Regressions, Regression{
+				Hook:     cur.Name,
+				Kind:     RegressionErrorRate,
+				Baseline: base.ErrorRate(),
+				Current:  cur.ErrorRate(),
+			})
+		}
+	}
+
+	return report
+}
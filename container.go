@@ -0,0 +1,260 @@
+package pipeline
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Container 轻量级依赖注入容器
+// 通过构造函数注册依赖（仓储、客户端等），在构建管道时按类型解析，
+// 每个类型只构造一次（单例），避免 Hook 以闭包形式引用全局变量。
+type Container struct {
+	mu        sync.Mutex // 只保护 providers，构造函数执行时不持有
+	providers map[reflect.Type]*provider
+}
+
+// provider 单个类型的构造信息
+// mu 只保护状态字段，构造函数执行时不持有：构造中的类型记录构造者 goroutine，
+// 同一 goroutine 再次解析视为循环依赖，其他 goroutine 等待 done 后重新检查。
+// 构造函数 panic 或失败时未标记 built，下次解析会重试
+type provider struct {
+	mu          sync.Mutex
+	constructor reflect.Value
+	instance    reflect.Value
+	built       bool
+	builder     uint64        // 正在构造的 goroutine（0 表示未在构造）
+	done        chan struct{} // 本轮构造结束时关闭
+}
+
+// NewContainer 创建依赖注入容器
+func NewContainer() *Container {
+	return &Container{
+		providers: make(map[reflect.Type]*provider),
+	}
+}
+
+// Provide 注册构造函数
+// 构造函数签名为 func(deps...) T 或 func(deps...) (T, error)，参数由容器按类型解析
+func (c *Container) Provide(constructor any) error {
+	fnType := reflect.TypeOf(constructor)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return fmt.Errorf("constructor must be a function, got %T", constructor)
+	}
+
+	switch {
+	case fnType.NumOut() == 1 && fnType.Out(0) != errorType:
+	case fnType.NumOut() == 2 && fnType.Out(1) == errorType:
+	default:
+		return fmt.Errorf("constructor %s must return T or (T, error)", fnType)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	outType := fnType.Out(0)
+	if _, exists := c.providers[outType]; exists {
+		return fmt.Errorf("type %s already provided", outType)
+	}
+
+	c.providers[outType] = &provider{constructor: reflect.ValueOf(constructor)}
+	return nil
+}
+
+// Invoke 解析函数参数并调用
+// 函数的最后一个返回值若为 error 且非 nil，则作为 Invoke 的错误返回
+func (c *Container) Invoke(fn any) error {
+	_, err := c.call(fn)
+	return err
+}
+
+// Resolve 按类型从容器中解析依赖
+func Resolve[T any](c *Container) (T, error) {
+	var zero T
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	val, err := c.resolve(t, nil)
+	if err != nil {
+		return zero, err
+	}
+
+	v, ok := val.Interface().(T)
+	if !ok {
+		return zero, fmt.Errorf("provider for type %s returned nil", t)
+	}
+	return v, nil
+}
+
+// call 解析参数并调用函数，返回去除末尾 error 后的结果
+func (c *Container) call(fn any) ([]reflect.Value, error) {
+	fnVal := reflect.ValueOf(fn)
+	if !fnVal.IsValid() || fnVal.Kind() != reflect.Func {
+		return nil, fmt.Errorf("invoke target must be a function, got %T", fn)
+	}
+
+	args, err := c.resolveArgs(fnVal.Type(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := fnVal.Call(args)
+	if n := len(out); n > 0 && fnVal.Type().Out(n-1) == errorType {
+		if errVal := out[n-1]; !errVal.IsNil() {
+			return nil, errVal.Interface().(error)
+		}
+		out = out[:n-1]
+	}
+
+	return out, nil
+}
+
+// resolveArgs 解析函数的所有参数
+func (c *Container) resolveArgs(fnType reflect.Type, stack []reflect.Type) ([]reflect.Value, error) {
+	args := make([]reflect.Value, fnType.NumIn())
+	for i := range args {
+		arg, err := c.resolve(fnType.In(i), stack)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+// resolve 解析单个类型
+// 循环依赖按解析栈检测；构造函数内再次调用 Resolve/Invoke 时解析栈丢失，
+// 由构造者 goroutine 检测，返回循环依赖错误而不是死锁
+func (c *Container) resolve(t reflect.Type, stack []reflect.Type) (reflect.Value, error) {
+	c.mu.Lock()
+	p, ok := c.providers[t]
+	c.mu.Unlock()
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("no provider for type %s", t)
+	}
+
+	for _, s := range stack {
+		if s == t {
+			return reflect.Value{}, fmt.Errorf("dependency cycle detected at type %s", t)
+		}
+	}
+
+	id := goroutineID()
+	for {
+		p.mu.Lock()
+		if p.built {
+			p.mu.Unlock()
+			return p.instance, nil
+		}
+		if p.builder == 0 {
+			p.builder, p.done = id, make(chan struct{})
+			p.mu.Unlock()
+			break
+		}
+		builder, done := p.builder, p.done
+		p.mu.Unlock()
+
+		if builder == id {
+			return reflect.Value{}, fmt.Errorf("dependency cycle detected at type %s", t)
+		}
+		<-done
+	}
+
+	return p.build(c, t, stack)
+}
+
+// build 在不持有锁的情况下执行构造函数，结束时（包括 panic）唤醒等待者
+func (p *provider) build(c *Container, t reflect.Type, stack []reflect.Type) (instance reflect.Value, err error) {
+	defer func() {
+		p.mu.Lock()
+		if err == nil && instance.IsValid() {
+			p.instance, p.built = instance, true
+		}
+		p.builder = 0
+		close(p.done)
+		p.mu.Unlock()
+	}()
+
+	args, err := c.resolveArgs(p.constructor.Type(), append(stack, t))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	out := p.constructor.Call(args)
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, fmt.Errorf("construct %s: %w", t, out[1].Interface().(error))
+	}
+	return out[0], nil
+}
+
+// goroutineID 当前 goroutine 的编号，只用于识别构造函数内的重入
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
+
+// Container 获取管道的依赖注入容器（首次调用时创建）
+func (p *Pipeline[C, Option, Payload, Result]) Container() *Container {
+	p.containerOnce.Do(func() {
+		p.container = NewContainer()
+	})
+	return p.container
+}
+
+// Provide 向管道容器注册构造函数
+// 构造函数不合法时 panic（属于构建期的编程错误）
+func (p *Pipeline[C, Option, Payload, Result]) Provide(
+	constructors ...any,
+) *Pipeline[C, Option, Payload, Result] {
+	for _, constructor := range constructors {
+		if err := p.Container().Provide(constructor); err != nil {
+			panic("pipeline '" + p.Name + "': " + err.Error())
+		}
+	}
+	return p
+}
+
+// Invoke 使用管道容器解析参数并调用函数
+func (p *Pipeline[C, Option, Payload, Result]) Invoke(fn any) error {
+	return p.Container().Invoke(fn)
+}
+
+// AddInjectedHook 添加依赖注入的 Hook
+// factory 签名为 func(deps...) HookHandler 或 func(deps...) (HookHandler, error)，
+// 在添加时立即解析依赖并构建 Handler，解析失败时 panic
+func (p *Pipeline[C, Option, Payload, Result]) AddInjectedHook(
+	name string,
+	factory any,
+) *Pipeline[C, Option, Payload, Result] {
+	out, err := p.Container().call(factory)
+	if err == nil && len(out) != 1 {
+		err = errors.New("hook factory must return a single HookHandler")
+	}
+	if err != nil {
+		panic("pipeline '" + p.Name + "': inject hook '" + name + "': " + err.Error())
+	}
+
+	handler, ok := out[0].Interface().(HookHandler[C, Option, Payload, Result])
+	if !ok {
+		if fn, isFn := out[0].Interface().(func(C, *PipeContext[Option, Payload, Result]) error); isFn {
+			handler, ok = fn, true
+		}
+	}
+	if !ok {
+		panic(fmt.Sprintf("pipeline '%s': inject hook '%s': factory returned %s, want HookHandler",
+			p.Name, name, out[0].Type()))
+	}
+
+	return p.AddNamedHook(name, handler)
+}
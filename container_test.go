@@ -0,0 +1,189 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+type testRepo struct {
+	prefix string
+}
+
+type testService struct {
+	repo *testRepo
+}
+
+// TestAddInjectedHook 测试依赖注入的 Hook
+func TestAddInjectedHook(t *testing.T) {
+	var repoBuilds int
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		Provide(
+			func() *testRepo {
+				repoBuilds++
+				return &testRepo{prefix: "repo:"}
+			},
+			func(repo *testRepo) (*testService, error) {
+				return &testService{repo: repo}, nil
+			},
+		).
+		AddInjectedHook("process", func(svc *testService) HookHandler[sylph.Context, TestOption, TestPayload, TestResult] {
+			return func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+				pipeCtx.Result.Output = append(pipeCtx.Result.Output, svc.repo.prefix+pipeCtx.Payload.Data)
+				return nil
+			}
+		})
+
+	result, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1, Data: "x"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.Output) != 1 || result.Output[0] != "repo:x" {
+		t.Errorf("Expected output [repo:x], got %v", result.Output)
+	}
+
+	if err := pipeline.Invoke(func(repo *testRepo, svc *testService) {
+		if svc.repo != repo {
+			t.Error("Expected singleton repo instance")
+		}
+	}); err != nil {
+		t.Fatalf("Unexpected invoke error: %v", err)
+	}
+
+	if repoBuilds != 1 {
+		t.Errorf("Expected repo built once, got %d", repoBuilds)
+	}
+}
+
+// TestContainerErrors 测试容器错误
+func TestContainerErrors(t *testing.T) {
+	c := NewContainer()
+
+	if err := c.Provide("not a function"); err == nil {
+		t.Error("Expected error for non-function constructor")
+	}
+
+	if _, err := Resolve[*testRepo](c); err == nil {
+		t.Error("Expected error for missing provider")
+	}
+
+	buildErr := errors.New("connect failed")
+	_ = c.Provide(func() (*testRepo, error) { return nil, buildErr })
+	if _, err := Resolve[*testRepo](c); !errors.Is(err, buildErr) {
+		t.Errorf("Expected constructor error, got %v", err)
+	}
+
+	_ = c.Provide(func(svc *testService) int { return 0 })
+	_ = c.Provide(func(n int) *testService { return nil })
+	if _, err := Resolve[int](c); err == nil {
+		t.Error("Expected dependency cycle error")
+	}
+}
+
+// TestContainerConstructorReentry 测试构造函数 panic 后容器仍可用，且构造函数内可再次解析
+func TestContainerConstructorReentry(t *testing.T) {
+	c := NewContainer()
+
+	attempts := 0
+	_ = c.Provide(func() *testRepo {
+		attempts++
+		if attempts == 1 {
+			panic("first build fails")
+		}
+		return &testRepo{prefix: "repo:"}
+	})
+	_ = c.Provide(func() (*testService, error) {
+		repo, err := Resolve[*testRepo](c)
+		return &testService{repo: repo}, err
+	})
+
+	func() {
+		defer func() { _ = recover() }()
+		_, _ = Resolve[*testRepo](c)
+	}()
+
+	svc, err := Resolve[*testService](c)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if svc.repo == nil || svc.repo.prefix != "repo:" || attempts != 2 {
+		t.Errorf("Expected repo rebuilt after panic, got %+v (attempts %d)", svc.repo, attempts)
+	}
+}
+
+// TestContainerResolveCycle 测试构造函数内通过 Resolve 形成的循环依赖返回错误而不是死锁
+func TestContainerResolveCycle(t *testing.T) {
+	c := NewContainer()
+	_ = c.Provide(func() (*testRepo, error) {
+		_, err := Resolve[*testService](c)
+		return &testRepo{}, err
+	})
+	_ = c.Provide(func() (*testService, error) {
+		_, err := Resolve[*testRepo](c)
+		return &testService{}, err
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := Resolve[*testRepo](c)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+			t.Errorf("Expected dependency cycle error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Resolve deadlocked on constructor re-entry")
+	}
+}
+
+// TestContainerConcurrentResolve 测试并发解析同一类型只构造一次
+func TestContainerConcurrentResolve(t *testing.T) {
+	c := NewContainer()
+	var builds atomic.Int32
+	_ = c.Provide(func() *testRepo {
+		builds.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return &testRepo{}
+	})
+
+	var wg sync.WaitGroup
+	repos := make([]*testRepo, 8)
+	for i := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repos[i], _ = Resolve[*testRepo](c)
+		}()
+	}
+	wg.Wait()
+
+	if builds.Load() != 1 {
+		t.Errorf("Expected repo built once, got %d", builds.Load())
+	}
+	for _, repo := range repos {
+		if repo == nil || repo != repos[0] {
+			t.Fatalf("Expected the same instance for every caller, got %v", repos)
+		}
+	}
+}
+
+// TestContainerNilInterface 测试构造函数返回 nil 接口时返回错误而不是 panic
+func TestContainerNilInterface(t *testing.T) {
+	c := NewContainer()
+	_ = c.Provide(func() fmt.Stringer { return nil })
+
+	if _, err := Resolve[fmt.Stringer](c); err == nil {
+		t.Error("Expected error for nil interface value")
+	}
+}
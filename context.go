@@ -1,6 +1,13 @@
 package pipeline
 
-import "sync"
+import (
+	"context"
+	"maps"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // PipeContext 管道上下文，包含输入(Payload)和输出(Result)
 // 所有的业务数据都放在 Payload 里
@@ -10,25 +17,156 @@ type PipeContext[Option any, Payload any, Result any] struct {
 	Payload *Payload // 输入数据：包含 User, Channel, Req, Plans 等
 	Result  *Result  // 输出数据：Hook 将结果写到这里
 
-	data  map[string]any // 中间状态：Hook 之间可以共享数据（私有，通过方法访问）
-	abort bool           // 控制位：是否中断后续 Hook（私有，通过方法访问）
-	mu    sync.RWMutex   // 保护 data 和 abort 的并发访问
+	state *sharedState // 共享状态：派生的分支上下文与原上下文共享同一份
 
 	stats *ExecutionStats // 执行统计
+	scope *dataScope      // 严格模式下的数据作用域（未开启时为 nil）
+
+	reducer  ResultReducer[Result] // Result 归并函数（未设置时为 nil）
+	reduceMu sync.Mutex            // 串行化对 Result 的归并
+
+	stream *resultStream[Result] // 流式执行的更新通道（非流式执行为 nil）
+
+	branch context.Context // 所在并行分支的取消信号（不在并行分支中时为 nil，见 ParallelBuilder.Build）
+
+	hookName    string         // 当前执行的 Hook 名称
+	hookIndex   int            // 当前执行的 Hook 索引
+	hookStage   string         // 当前执行的 Hook 所属的阶段
+	hookVariant string         // 当前 Hook 选中的实验变体（见 Experiment）
+	logFields   map[string]any // 当前 Hook 的结构化日志字段
+	hookMu      sync.RWMutex   // 保护当前 Hook 的名称、索引、阶段、变体和 logFields
+}
+
+// sharedState 同一次执行中所有（分支）上下文共享的状态
+type sharedState struct {
+	data    dataMap        // 中间状态：Hook 之间可以共享数据（私有，通过方法访问，首次写入时分配）
+	shards  *shardedData   // 并发共享数据存储（WithConcurrentDataStore，非 nil 时代替 data）
+	outputs map[string]any // Hook 发布的类型化输出（Hook 名称 -> 值，见 SetOutput）
+	slots   map[any]any    // 扩展包的类型化执行状态（*StateKey -> 值，见 StoreState）
+	abort   atomic.Bool    // 控制位：是否中断后续 Hook（读取不加锁，写入时持有 mu 以配合 abortCh）
+	abortCh chan struct{}  // 中断时关闭（按需创建，见 abortSignal）
+	mu      sync.RWMutex   // 保护 data、outputs、slots、abortCh 和 cleanups 的并发访问（shards 由各分片的锁保护）
+
+	waiters map[string]chan struct{} // WaitFor 等待的键（写入时关闭并移除）
+	waitMu  sync.Mutex               // 保护 waiters
+	waiting atomic.Int32             // 登记中的 WaitFor 数量，为 0 时写入不加 waitMu
+
+	cleanups   []func() error     // 管道结束后执行的清理函数（后进先出）
+	events     eventHandlers      // 重试、超时等事件回调（创建后只读）
+	classifier ErrorClassifier    // 错误分类器（创建后只读）
+	journal    *journal           // 事件记录器（未配置事件存储时为 nil，创建后只读）
+	coverage   *CoverageCollector // 覆盖收集器（未配置时为 nil，创建后只读）
+	strict     *strictData        // 严格模式的写入记录（未开启时为 nil，创建后只读）
+	redacted   map[string]bool    // 调试输出时脱敏的键（创建后只读）
+	clock      Clock              // Now() 的时间源（nil 为系统时间，创建后只读）
+	seed       uint64             // Rand() 的种子（创建后只读）
+	rand       *rand.Rand         // 按需创建的随机数生成器
+	randOnce   sync.Once          // 保护 rand 的延迟创建
+}
+
+// NewPipeContext 创建管道上下文
+// 通常由 Execute 创建；单独测试 Hook 时可直接构造
+func NewPipeContext[Option any, Payload any, Result any](
+	name string,
+	option *Option,
+	payload *Payload,
+	result *Result,
+) *PipeContext[Option, Payload, Result] {
+	if option == nil {
+		option = new(Option)
+	}
+	if result == nil {
+		result = new(Result)
+	}
+
+	return &PipeContext[Option, Payload, Result]{
+		Name:    name,
+		Option:  option,
+		Payload: payload,
+		Result:  result,
+		state:   &sharedState{},
+		stats:   NewExecutionStats(name),
+	}
+}
+
+// fork 派生共享数据和中断标记、但使用独立 Result 的分支上下文
+func (p *PipeContext[Option, Payload, Result]) fork(result *Result) *PipeContext[Option, Payload, Result] {
+	name, index := p.CurrentHook()
+
+	return &PipeContext[Option, Payload, Result]{
+		Name:      p.Name,
+		Option:    p.Option,
+		Payload:   p.Payload,
+		Result:    result,
+		state:     p.state,
+		stats:     p.stats,
+		scope:     p.scope,
+		reducer:   p.reducer,
+		stream:    p.stream,
+		branch:    p.branch,
+		hookName:  name,
+		hookIndex: index,
+		hookStage: p.CurrentStage(),
+	}
 }
 
 // Abort 允许 Hook 中断流程（比如参数校验不通过）
 func (p *PipeContext[Option, Payload, Result]) Abort() {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-	p.abort = true
+	p.abort("", nil)
+}
+
+// AbortWithReason 中断流程并记录原因
+func (p *PipeContext[Option, Payload, Result]) AbortWithReason(reason string) {
+	p.abort(reason, nil)
+}
+
+// AbortWithError 中断流程并记录原因错误（管道本身仍视为成功结束）
+func (p *PipeContext[Option, Payload, Result]) AbortWithError(err error) {
+	reason := ""
+	if err != nil {
+		reason = err.Error()
+	}
+	p.abort(reason, err)
 }
 
-// IsAborted 是否已中断
+// abort 设置中断标记，首次中断时将来源 Hook、原因和时间记录到统计
+func (p *PipeContext[Option, Payload, Result]) abort(reason string, err error) {
+	p.state.mu.Lock()
+	first := p.state.abort.CompareAndSwap(false, true)
+	if first && p.state.abortCh != nil {
+		close(p.state.abortCh)
+	}
+	p.state.mu.Unlock()
+
+	if first {
+		name, index := p.CurrentHook()
+		p.stats.setAbortInfo(&AbortInfo{
+			Hook:   name,
+			Index:  index,
+			Reason: reason,
+			Err:    err,
+			At:     time.Now(),
+		})
+	}
+}
+
+// abortSignal 返回中断时关闭的通道（已中断时返回已关闭的通道）
+func (p *PipeContext[Option, Payload, Result]) abortSignal() <-chan struct{} {
+	p.state.mu.Lock()
+	defer p.state.mu.Unlock()
+
+	if p.state.abortCh == nil {
+		p.state.abortCh = make(chan struct{})
+		if p.state.abort.Load() {
+			close(p.state.abortCh)
+		}
+	}
+	return p.state.abortCh
+}
+
+// IsAborted 是否已中断（不加锁，可在热路径中频繁调用）
 func (p *PipeContext[Option, Payload, Result]) IsAborted() bool {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
-	return p.abort
+	return p.state.abort.Load()
 }
 
 // Stats 获取执行统计
@@ -36,26 +174,73 @@ func (p *PipeContext[Option, Payload, Result]) Stats() *ExecutionStats {
 	return p.stats
 }
 
+// CurrentHook 获取当前正在执行的 Hook 名称和索引
+func (p *PipeContext[Option, Payload, Result]) CurrentHook() (name string, index int) {
+	p.hookMu.RLock()
+	defer p.hookMu.RUnlock()
+	return p.hookName, p.hookIndex
+}
+
+// setCurrentHook 记录当前执行的 Hook
+func (p *PipeContext[Option, Payload, Result]) setCurrentHook(name string, index int, stage string) {
+	p.hookMu.Lock()
+	defer p.hookMu.Unlock()
+	p.hookName = name
+	p.hookIndex = index
+	p.hookStage = stage
+	p.hookVariant = ""
+	p.logFields = nil
+}
+
+// AddLogField 为当前 Hook 添加结构化日志字段
+// 日志中间件、OnError 和 HookStat 会自动带上这些字段，切换到下一个 Hook 时清空
+func (p *PipeContext[Option, Payload, Result]) AddLogField(key string, value any) {
+	p.hookMu.Lock()
+	defer p.hookMu.Unlock()
+	if p.logFields == nil {
+		p.logFields = make(map[string]any)
+	}
+	p.logFields[key] = value
+}
+
+// LogFields 获取当前 Hook 的结构化日志字段副本（没有时返回 nil）
+func (p *PipeContext[Option, Payload, Result]) LogFields() map[string]any {
+	p.hookMu.RLock()
+	defer p.hookMu.RUnlock()
+	if len(p.logFields) == 0 {
+		return nil
+	}
+	return maps.Clone(p.logFields)
+}
+
 // Set 设置共享数据（并发安全）
 func (p *PipeContext[Option, Payload, Result]) Set(key string, value any) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-	p.data[key] = value
+	p.checkWrite(key)
+	p.state.storeData(key, value)
+	p.recordData(key, value)
 }
 
 // Get 获取共享数据（并发安全）
 func (p *PipeContext[Option, Payload, Result]) Get(key string) (any, bool) {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
-	val, ok := p.data[key]
-	return val, ok
+	p.checkRead(key)
+	return p.state.loadData(key)
+}
+
+// Delete 删除共享数据（并发安全）
+func (p *PipeContext[Option, Payload, Result]) Delete(key string) {
+	p.checkWrite(key)
+	p.state.deleteData(key)
+
+	if p.state.journal != nil {
+		name, index := p.CurrentHook()
+		p.state.journal.dataChanged(EventDataDeleted, name, index, key, nil)
+	}
 }
 
 // MustGet 获取共享数据（不存在时 panic，并发安全）
 func (p *PipeContext[Option, Payload, Result]) MustGet(key string) any {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
-	val, ok := p.data[key]
+	p.checkRead(key)
+	val, ok := p.state.loadData(key)
 	if !ok {
 		panic("key not found: " + key)
 	}
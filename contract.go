@@ -0,0 +1,82 @@
+package pipeline
+
+import (
+	"fmt"
+	"maps"
+)
+
+// WithProvidedKeys 声明在所有 Hook 之前就已存在的共享数据键（如由 Payload 解析或 OnBeforeExecute 写入），
+// Validate 检查读取声明时视为已写入
+func (p *Pipeline[C, Option, Payload, Result]) WithProvidedKeys(keys ...string) *Pipeline[C, Option, Payload, Result] {
+	p.providedKeys = append(p.providedKeys, keys...)
+	return p
+}
+
+// contractErrors 检查每个声明的读取键都由之前的 Hook 写入（或由 WithProvidedKeys 提供）
+// 并行分支只能读取并行组之前写入的键；If/Else 与多路分支之后，只有所有分组都写入的键视为已写入
+func (p *Pipeline[C, Option, Payload, Result]) contractErrors() []error {
+	written := make(map[string]bool)
+	for _, key := range p.providedKeys {
+		written[key] = true
+	}
+
+	var errs []error
+	p.checkContracts(p.hooks, written, &errs)
+	return errs
+}
+
+// checkContracts 按执行顺序检查一组 Hook，written 为执行到此处时已写入的键（会被更新）
+func (p *Pipeline[C, Option, Payload, Result]) checkContracts(
+	hooks []*Hook[C, Option, Payload, Result],
+	written map[string]bool,
+	errs *[]error,
+) {
+	for _, hook := range hooks {
+		for _, key := range hook.reads {
+			if !written[key] {
+				*errs = append(*errs, fmt.Errorf("%w: hook '%s' reads '%s' but no earlier hook writes it",
+					ErrMissingDataKey, p.hookName(hook), key))
+			}
+		}
+
+		if len(hook.groups) > 0 {
+			maps.Copy(written, p.groupWrites(hook, written, errs))
+		}
+		for _, key := range hook.writes {
+			written[key] = true
+		}
+	}
+}
+
+// groupWrites 检查复合 Hook 的各分组，返回执行后新写入的键
+func (p *Pipeline[C, Option, Payload, Result]) groupWrites(
+	hook *Hook[C, Option, Payload, Result],
+	written map[string]bool,
+	errs *[]error,
+) map[string]bool {
+	exclusive := hook.kind == "branch" || hook.kind == "switch" // 只执行其中一个分组
+
+	var result map[string]bool
+	for i, group := range hook.groups {
+		// 每个分组从复合 Hook 之前的状态开始（并行分支看不到兄弟分支的写入）
+		groupWritten := maps.Clone(written)
+		p.checkContracts(group.hooks, groupWritten, errs)
+
+		added := make(map[string]bool)
+		for key := range groupWritten {
+			if !written[key] {
+				added[key] = true
+			}
+		}
+
+		switch {
+		case i == 0:
+			result = added
+		case exclusive:
+			maps.DeleteFunc(result, func(key string, _ bool) bool { return !added[key] })
+		default:
+			maps.Copy(result, added)
+		}
+	}
+	return result
+}
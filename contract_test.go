@@ -0,0 +1,69 @@
+package pipeline
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func contractHook(name string) *HookBuilder[Context, NoOption, TestPayload, TestResult] {
+	return NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+		return nil
+	}).WithName(name)
+}
+
+// TestValidateContracts 测试共享数据读写声明的校验
+func TestValidateContracts(t *testing.T) {
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("contracts").
+		WithProvidedKeys("request").
+		AddHookWithOptions(contractHook("load").Reads("request").Writes("user").Build()).
+		AddHookWithOptions(contractHook("score").Reads("user", "history").Writes("score").Build()).
+		AddHookWithOptions(Parallel[Context, NoOption, TestPayload, TestResult]().
+			BranchHooks(contractHook("price").Reads("score").Writes("price").Build()).
+			BranchHooks(contractHook("stock").Reads("price").Writes("stock").Build()).
+			Build("enrich")).
+		AddHookWithOptions(Branch[Context, NoOption, TestPayload, TestResult](nil).
+			Then(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error { return nil }).
+			Build("vip")).
+		AddHookWithOptions(contractHook("persist").Reads("price", "stock").Build())
+
+	err := pipeline.Validate()
+	if !errors.Is(err, ErrMissingDataKey) {
+		t.Fatalf("Expected ErrMissingDataKey, got %v", err)
+	}
+
+	msgs := strings.Split(err.Error(), "\n")
+	expected := []string{
+		"hook 'score' reads 'history'",
+		"hook 'stock' reads 'price'", // 兄弟分支的写入不可见
+	}
+	if len(msgs) != len(expected) {
+		t.Fatalf("Expected %d errors, got:\n%s", len(expected), err)
+	}
+	for i, want := range expected {
+		if !strings.Contains(msgs[i], want) {
+			t.Errorf("Expected error %d to contain %q, got %q", i, want, msgs[i])
+		}
+	}
+}
+
+// TestValidateContractsExclusiveGroups 测试分支只保证所有分组都写入的键
+func TestValidateContractsExclusiveGroups(t *testing.T) {
+	build := func(elseWrites ...string) *SimplePipeline[TestPayload, TestResult] {
+		sw := Switch[Context, NoOption, TestPayload, TestResult](nil).Build("route")
+		sw.groups = []hookGroup[Context, NoOption, TestPayload, TestResult]{
+			{owner: "route", label: "case a", hooks: []*Hook[Context, NoOption, TestPayload, TestResult]{contractHook("a").Writes("quote", "a").Build()}},
+			{owner: "route", label: "default", hooks: []*Hook[Context, NoOption, TestPayload, TestResult]{contractHook("b").Writes(elseWrites...).Build()}},
+		}
+		return NewSimplePipeline[TestPayload, TestResult]("exclusive").
+			AddHookWithOptions(sw).
+			AddHookWithOptions(contractHook("use").Reads("quote").Build())
+	}
+
+	if err := build("quote").Validate(); err != nil {
+		t.Errorf("Expected key written by all groups to be available, got %v", err)
+	}
+	if err := build("b").Validate(); !errors.Is(err, ErrMissingDataKey) {
+		t.Errorf("Expected key written by one group to be missing, got %v", err)
+	}
+}
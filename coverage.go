@@ -0,0 +1,210 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CoverageCollector 记录测试运行中执行过的 Hook 和分支边（then/else、case、并行分支、循环体），
+// 用于在管道拓扑层面发现未测试的 Hook。可在多个管道和并发执行间共享
+type CoverageCollector struct {
+	mu        sync.Mutex
+	pipelines map[string]*pipelineCoverage
+}
+
+// pipelineCoverage 单个管道的覆盖记录
+type pipelineCoverage struct {
+	hooks    []string // Hook 名称（声明顺序）
+	edges    []string // 分支边（"复合 Hook 名称/分组标签"，声明顺序）
+	hookHits map[string]int
+	edgeHits map[string]int
+}
+
+// CoverageReport 覆盖报告
+type CoverageReport struct {
+	Pipelines []PipelineCoverage `json:"pipelines"` // 按管道名称排序
+}
+
+// PipelineCoverage 单个管道的覆盖情况
+type PipelineCoverage struct {
+	Pipeline string          `json:"pipeline"`
+	Hooks    []CoverageEntry `json:"hooks"`
+	Edges    []CoverageEntry `json:"edges,omitempty"`
+}
+
+// CoverageEntry Hook 或分支边的执行次数
+type CoverageEntry struct {
+	Name string `json:"name"`
+	Hits int    `json:"hits"`
+}
+
+// NewCoverageCollector 创建覆盖收集器
+func NewCoverageCollector() *CoverageCollector {
+	return &CoverageCollector{pipelines: make(map[string]*pipelineCoverage)}
+}
+
+// WithCoverage 将管道的执行记录到覆盖收集器（用于测试）
+// 管道拓扑在每次执行时登记，之后添加的 Hook 同样计入
+func (p *Pipeline[C, Option, Payload, Result]) WithCoverage(c *CoverageCollector) *Pipeline[C, Option, Payload, Result] {
+	p.coverage = c
+	return p
+}
+
+// register 登记管道拓扑中的 Hook 和分支边
+func (c *CoverageCollector) register(pipeline string, hooks, edges []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cov := c.pipeline(pipeline)
+	for _, hook := range hooks {
+		cov.hooks = appendMissing(cov.hooks, cov.hookHits, hook)
+	}
+	for _, edge := range edges {
+		cov.edges = appendMissing(cov.edges, cov.edgeHits, edge)
+	}
+}
+
+// hitHook 记录一次 Hook 执行（c 为 nil 时为空操作）
+func (c *CoverageCollector) hitHook(pipeline, hook string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cov := c.pipeline(pipeline)
+	cov.hooks = appendMissing(cov.hooks, cov.hookHits, hook)
+	cov.hookHits[hook]++
+}
+
+// hitEdge 记录一次分支边执行（c 为 nil 时为空操作）
+func (c *CoverageCollector) hitEdge(pipeline, edge string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cov := c.pipeline(pipeline)
+	cov.edges = appendMissing(cov.edges, cov.edgeHits, edge)
+	cov.edgeHits[edge]++
+}
+
+// pipeline 返回管道的覆盖记录（调用方持有锁）
+func (c *CoverageCollector) pipeline(name string) *pipelineCoverage {
+	cov, ok := c.pipelines[name]
+	if !ok {
+		cov = &pipelineCoverage{hookHits: make(map[string]int), edgeHits: make(map[string]int)}
+		c.pipelines[name] = cov
+	}
+	return cov
+}
+
+// appendMissing 将未登记的名称追加到列表
+func appendMissing(names []string, hits map[string]int, name string) []string {
+	if _, ok := hits[name]; ok {
+		return names
+	}
+	hits[name] = 0
+	return append(names, name)
+}
+
+// Report 返回当前的覆盖报告
+func (c *CoverageCollector) Report() CoverageReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var report CoverageReport
+	for name, cov := range c.pipelines {
+		pc := PipelineCoverage{Pipeline: name}
+		for _, hook := range cov.hooks {
+			pc.Hooks = append(pc.Hooks, CoverageEntry{Name: hook, Hits: cov.hookHits[hook]})
+		}
+		for _, edge := range cov.edges {
+			pc.Edges = append(pc.Edges, CoverageEntry{Name: edge, Hits: cov.edgeHits[edge]})
+		}
+		report.Pipelines = append(report.Pipelines, pc)
+	}
+	sort.Slice(report.Pipelines, func(i, j int) bool {
+		return report.Pipelines[i].Pipeline < report.Pipelines[j].Pipeline
+	})
+	return report
+}
+
+// Untested 返回未执行过的 Hook 和分支边（"管道名称: Hook 名称" 与 "管道名称: 复合 Hook 名称/分组标签"）
+func (r CoverageReport) Untested() []string {
+	var untested []string
+	for _, pc := range r.Pipelines {
+		for _, entry := range append(pc.Hooks, pc.Edges...) {
+			if entry.Hits == 0 {
+				untested = append(untested, pc.Pipeline+": "+entry.Name)
+			}
+		}
+	}
+	return untested
+}
+
+// String 输出覆盖率摘要和未测试的 Hook、分支边
+func (r CoverageReport) String() string {
+	var b strings.Builder
+	for _, pc := range r.Pipelines {
+		fmt.Fprintf(&b, "pipeline %s: hooks %d/%d, edges %d/%d\n",
+			pc.Pipeline, covered(pc.Hooks), len(pc.Hooks), covered(pc.Edges), len(pc.Edges))
+		for _, entry := range pc.Hooks {
+			if entry.Hits == 0 {
+				fmt.Fprintf(&b, "  untested hook: %s\n", entry.Name)
+			}
+		}
+		for _, entry := range pc.Edges {
+			if entry.Hits == 0 {
+				fmt.Fprintf(&b, "  untested edge: %s\n", entry.Name)
+			}
+		}
+	}
+	return b.String()
+}
+
+// covered 统计执行过的条目数
+func covered(entries []CoverageEntry) int {
+	n := 0
+	for _, entry := range entries {
+		if entry.Hits > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// registerCoverage 将管道拓扑登记到覆盖收集器
+func (p *Pipeline[C, Option, Payload, Result]) registerCoverage() {
+	if p.coverage == nil {
+		return
+	}
+
+	hooks := make([]string, 0, len(p.hooks))
+	var edges []string
+	for _, hook := range p.hooks {
+		if hook.isSlot() {
+			continue
+		}
+		hooks = append(hooks, p.hookName(hook))
+		edges = appendEdges(edges, hook)
+	}
+	p.coverage.register(p.Name, hooks, edges)
+}
+
+// appendEdges 递归收集复合 Hook 的分支边
+func appendEdges[C Context, Option any, Payload any, Result any](
+	edges []string,
+	hook *Hook[C, Option, Payload, Result],
+) []string {
+	for _, group := range hook.groups {
+		edges = append(edges, group.edge())
+		for _, child := range group.hooks {
+			edges = appendEdges(edges, child)
+		}
+	}
+	return edges
+}
@@ -0,0 +1,86 @@
+package pipeline
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestCoverageCollector 测试 Hook 与分支边的覆盖记录
+func TestCoverageCollector(t *testing.T) {
+	coverage := NewCoverageCollector()
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("order").
+		WithCoverage(coverage).
+		AddNamedHook("validate", appendHook("validate")).
+		AddBranch("vip", Branch(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) bool {
+			return pipeCtx.Payload.UserID > 100
+		}).
+			Then(appendHook("discount")).
+			Else(appendHook("normal"))).
+		AddNamedHook("notify", func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			pipeCtx.Abort()
+			return nil
+		}).
+		AddNamedHook("persist", appendHook("persist"))
+
+	for range 2 {
+		if _, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	report := coverage.Report()
+	if len(report.Pipelines) != 1 {
+		t.Fatalf("Expected 1 pipeline, got %d", len(report.Pipelines))
+	}
+	pc := report.Pipelines[0]
+	expectedHooks := []CoverageEntry{{"validate", 2}, {"vip", 2}, {"notify", 2}, {"persist", 0}}
+	if !reflect.DeepEqual(pc.Hooks, expectedHooks) {
+		t.Errorf("Expected hooks %v, got %v", expectedHooks, pc.Hooks)
+	}
+	expectedEdges := []CoverageEntry{{"vip/then", 0}, {"vip/else", 2}}
+	if !reflect.DeepEqual(pc.Edges, expectedEdges) {
+		t.Errorf("Expected edges %v, got %v", expectedEdges, pc.Edges)
+	}
+
+	expectedUntested := []string{"order: persist", "order: vip/then"}
+	if got := report.Untested(); !reflect.DeepEqual(got, expectedUntested) {
+		t.Errorf("Expected untested %v, got %v", expectedUntested, got)
+	}
+	text := report.String()
+	for _, want := range []string{"pipeline order: hooks 3/4, edges 1/2", "untested hook: persist", "untested edge: vip/then"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 101}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := coverage.Report().Untested(); !reflect.DeepEqual(got, []string{"order: persist"}) {
+		t.Errorf("Expected only persist untested, got %v", got)
+	}
+}
+
+// TestCoverageParallelBranches 测试并行分支的覆盖记录
+func TestCoverageParallelBranches(t *testing.T) {
+	coverage := NewCoverageCollector()
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("fanout").
+		WithCoverage(coverage).
+		AddHookWithOptions(Parallel[sylph.Context, TestOption, TestPayload, TestResult]().
+			Branch(appendHook("a")).
+			Branch(appendHook("b")).
+			Build("fetch"))
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := coverage.Report().Untested(); len(got) != 0 {
+		t.Errorf("Expected full coverage, got untested %v", got)
+	}
+	if got := len(coverage.Report().Pipelines[0].Edges); got != 2 {
+		t.Errorf("Expected 2 edges, got %d", got)
+	}
+}
@@ -0,0 +1,166 @@
+package pipeline
+
+import (
+	"slices"
+	"time"
+)
+
+// AutoParallel 根据 Hook 的 Reads/Writes 声明推导执行 DAG，将互不依赖的相邻 Hook 重新编排为并行阶段，
+// 在保持数据依赖的前提下最大化并行度。应在添加完所有 Hook 后调用。
+//
+// 后一个 Hook 读取前一个写入的键、写入前一个读取或写入的键时存在依赖；
+// 没有任何声明的 Hook 视为屏障，与前后所有 Hook 都存在依赖。
+// 同一阶段的 Hook 作为并行分支执行（Result 按 FieldMerger 合并），各自仍经过中间件、超时、降级处理并记录 HookStat。
+// 通过 Result 字段传递数据的 Hook 需要用 Reads/Writes 声明对应的键（如 "result.total"）
+func (p *Pipeline[C, Option, Payload, Result]) AutoParallel() *Pipeline[C, Option, Payload, Result] {
+	stages := p.inferStages()
+	hooks := make([]*Hook[C, Option, Payload, Result], 0, len(stages))
+
+	for _, stage := range stages {
+		if len(stage) == 1 {
+			hooks = append(hooks, p.hooks[stage[0]])
+			continue
+		}
+
+		builder := Parallel[C, Option, Payload, Result]().Merger(FieldMerger[Result]{})
+		var flow FlowStage
+		for _, i := range stage {
+			builder.BranchHooks(p.stageMember(p.hooks[i], i))
+			flow.Branches = append(flow.Branches, []string{p.hookName(p.hooks[i])})
+		}
+		hooks = append(hooks, builder.Build(flow.String()))
+	}
+
+	p.hooks = hooks
+	return p
+}
+
+// InferStages 返回 AutoParallel 将生成的执行阶段（每个阶段内的 Hook 名称），不修改管道
+func (p *Pipeline[C, Option, Payload, Result]) InferStages() [][]string {
+	stages := p.inferStages()
+	names := make([][]string, len(stages))
+	for s, stage := range stages {
+		for _, i := range stage {
+			names[s] = append(names[s], p.hookName(p.hooks[i]))
+		}
+	}
+	return names
+}
+
+// inferStages 按依赖分层：每个 Hook 位于其所有依赖所在层的下一层，返回各层的 Hook 索引
+func (p *Pipeline[C, Option, Payload, Result]) inferStages() [][]int {
+	type access struct {
+		reads, writes map[string]bool
+		declared      bool
+	}
+
+	accesses := make([]access, len(p.hooks))
+	for i, hook := range p.hooks {
+		a := access{reads: make(map[string]bool), writes: make(map[string]bool)}
+		collectAccess(hook, a.reads, a.writes)
+		a.declared = len(a.reads) > 0 || len(a.writes) > 0
+		accesses[i] = a
+	}
+
+	dependsOn := func(later, earlier access) bool {
+		if !later.declared || !earlier.declared {
+			return true
+		}
+		for key := range later.reads {
+			if earlier.writes[key] {
+				return true
+			}
+		}
+		for key := range later.writes {
+			if earlier.reads[key] || earlier.writes[key] {
+				return true
+			}
+		}
+		return false
+	}
+
+	levels := make([]int, len(p.hooks))
+	var stages [][]int
+	for j := range p.hooks {
+		for i := range j {
+			if levels[i]+1 > levels[j] && dependsOn(accesses[j], accesses[i]) {
+				levels[j] = levels[i] + 1
+			}
+		}
+		if levels[j] == len(stages) {
+			stages = append(stages, nil)
+		}
+		stages[levels[j]] = append(stages[levels[j]], j)
+	}
+	return stages
+}
+
+// collectAccess 收集 Hook 及其子 Hook 声明的读写键
+func collectAccess[C Context, Option any, Payload any, Result any](
+	hook *Hook[C, Option, Payload, Result],
+	reads, writes map[string]bool,
+) {
+	for _, key := range hook.reads {
+		reads[key] = true
+	}
+	for _, key := range hook.writes {
+		writes[key] = true
+	}
+	for _, group := range hook.groups {
+		for _, child := range group.hooks {
+			collectAccess(child, reads, writes)
+		}
+	}
+}
+
+// stageMember 将 Hook 包装为并行阶段的分支：经过 runHook（中间件、超时、降级处理等）执行并记录 HookStat
+func (p *Pipeline[C, Option, Payload, Result]) stageMember(
+	hook *Hook[C, Option, Payload, Result],
+	index int,
+) *Hook[C, Option, Payload, Result] {
+	name := p.hookName(hook)
+
+	return &Hook[C, Option, Payload, Result]{
+		Name:        name,
+		Description: hook.Description,
+		SkipOnError: hook.SkipOnError,
+		errorHandle: hook.errorHandle, // 仅用于判断是否跳过，处理本身在 runHook 中
+		skipErrors:  hook.skipErrors,
+		Handler: func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+			pipeCtx.setCurrentHook(name, index, hook.stage)
+			stat := HookStat{Name: name, Index: index, Stage: hook.stage, StartTime: time.Now()}
+
+			var err error
+			if hook.skipIf != nil && hook.skipIf(pipeCtx.Option) {
+				stat.Skipped = true
+			} else {
+				if pipeCtx.stats.Sampled {
+					stat.Profile = &HookProfile{}
+				}
+				err = p.runHook(ctx, pipeCtx, hook, pipeCtx.Payload, &stat)
+			}
+
+			stat.EndTime = time.Now()
+			stat.Duration = stat.EndTime.Sub(stat.StartTime)
+			stat.Error = err
+			if err != nil {
+				stat.Class = pipeCtx.ClassifyError(err)
+			}
+			stat.Panicked = isPanic(err) || isPanic(stat.Cause)
+			stat.Variant = pipeCtx.CurrentVariant()
+			stat.Fields = pipeCtx.LogFields()
+			if !stat.Skipped && hook.deprecated != nil {
+				stat.Deprecated = true
+				warnDeprecated(ctx, p.Name, name, hook.deprecated)
+			}
+			p.addHookStat(pipeCtx.stats, stat)
+			if !stat.Skipped {
+				hook.complete(ctx, pipeCtx, err)
+			}
+			return err
+		},
+		tags:   slices.Clone(hook.tags),
+		reads:  slices.Clone(hook.reads),
+		writes: slices.Clone(hook.writes),
+	}
+}
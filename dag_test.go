@@ -0,0 +1,103 @@
+package pipeline
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type dagResult struct {
+	Price int
+	Stock int
+	Score int
+}
+
+// TestInferStages 测试由读写声明推导执行阶段
+func TestInferStages(t *testing.T) {
+	noop := func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error { return nil }
+	hook := func(name string) *HookBuilder[Context, NoOption, TestPayload, TestResult] {
+		return NewHook(noop).WithName(name)
+	}
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("dag").
+		AddHookWithOptions(hook("load").Writes("user").Build()).
+		AddHookWithOptions(hook("price").Reads("user").Writes("price").Build()).
+		AddHookWithOptions(hook("stock").Reads("user").Writes("stock").Build()).
+		AddHookWithOptions(hook("score").Reads("price", "stock").Writes("score").Build()).
+		AddHookWithOptions(hook("notify").Reads("user").Build()).
+		AddNamedHook("audit", noop). // 未声明：屏障
+		AddHookWithOptions(hook("archive").Reads("score").Build()).
+		AddHookWithOptions(hook("rewrite").Writes("score").Build()) // 写后读：依赖 archive
+
+	expected := [][]string{{"load"}, {"price", "stock", "notify"}, {"score"}, {"audit"}, {"archive"}, {"rewrite"}}
+	if got := pipeline.InferStages(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected stages %v, got %v", expected, got)
+	}
+}
+
+// TestAutoParallel 测试自动编排后的并行执行
+func TestAutoParallel(t *testing.T) {
+	priceStarted := make(chan struct{})
+	stockStarted := make(chan struct{})
+
+	pipeline := NewSimplePipeline[TestPayload, dagResult]("dag").
+		AddHookWithOptions(NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, dagResult]) error {
+			pipeCtx.Set("user", 7)
+			return nil
+		}).WithName("load").Writes("user").Build()).
+		AddHookWithOptions(NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, dagResult]) error {
+			close(priceStarted)
+			<-stockStarted // 两个 Hook 同时执行才能完成
+			pipeCtx.Result.Price = pipeCtx.MustGet("user").(int) * 10
+			return nil
+		}).WithName("price").Reads("user").Writes("result.price").WithTimeout(time.Second).Build()).
+		AddHookWithOptions(NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, dagResult]) error {
+			close(stockStarted)
+			<-priceStarted
+			pipeCtx.Result.Stock = 3
+			return nil
+		}).WithName("stock").Reads("user").Writes("result.stock").Build()).
+		AddHookWithOptions(NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, dagResult]) error {
+			pipeCtx.Result.Score = pipeCtx.Result.Price + pipeCtx.Result.Stock
+			return nil
+		}).WithName("score").Reads("result.price", "result.stock").Build()).
+		AutoParallel()
+
+	var stats *ExecutionStats
+	pipeline.OnAfterExecute(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, dagResult], err error) {
+		stats = pipeCtx.Stats()
+	})
+
+	done := make(chan struct{})
+	var (
+		result *dagResult
+		err    error
+	)
+	go func() {
+		defer close(done)
+		result, err = pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stage hooks did not run in parallel")
+	}
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if *result != (dagResult{Price: 70, Stock: 3, Score: 73}) {
+		t.Errorf("Unexpected result %+v", *result)
+	}
+
+	names := make(map[string]bool)
+	for _, stat := range stats.HookStats {
+		names[stat.Name] = true
+	}
+	for _, name := range []string{"load", "price", "stock", "[price, stock]", "score"} {
+		if !names[name] {
+			t.Errorf("Expected HookStat for %s, got %v", name, names)
+		}
+	}
+}
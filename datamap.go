@@ -0,0 +1,123 @@
+package pipeline
+
+import (
+	"iter"
+	"maps"
+)
+
+// smallDataKeys 共享数据的内联容量，超过后转为 map 存储
+const smallDataKeys = 8
+
+// dataEntry 内联存储的单个键值
+type dataEntry struct {
+	key   string
+	value any
+}
+
+// dataMap 共享数据存储（不是并发安全的，由 sharedState.mu 保护）
+// 零值可用：从不调用 Set 的执行不分配任何存储；键不超过 smallDataKeys 个时线性查找内联数组，
+// 之后整体迁移到 map
+type dataMap struct {
+	small [smallDataKeys]dataEntry
+	n     int            // 内联数组中的键数量
+	large map[string]any // 非 nil 时所有键都在 map 中
+}
+
+// get 读取键
+func (d *dataMap) get(key string) (any, bool) {
+	if d.large != nil {
+		value, ok := d.large[key]
+		return value, ok
+	}
+	for i := 0; i < d.n; i++ {
+		if d.small[i].key == key {
+			return d.small[i].value, true
+		}
+	}
+	return nil, false
+}
+
+// set 写入键，内联数组已满时迁移到 map
+func (d *dataMap) set(key string, value any) {
+	if d.large != nil {
+		d.large[key] = value
+		return
+	}
+	for i := 0; i < d.n; i++ {
+		if d.small[i].key == key {
+			d.small[i].value = value
+			return
+		}
+	}
+	if d.n < smallDataKeys {
+		d.small[d.n] = dataEntry{key: key, value: value}
+		d.n++
+		return
+	}
+
+	d.large = make(map[string]any, 2*smallDataKeys)
+	for i := 0; i < d.n; i++ {
+		d.large[d.small[i].key] = d.small[i].value
+	}
+	d.small = [smallDataKeys]dataEntry{}
+	d.n = 0
+	d.large[key] = value
+}
+
+// del 删除键
+func (d *dataMap) del(key string) {
+	if d.large != nil {
+		delete(d.large, key)
+		return
+	}
+	for i := 0; i < d.n; i++ {
+		if d.small[i].key == key {
+			d.n--
+			d.small[i] = d.small[d.n]
+			d.small[d.n] = dataEntry{}
+			return
+		}
+	}
+}
+
+// len 键数量
+func (d *dataMap) len() int {
+	if d.large != nil {
+		return len(d.large)
+	}
+	return d.n
+}
+
+// all 遍历所有键值（无序）
+func (d *dataMap) all() iter.Seq2[string, any] {
+	if d.large != nil {
+		return maps.All(d.large)
+	}
+	return func(yield func(string, any) bool) {
+		for i := 0; i < d.n; i++ {
+			if !yield(d.small[i].key, d.small[i].value) {
+				return
+			}
+		}
+	}
+}
+
+// keys 遍历所有键（无序）
+func (d *dataMap) keys() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for key := range d.all() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// clone 浅拷贝，值保持原有引用
+func (d *dataMap) clone() dataMap {
+	cp := *d
+	if cp.large != nil {
+		cp.large = maps.Clone(cp.large)
+	}
+	return cp
+}
@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestDataMap 测试内联存储与迁移到 map 后的读写删除
+func TestDataMap(t *testing.T) {
+	var d dataMap
+	if _, ok := d.get("a"); ok || d.len() != 0 {
+		t.Fatal("Expected empty zero value")
+	}
+
+	expected := make(map[string]any)
+	for i := 0; i < 2*smallDataKeys; i++ {
+		key := fmt.Sprintf("k%d", i)
+		d.set(key, i)
+		expected[key] = i
+		if i == 3 {
+			d.del("k1")
+			delete(expected, "k1")
+			d.set("k0", "updated")
+			expected["k0"] = "updated"
+		}
+		if (d.large != nil) != (len(expected) > smallDataKeys) {
+			t.Fatalf("Unexpected storage at %d keys", len(expected))
+		}
+		if got := maps.Collect(d.all()); !maps.Equal(got, expected) {
+			t.Fatalf("Expected %v, got %v", expected, got)
+		}
+	}
+
+	cp := d.clone()
+	cp.set("k0", "copy")
+	if v, _ := d.get("k0"); v != "updated" {
+		t.Errorf("Expected clone isolated, got %v", v)
+	}
+}
+
+// benchmarkExecute 执行每次写入 keys 个共享数据键的单 Hook 管道
+func benchmarkExecute(b *testing.B, keys int) {
+	names := make([]string, keys)
+	for i := range names {
+		names[i] = fmt.Sprintf("key%d", i)
+	}
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("bench").
+		AddHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			for _, name := range names {
+				pipeCtx.Set(name, pipeCtx)
+			}
+			return nil
+		})
+	ctx := newMockContext()
+	payload := &TestPayload{}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := pipeline.Execute(ctx, payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkExecuteNoData(b *testing.B)    { benchmarkExecute(b, 0) }
+func BenchmarkExecuteSmallData(b *testing.B) { benchmarkExecute(b, 4) }
+func BenchmarkExecuteLargeData(b *testing.B) { benchmarkExecute(b, 16) }
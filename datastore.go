@@ -0,0 +1,149 @@
+package pipeline
+
+import (
+	"hash/maphash"
+	"sync"
+)
+
+// dataShards 并发共享数据存储的分片数量
+const dataShards = 32
+
+// shardedData 按键哈希分片的共享数据存储，不同键的读写只竞争各自分片的锁
+type shardedData struct {
+	seed   maphash.Seed
+	shards [dataShards]dataShard
+}
+
+// dataShard 单个分片（填充到缓存行大小，避免相邻分片的锁伪共享）
+type dataShard struct {
+	mu sync.RWMutex
+	m  map[string]any
+	_  [32]byte
+}
+
+// newShardedData 创建分片存储，initial 为初始内容（如预热资源）
+func newShardedData(initial *dataMap) *shardedData {
+	d := &shardedData{seed: maphash.MakeSeed()}
+	for key, value := range initial.all() {
+		d.set(key, value)
+	}
+	return d
+}
+
+// shard 返回键所在的分片
+func (d *shardedData) shard(key string) *dataShard {
+	return &d.shards[maphash.String(d.seed, key)%dataShards]
+}
+
+func (d *shardedData) get(key string) (any, bool) {
+	s := d.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.m[key]
+	return value, ok
+}
+
+func (d *shardedData) set(key string, value any) {
+	s := d.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = make(map[string]any)
+	}
+	s.m[key] = value
+}
+
+func (d *shardedData) del(key string) {
+	s := d.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, key)
+}
+
+// copy 逐个分片复制所有键值（不是跨分片的原子快照）
+func (d *shardedData) copy() dataMap {
+	var cp dataMap
+	for i := range d.shards {
+		s := &d.shards[i]
+		s.mu.RLock()
+		for key, value := range s.m {
+			cp.set(key, value)
+		}
+		s.mu.RUnlock()
+	}
+	return cp
+}
+
+// replace 用 data 替换所有键值
+func (d *shardedData) replace(data *dataMap) {
+	for i := range d.shards {
+		s := &d.shards[i]
+		s.mu.Lock()
+		clear(s.m)
+		s.mu.Unlock()
+	}
+	for key, value := range data.all() {
+		d.set(key, value)
+	}
+}
+
+// loadData 读取共享数据
+func (s *sharedState) loadData(key string) (any, bool) {
+	if s.shards != nil {
+		return s.shards.get(key)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data.get(key)
+}
+
+// storeData 写入共享数据并唤醒等待该键的 WaitFor
+func (s *sharedState) storeData(key string, value any) {
+	defer s.notify(key)
+	if s.shards != nil {
+		s.shards.set(key, value)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.set(key, value)
+}
+
+// deleteData 删除共享数据
+func (s *sharedState) deleteData(key string) {
+	if s.shards != nil {
+		s.shards.del(key)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.del(key)
+}
+
+// copyData 返回共享数据的浅拷贝，调用方持有 s.mu 读锁
+func (s *sharedState) copyData() dataMap {
+	if s.shards != nil {
+		return s.shards.copy()
+	}
+	return s.data.clone()
+}
+
+// replaceData 替换共享数据并唤醒等待其中的键的 WaitFor，调用方持有 s.mu 写锁
+func (s *sharedState) replaceData(data dataMap) {
+	if s.shards != nil {
+		s.shards.replace(&data)
+	} else {
+		s.data = data
+	}
+	for key := range data.keys() {
+		s.notify(key)
+	}
+}
+
+// WithConcurrentDataStore 使用按键分片的共享数据存储，代替单个读写锁保护的 map，
+// 适合并行分支、集合元素等大量 Hook 同时读写不同键的管道（每次执行多分配约 2KB）。
+// Keys、Range、Snapshot 在该模式下逐个分片复制，不是所有键的原子快照
+func (p *Pipeline[C, Option, Payload, Result]) WithConcurrentDataStore() *Pipeline[C, Option, Payload, Result] {
+	p.concurrentData = true
+	return p
+}
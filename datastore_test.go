@@ -0,0 +1,86 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestConcurrentDataStore 测试分片存储下并行分支读写、快照回滚和预热资源
+func TestConcurrentDataStore(t *testing.T) {
+	pool := NewStandbyPool(func(ctx context.Context) (string, error) { return "conn", nil })
+	branch := func(name string) HookHandler[sylph.Context, TestOption, TestPayload, TestResult] {
+		return func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			for i := 0; i < 100; i++ {
+				pipeCtx.Set(fmt.Sprintf("%s-%d", name, i), i)
+				pipeCtx.Get("shared")
+			}
+			pipeCtx.Delete(name + "-99")
+			return nil
+		}
+	}
+
+	var keys []string
+	var restored []string
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("fanout").
+		WithConcurrentDataStore().
+		WithStandby("conn", pool).
+		AddHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			pipeCtx.Set("shared", true)
+			return nil
+		}).
+		AddParallel("branches", Parallel(branch("a"), branch("b"), branch("c"))).
+		AddHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			keys = pipeCtx.Keys()
+			snapshot := pipeCtx.Snapshot()
+			pipeCtx.Set("later", 1)
+			pipeCtx.Delete("shared")
+			pipeCtx.Restore(snapshot)
+			restored = pipeCtx.Keys()
+			return nil
+		})
+	defer pipeline.Close()
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(keys) != 3*99+2 {
+		t.Errorf("Expected %d keys, got %d", 3*99+2, len(keys))
+	}
+	if !reflect.DeepEqual(keys, restored) {
+		t.Errorf("Expected restore to roll back keys, got %d keys", len(restored))
+	}
+}
+
+// benchmarkDataStore 并发读写共享数据（读多写少，键分散）
+func benchmarkDataStore(b *testing.B, concurrent bool) {
+	pipeCtx := NewPipeContext[TestOption, TestPayload, TestResult]("bench", nil, &TestPayload{}, nil)
+	if concurrent {
+		pipeCtx.state.shards = newShardedData(&pipeCtx.state.data)
+	}
+	keys := make([]string, 64)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key%d", i)
+		pipeCtx.Set(keys[i], i)
+	}
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := keys[i%len(keys)]
+			if i%8 == 0 {
+				pipeCtx.Set(key, i)
+			} else {
+				pipeCtx.Get(key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkDataStoreMutex(b *testing.B)   { benchmarkDataStore(b, false) }
+func BenchmarkDataStoreSharded(b *testing.B) { benchmarkDataStore(b, true) }
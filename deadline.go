@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"fmt"
+	"time"
+)
+
+// DeadlinePolicy 剩余时间不足以完成 Hook 时的处理策略
+type DeadlinePolicy int
+
+const (
+	// DeadlineIgnore 不检查剩余时间（默认）
+	DeadlineIgnore DeadlinePolicy = iota
+	// DeadlineSkip 跳过剩余时间不足的 Hook
+	DeadlineSkip
+	// DeadlineFail 以 ErrInsufficientTime 失败
+	DeadlineFail
+)
+
+// WithDeadlinePolicy 设置截止时间感知策略
+// 每个 Hook 执行前比较 ctx 剩余时间与 Hook 所需时间（预估耗时，无预估时使用 Timeout），不足时按策略跳过或失败
+func (p *Pipeline[C, Option, Payload, Result]) WithDeadlinePolicy(policy DeadlinePolicy) *Pipeline[C, Option, Payload, Result] {
+	p.deadlinePolicy = policy
+	return p
+}
+
+// checkDeadline 检查剩余时间是否足够执行 Hook
+func (p *Pipeline[C, Option, Payload, Result]) checkDeadline(
+	ctx C,
+	hook *Hook[C, Option, Payload, Result],
+	payload *Payload,
+) (skip bool, err error) {
+	if p.deadlinePolicy == DeadlineIgnore {
+		return false, nil
+	}
+
+	// 预估耗时（成本函数、历史平均、声明值）更接近实际所需时间，Timeout 只是上限，仅作兜底
+	required := p.estimateHook(hook, payload)
+	if required <= 0 {
+		required = p.hookTimeout(hook)
+	}
+	if required <= 0 {
+		return false, nil
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return false, nil
+	}
+
+	remaining := time.Until(deadline)
+	if remaining >= required {
+		return false, nil
+	}
+
+	if p.deadlinePolicy == DeadlineSkip {
+		return true, nil
+	}
+	return false, fmt.Errorf("%w: %v remaining, %v required", ErrInsufficientTime, remaining.Round(time.Millisecond), required)
+}
@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestDeadlinePolicy 测试剩余时间不足时跳过或失败
+func TestDeadlinePolicy(t *testing.T) {
+	var calls int
+	expensive := NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+		calls++
+		return nil
+	}).WithName("expensive").WithTimeout(time.Hour).Build()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	skipping := NewSimplePipeline[TestPayload, TestResult]("test").
+		WithDeadlinePolicy(DeadlineSkip).
+		AddHookWithOptions(expensive)
+	if _, err := skipping.ExecuteStd(ctx, &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	failing := NewSimplePipeline[TestPayload, TestResult]("test").
+		WithDeadlinePolicy(DeadlineFail).
+		AddHookWithOptions(expensive)
+	if _, err := failing.ExecuteStd(ctx, &TestPayload{}); !errors.Is(err, ErrInsufficientTime) {
+		t.Errorf("Expected ErrInsufficientTime, got %v", err)
+	}
+
+	if calls != 0 {
+		t.Errorf("Expected expensive hook not to run, got %d calls", calls)
+	}
+}
+
+// TestDeadlinePrefersEstimate 测试截止时间检查优先使用预估耗时而非 Timeout
+func TestDeadlinePrefersEstimate(t *testing.T) {
+	var calls int
+	hook := NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+		calls++
+		return nil
+	}).WithName("bounded").WithTimeout(time.Hour).WithEstimatedDuration(time.Millisecond).Build()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		WithDeadlinePolicy(DeadlineFail).
+		AddHookWithOptions(hook)
+	if _, err := pipeline.ExecuteStd(ctx, &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected hook to run once, got %d calls", calls)
+	}
+}
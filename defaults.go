@@ -0,0 +1,36 @@
+package pipeline
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// pipelineDefaults 各类型参数组合的默认配置（*Pipeline 类型 -> []func(*Pipeline)）
+var pipelineDefaults sync.Map
+
+// SetDefaults 设置类型参数对应的默认配置，之后 NewPipeline 创建的每个管道都会依次执行，
+// 确保应用内的管道统一带有 Recovery、追踪、指标等中间件和生命周期钩子（通常在 main 或 init 中调用）。
+// 再次调用替换之前的默认配置，不传参数时清除；已创建的管道不受影响。
+// 默认中间件建议使用 UseNamed 注册，个别管道可以通过 RemoveMiddleware 移除
+func SetDefaults[C Context, Option any, Payload any, Result any](
+	configure ...func(p *Pipeline[C, Option, Payload, Result]),
+) {
+	key := reflect.TypeOf((*Pipeline[C, Option, Payload, Result])(nil))
+	if len(configure) == 0 {
+		pipelineDefaults.Delete(key)
+		return
+	}
+	pipelineDefaults.Store(key, slices.Clone(configure))
+}
+
+// applyDefaults 对新创建的管道执行默认配置
+func (p *Pipeline[C, Option, Payload, Result]) applyDefaults() {
+	configure, ok := pipelineDefaults.Load(reflect.TypeOf(p))
+	if !ok {
+		return
+	}
+	for _, fn := range configure.([]func(p *Pipeline[C, Option, Payload, Result])) {
+		fn(p)
+	}
+}
@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type defaultsPayload struct{}
+
+// TestSetDefaults 测试默认配置应用于新创建的管道
+func TestSetDefaults(t *testing.T) {
+	var trace []string
+	SetDefaults(func(p *Pipeline[Context, NoOption, defaultsPayload, TestResult]) {
+		p.UseNamed("recovery", func(next HookHandler[Context, NoOption, defaultsPayload, TestResult]) HookHandler[Context, NoOption, defaultsPayload, TestResult] {
+			return func(ctx Context, pipeCtx *PipeContext[NoOption, defaultsPayload, TestResult]) error {
+				trace = append(trace, "recovery")
+				return next(ctx, pipeCtx)
+			}
+		}).OnBeforeExecute(func(ctx Context, pipeCtx *PipeContext[NoOption, defaultsPayload, TestResult]) {
+			trace = append(trace, "before")
+		})
+	})
+	t.Cleanup(func() { SetDefaults[Context, NoOption, defaultsPayload, TestResult]() })
+
+	hook := func(ctx Context, pipeCtx *PipeContext[NoOption, defaultsPayload, TestResult]) error {
+		trace = append(trace, "hook")
+		return nil
+	}
+	withDefaults := NewSimplePipeline[defaultsPayload, TestResult]("a").AddHook(hook)
+	optedOut := NewSimplePipeline[defaultsPayload, TestResult]("b").RemoveMiddleware("recovery").AddHook(hook)
+
+	if _, err := withDefaults.ExecuteStd(context.Background(), &defaultsPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []string{"before", "recovery", "hook"}; !reflect.DeepEqual(trace, expected) {
+		t.Errorf("Expected %v, got %v", expected, trace)
+	}
+
+	trace = nil
+	if _, err := optedOut.ExecuteStd(context.Background(), &defaultsPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []string{"before", "hook"}; !reflect.DeepEqual(trace, expected) {
+		t.Errorf("Expected %v, got %v", expected, trace)
+	}
+
+	// 其他类型参数组合不受影响，清除后新管道不再应用
+	if got := NewSimplePipeline[TestPayload, TestResult]("c").Middlewares(); len(got) != 0 {
+		t.Errorf("Expected no defaults for other type parameters, got %v", got)
+	}
+	SetDefaults[Context, NoOption, defaultsPayload, TestResult]()
+	if got := NewSimplePipeline[defaultsPayload, TestResult]("d").Middlewares(); len(got) != 0 {
+		t.Errorf("Expected defaults cleared, got %v", got)
+	}
+}
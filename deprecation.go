@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"fmt"
+	"time"
+)
+
+// Deprecation Hook 的弃用信息
+type Deprecation struct {
+	Message string    `json:"message"`         // 替代方案说明（如 "use enrich-v2"）
+	Sunset  time.Time `json:"sunset,omitzero"` // 下线日期（零值表示未定）
+}
+
+// WithDeprecated 标记 Hook 已弃用：每次执行通过 ctx.Warn 输出警告，并计入 HookStat.Deprecated；
+// 管道开启 EnforceSunset 后，超过下线日期时 Validate 返回 ErrHookSunset
+func (b *HookBuilder[C, Option, Payload, Result]) WithDeprecated(message string, sunset time.Time) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.deprecated = &Deprecation{Message: message, Sunset: sunset}
+	return b
+}
+
+// EnforceSunset 开启后，包含已过下线日期的弃用 Hook 时 Validate 返回错误
+func (p *Pipeline[C, Option, Payload, Result]) EnforceSunset() *Pipeline[C, Option, Payload, Result] {
+	p.enforceSunset = true
+	return p
+}
+
+// sunsetErrors 开启 EnforceSunset 时，已过下线日期的弃用 Hook
+func (p *Pipeline[C, Option, Payload, Result]) sunsetErrors() []error {
+	if !p.enforceSunset {
+		return nil
+	}
+
+	var errs []error
+	now := time.Now()
+	p.walkHooks(func(hook *Hook[C, Option, Payload, Result]) {
+		d := hook.deprecated
+		if d != nil && !d.Sunset.IsZero() && now.After(d.Sunset) {
+			errs = append(errs, fmt.Errorf("%w: hook '%s' (sunset %s): %s",
+				ErrHookSunset, p.hookName(hook), d.Sunset.Format(time.DateOnly), d.Message))
+		}
+	})
+	return errs
+}
+
+// warnDeprecated 执行弃用 Hook 时输出警告
+func warnDeprecated[C Context](ctx C, pipeline, hook string, d *Deprecation) {
+	data := map[string]any{
+		"pipeline": pipeline,
+		"hook":     hook,
+		"message":  d.Message,
+	}
+	if !d.Sunset.IsZero() {
+		data["sunset"] = d.Sunset.Format(time.DateOnly)
+	}
+	ctx.Warn("pipeline", "deprecated hook executed", data)
+}
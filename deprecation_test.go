@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// warnRecorder 记录 Warn 调用
+type warnRecorder struct {
+	nopLogger
+	mu    sync.Mutex
+	warns []map[string]any
+}
+
+func (l *warnRecorder) Warn(pkg, action string, data any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if m, ok := data.(map[string]any); ok && action == "deprecated hook executed" {
+		l.warns = append(l.warns, m)
+	}
+}
+
+// TestDeprecatedHook 测试弃用 Hook 的警告与统计
+func TestDeprecatedHook(t *testing.T) {
+	logger := &warnRecorder{}
+	sunset := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	agg := NewAggregateStats("deprecated")
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("deprecated").
+		WithLogger(logger).
+		WithStatsSink(agg).
+		AddHookWithOptions(NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			return nil
+		}).WithName("enrich").WithDeprecated("use enrich-v2", sunset).Build()).
+		AddNamedHook("persist", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			return nil
+		})
+
+	for range 2 {
+		if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if len(logger.warns) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d", len(logger.warns))
+	}
+	warn := logger.warns[0]
+	if warn["hook"] != "enrich" || warn["message"] != "use enrich-v2" || warn["sunset"] != "2030-01-01" {
+		t.Errorf("Unexpected warning %v", warn)
+	}
+
+	hooks := agg.Hooks()
+	for _, h := range hooks {
+		expected := 0
+		if h.Name == "enrich" {
+			expected = 2
+		}
+		if h.Deprecated != expected {
+			t.Errorf("Hook %s: expected %d deprecated calls, got %d", h.Name, expected, h.Deprecated)
+		}
+	}
+
+	if !strings.Contains(pipeline.Describe(), "enrich [deprecated: use enrich-v2]") {
+		t.Errorf("Expected Describe to mark deprecated hook, got:\n%s", pipeline.Describe())
+	}
+}
+
+// TestValidateSunset 测试超过下线日期的校验
+func TestValidateSunset(t *testing.T) {
+	noop := func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error { return nil }
+	past := time.Now().Add(-24 * time.Hour)
+	future := time.Now().Add(24 * time.Hour)
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("sunset").
+		AddHookWithOptions(NewHook(noop).WithName("old").WithDeprecated("use new", past).Build()).
+		AddHookWithOptions(NewHook(noop).WithName("soon").WithDeprecated("use newer", future).Build()).
+		AddHookWithOptions(Parallel[Context, NoOption, TestPayload, TestResult]().
+			BranchHooks(NewHook(noop).WithName("nested").WithDeprecated("gone", past).Build()).
+			Build("fanout"))
+
+	if err := pipeline.Validate(); err != nil {
+		t.Errorf("Expected no error without EnforceSunset, got %v", err)
+	}
+
+	err := pipeline.EnforceSunset().Validate()
+	if !errors.Is(err, ErrHookSunset) {
+		t.Fatalf("Expected ErrHookSunset, got %v", err)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "hook 'old'") || !strings.Contains(msg, "hook 'nested'") || strings.Contains(msg, "soon") {
+		t.Errorf("Unexpected validation error: %s", msg)
+	}
+}
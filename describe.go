@@ -0,0 +1,81 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Describe 返回管道结构的文本描述
+// 复合 Hook（分支、多路分支等）的子 Hook 和命名阶段内的 Hook 会以缩进形式展开
+func (p *Pipeline[C, Option, Payload, Result]) Describe() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "pipeline %s\n", p.Name)
+	if p.description != "" {
+		fmt.Fprintf(&b, "  description: %s\n", p.description)
+	}
+	for _, key := range sortedLabelKeys(p.labels) {
+		fmt.Fprintf(&b, "  label %s=%s\n", key, p.labels[key])
+	}
+	for _, entry := range p.middlewareChain {
+		fmt.Fprintf(&b, "  middleware %s", entry.displayName())
+		if entry.priority != 0 {
+			fmt.Fprintf(&b, " (priority %d)", entry.priority)
+		}
+		if entry.match != nil {
+			b.WriteString(" [conditional]")
+		}
+		b.WriteString("\n")
+	}
+	stage := ""
+	for i, hook := range p.hooks {
+		// 属于命名阶段的 Hook 缩进展示在阶段之下
+		if hook.stage != stage && hook.stage != "" {
+			fmt.Fprintf(&b, "  stage %s:%s\n", hook.stage, p.stagePolicies[hook.stage].describe())
+		}
+		stage = hook.stage
+
+		depth := 1
+		if stage != "" {
+			depth = 2
+		}
+		describeHook(&b, hook, p.hookNamer, fmt.Sprintf("[%d]", i), depth)
+	}
+
+	return b.String()
+}
+
+// describeHook 递归输出单个 Hook 的描述
+func describeHook[C Context, Option any, Payload any, Result any](
+	b *strings.Builder,
+	hook *Hook[C, Option, Payload, Result],
+	namer HookNamer,
+	prefix string,
+	depth int,
+) {
+	indent := strings.Repeat("  ", depth)
+
+	name := hook.Name
+	if name == "" && namer != nil {
+		name = namer(hook.Handler)
+	}
+	if name == "" {
+		name = "<unnamed>"
+	}
+
+	fmt.Fprintf(b, "%s%s %s", indent, prefix, name)
+	if hook.kind != "" {
+		fmt.Fprintf(b, " (%s)", hook.kind)
+	}
+	if d := hook.deprecated; d != nil {
+		fmt.Fprintf(b, " [deprecated: %s]", d.Message)
+	}
+	b.WriteString("\n")
+
+	for _, group := range hook.groups {
+		fmt.Fprintf(b, "%s    %s:\n", indent, group.label)
+		for _, child := range group.hooks {
+			describeHook(b, child, namer, "-", depth+3)
+		}
+	}
+}
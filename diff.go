@@ -0,0 +1,266 @@
+package pipeline
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DiffOption 结果对比选项
+type DiffOption func(*diffConfig)
+
+// diffConfig 结果对比配置
+type diffConfig struct {
+	ignore         []*regexp.Regexp
+	floatTolerance float64
+	timeTolerance  time.Duration
+}
+
+// IgnoreFields 忽略指定路径（及其子路径）的差异，如 "Metadata.trace_id"、"Items[*].UpdatedAt"；
+// "*" 匹配任意一段字段名或字符串键，"[*]" 匹配任意索引
+func IgnoreFields(paths ...string) DiffOption {
+	return func(c *diffConfig) {
+		for _, path := range paths {
+			pattern := regexp.QuoteMeta(path)
+			pattern = strings.ReplaceAll(pattern, `\[\*\]`, `\[[^\]]*\]`)
+			pattern = strings.ReplaceAll(pattern, `\*`, `[^.\[]*`)
+			c.ignore = append(c.ignore, regexp.MustCompile(`^`+pattern+`($|[.\[])`))
+		}
+	}
+}
+
+// FloatTolerance 浮点数之差的绝对值不超过 epsilon 时视为相同
+func FloatTolerance(epsilon float64) DiffOption {
+	return func(c *diffConfig) {
+		c.floatTolerance = epsilon
+	}
+}
+
+// TimeTolerance 时间之差不超过 d 时视为相同（时间总是按 Equal 比较，忽略时区和单调时钟）
+func TimeTolerance(d time.Duration) DiffOption {
+	return func(c *diffConfig) {
+		c.timeTolerance = d
+	}
+}
+
+// Difference 单处差异
+type Difference struct {
+	Path string // 字段路径（如 "Items[2].Price"、"Metadata.region"，根为空）
+	A    any    // 第一个值（不存在时为 nil）
+	B    any    // 第二个值（不存在时为 nil）
+}
+
+func (d Difference) String() string {
+	path := d.Path
+	if path == "" {
+		path = "<root>"
+	}
+	return fmt.Sprintf("%s: %v != %v", path, d.A, d.B)
+}
+
+// Diff 结果对比报告
+type Diff struct {
+	Differences []Difference // 按遍历顺序（字段声明顺序，map 键排序）
+}
+
+// Equal 是否没有差异
+func (d Diff) Equal() bool {
+	return len(d.Differences) == 0
+}
+
+func (d Diff) String() string {
+	if d.Equal() {
+		return "no differences"
+	}
+	lines := make([]string, len(d.Differences))
+	for i, diff := range d.Differences {
+		lines[i] = diff.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DiffResults 深度对比两个值（通常为 Result），供影子执行、重放校验和 golden 测试使用。
+// 只比较导出字段；nil 与空切片、空 map 视为相同；函数和通道不参与比较
+func DiffResults[T any](a, b *T, opts ...DiffOption) Diff {
+	var cfg diffConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	d := differ{cfg: cfg, visited: make(map[[2]uintptr]bool)}
+	d.diff("", reflect.ValueOf(a), reflect.ValueOf(b))
+	return Diff{Differences: d.diffs}
+}
+
+// timeType time.Time 的类型
+var timeType = reflect.TypeFor[time.Time]()
+
+// differ 递归对比状态
+type differ struct {
+	cfg     diffConfig
+	visited map[[2]uintptr]bool // 已对比的指针对（处理循环引用）
+	diffs   []Difference
+}
+
+// ignored 路径是否被忽略
+func (d *differ) ignored(path string) bool {
+	for _, re := range d.cfg.ignore {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// report 记录差异
+func (d *differ) report(path string, a, b reflect.Value) {
+	d.diffs = append(d.diffs, Difference{Path: path, A: valueOf(a), B: valueOf(b)})
+}
+
+// diff 对比同一路径上的两个值
+func (d *differ) diff(path string, a, b reflect.Value) {
+	if d.ignored(path) {
+		return
+	}
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			d.report(path, a, b)
+		}
+		return
+	}
+	if a.Type() != b.Type() {
+		d.report(path, a, b)
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.report(path, a, b)
+			}
+			return
+		}
+		key := [2]uintptr{a.Pointer(), b.Pointer()}
+		if d.visited[key] {
+			return
+		}
+		d.visited[key] = true
+		d.diff(path, a.Elem(), b.Elem())
+
+	case reflect.Interface:
+		d.diff(path, a.Elem(), b.Elem())
+
+	case reflect.Struct:
+		if a.Type() == timeType {
+			d.diffTime(path, a, b)
+			return
+		}
+		for i := 0; i < a.NumField(); i++ {
+			if field := a.Type().Field(i); field.IsExported() {
+				d.diff(joinPath(path, field.Name), a.Field(i), b.Field(i))
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		n := max(a.Len(), b.Len())
+		for i := 0; i < n; i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= a.Len():
+				d.diff(elemPath, reflect.Value{}, b.Index(i))
+			case i >= b.Len():
+				d.diff(elemPath, a.Index(i), reflect.Value{})
+			default:
+				d.diff(elemPath, a.Index(i), b.Index(i))
+			}
+		}
+
+	case reflect.Map:
+		for _, key := range mapKeys(a, b) {
+			d.diff(keyPath(path, key), a.MapIndex(key), b.MapIndex(key))
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if math.Abs(a.Float()-b.Float()) > d.cfg.floatTolerance {
+			d.report(path, a, b)
+		}
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		// 不参与比较
+
+	default:
+		if !a.Equal(b) {
+			d.report(path, a, b)
+		}
+	}
+}
+
+// diffTime 按容差对比时间
+func (d *differ) diffTime(path string, a, b reflect.Value) {
+	ta, tb := a.Interface().(time.Time), b.Interface().(time.Time)
+	delta := ta.Sub(tb)
+	if delta < 0 {
+		delta = -delta
+	}
+	if delta > d.cfg.timeTolerance {
+		d.report(path, a, b)
+	}
+}
+
+// mapKeys 返回两个 map 的键的并集（按格式化后的字符串排序）
+func mapKeys(a, b reflect.Value) []reflect.Value {
+	seen := make(map[any]bool)
+	var keys []reflect.Value
+	for _, m := range []reflect.Value{a, b} {
+		for _, key := range m.MapKeys() {
+			if !seen[key.Interface()] {
+				seen[key.Interface()] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
+
+// joinPath 拼接字段路径
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// keyPath 拼接 map 键路径：字符串键按字段形式（与解码后的 JSON 对象一致），其他键用方括号
+func keyPath(path string, key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return joinPath(path, key.String())
+	}
+	return fmt.Sprintf("%s[%v]", path, key.Interface())
+}
+
+// valueOf 返回差异中展示的值（指针取其指向的值），不存在或 nil 时为 nil
+func valueOf(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return valueOf(v.Elem())
+	case reflect.Interface, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+	}
+	return v.Interface()
+}
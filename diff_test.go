@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type diffLine struct {
+	SKU   string
+	Price float64
+}
+
+type diffResult struct {
+	ID        string
+	Lines     []diffLine
+	Tags      map[string]string
+	Parent    *diffResult
+	UpdatedAt time.Time
+	internal  int
+}
+
+// TestDiffResults 测试结果的逐字段对比
+func TestDiffResults(t *testing.T) {
+	now := time.Now()
+	a := &diffResult{
+		ID:        "r-1",
+		Lines:     []diffLine{{"a", 1.0}, {"b", 2.0}},
+		Tags:      map[string]string{"region": "eu", "tier": "gold"},
+		UpdatedAt: now,
+		internal:  1,
+	}
+	b := &diffResult{
+		ID:        "r-1",
+		Lines:     []diffLine{{"a", 1.0000001}, {"c", 2.0}, {"d", 3.0}},
+		Tags:      map[string]string{"region": "us", "tier": "gold"},
+		Parent:    &diffResult{ID: "r-0"},
+		UpdatedAt: now.Add(time.Millisecond),
+		internal:  2,
+	}
+
+	var paths []string
+	for _, d := range DiffResults(a, b).Differences {
+		paths = append(paths, d.Path)
+	}
+	expected := []string{"Lines[0].Price", "Lines[1].SKU", "Lines[2]", "Tags.region", "Parent", "UpdatedAt"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected %v, got %v", expected, paths)
+	}
+
+	diff := DiffResults(a, b,
+		IgnoreFields("Lines[*].SKU", "Lines[2]", "Tags.*", "Parent"),
+		FloatTolerance(1e-3),
+		TimeTolerance(time.Second))
+	if !diff.Equal() {
+		t.Errorf("Expected no differences with options, got:\n%s", diff)
+	}
+}
+
+// TestDiffResultsValues 测试差异中记录的值，nil 与空集合视为相同
+func TestDiffResultsValues(t *testing.T) {
+	a := &TestResult{Output: nil, Metadata: map[string]any{"n": 1}}
+	b := &TestResult{Output: []string{}, Metadata: map[string]any{"n": 1}}
+	if diff := DiffResults(a, b); !diff.Equal() {
+		t.Errorf("Expected nil and empty equal, got:\n%s", diff)
+	}
+
+	b.Metadata["n"] = "1"
+	b.Metadata["extra"] = true
+	diff := DiffResults(a, b)
+	if len(diff.Differences) != 2 {
+		t.Fatalf("Expected 2 differences, got:\n%s", diff)
+	}
+	if d := diff.Differences[0]; d.Path != "Metadata.extra" || d.A != nil || d.B != true {
+		t.Errorf("Unexpected missing key difference: %+v", d)
+	}
+	if d := diff.Differences[1]; d.Path != "Metadata.n" || d.A != 1 || d.B != "1" {
+		t.Errorf("Unexpected type difference: %+v", d)
+	}
+	if s := diff.Differences[1].String(); s != "Metadata.n: 1 != 1" {
+		t.Errorf("Unexpected string: %s", s)
+	}
+}
@@ -0,0 +1,166 @@
+package pipeline
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+)
+
+// PipelineDoc 管道文档（可直接序列化为 JSON）
+type PipelineDoc struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Hooks       []HookDoc         `json:"hooks"`
+}
+
+// HookDoc 单个 Hook 的文档
+type HookDoc struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Kind        string         `json:"kind,omitempty"`
+	Stage       string         `json:"stage,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Reads       []string       `json:"reads,omitempty"`
+	Writes      []string       `json:"writes,omitempty"`
+	Timeout     time.Duration  `json:"timeout_ns,omitempty"`
+	Deprecated  *Deprecation   `json:"deprecated,omitempty"`
+	Groups      []HookGroupDoc `json:"groups,omitempty"`
+}
+
+// HookGroupDoc 复合 Hook 的子 Hook 分组（分支、循环体等）
+type HookGroupDoc struct {
+	Label string    `json:"label"`
+	Hooks []HookDoc `json:"hooks"`
+}
+
+// ExportDocs 导出管道文档：各 Hook 的名称、描述、标签，以及通过 Reads/Writes 声明的共享数据读写，
+// 文档由代码生成，不会与实现脱节。使用 json.Marshal 得到 JSON，Markdown 得到 Markdown
+func (p *Pipeline[C, Option, Payload, Result]) ExportDocs() PipelineDoc {
+	doc := PipelineDoc{
+		Name:        p.Name,
+		Description: p.description,
+		Labels:      maps.Clone(p.labels),
+		Hooks:       make([]HookDoc, 0, len(p.hooks)),
+	}
+	for _, hook := range p.hooks {
+		doc.Hooks = append(doc.Hooks, p.hookDoc(hook))
+	}
+	return doc
+}
+
+// hookDoc 生成单个 Hook 的文档（递归展开复合 Hook）
+func (p *Pipeline[C, Option, Payload, Result]) hookDoc(hook *Hook[C, Option, Payload, Result]) HookDoc {
+	name := p.hookName(hook)
+	if name == "" {
+		name = "<unnamed>"
+	}
+
+	doc := HookDoc{
+		Name:        name,
+		Description: hook.Description,
+		Kind:        hook.kind,
+		Stage:       hook.stage,
+		Tags:        hook.tags,
+		Reads:       hook.reads,
+		Writes:      hook.writes,
+		Timeout:     hook.Timeout,
+		Deprecated:  hook.deprecated,
+	}
+	for _, group := range hook.groups {
+		groupDoc := HookGroupDoc{Label: group.label, Hooks: make([]HookDoc, 0, len(group.hooks))}
+		for _, child := range group.hooks {
+			groupDoc.Hooks = append(groupDoc.Hooks, p.hookDoc(child))
+		}
+		doc.Groups = append(doc.Groups, groupDoc)
+	}
+	return doc
+}
+
+// Markdown 将管道文档输出为 Markdown
+func (d PipelineDoc) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", d.Name)
+	if d.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", d.Description)
+	}
+	if len(d.Labels) > 0 {
+		labels := make([]string, 0, len(d.Labels))
+		for _, key := range sortedLabelKeys(d.Labels) {
+			labels = append(labels, fmt.Sprintf("`%s=%s`", key, d.Labels[key]))
+		}
+		fmt.Fprintf(&b, "Labels: %s\n\n", strings.Join(labels, ", "))
+	}
+	stage := ""
+	for i, hook := range d.Hooks {
+		// 命名阶段作为二级标题，阶段内的 Hook 降一级
+		if hook.Stage != stage && hook.Stage != "" {
+			fmt.Fprintf(&b, "## Stage: %s\n\n", hook.Stage)
+		}
+		stage = hook.Stage
+
+		level := 2
+		if stage != "" {
+			level = 3
+		}
+		hook.markdown(&b, level, fmt.Sprintf("%d. ", i+1))
+	}
+	return b.String()
+}
+
+// markdown 输出单个 Hook 的 Markdown，子 Hook 使用更深一级的标题
+func (h HookDoc) markdown(b *strings.Builder, level int, prefix string) {
+	fmt.Fprintf(b, "%s %s%s", strings.Repeat("#", min(level, 6)), prefix, h.Name)
+	if h.Kind != "" {
+		fmt.Fprintf(b, " (%s)", h.Kind)
+	}
+	b.WriteString("\n\n")
+
+	if h.Description != "" {
+		fmt.Fprintf(b, "%s\n\n", h.Description)
+	}
+
+	var items []string
+	if len(h.Tags) > 0 {
+		items = append(items, "Tags: "+strings.Join(h.Tags, ", "))
+	}
+	if len(h.Reads) > 0 {
+		items = append(items, "Reads: "+codeList(h.Reads))
+	}
+	if len(h.Writes) > 0 {
+		items = append(items, "Writes: "+codeList(h.Writes))
+	}
+	if h.Timeout > 0 {
+		items = append(items, "Timeout: "+h.Timeout.String())
+	}
+	if d := h.Deprecated; d != nil {
+		item := "Deprecated: " + d.Message
+		if !d.Sunset.IsZero() {
+			item += " (sunset " + d.Sunset.Format(time.DateOnly) + ")"
+		}
+		items = append(items, item)
+	}
+	for _, item := range items {
+		fmt.Fprintf(b, "- %s\n", item)
+	}
+	if len(items) > 0 {
+		b.WriteString("\n")
+	}
+
+	for _, group := range h.Groups {
+		for _, child := range group.Hooks {
+			child.markdown(b, level+1, group.Label+": ")
+		}
+	}
+}
+
+// codeList 以行内代码格式列出键
+func codeList(keys []string) string {
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = "`" + key + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newDocsPipeline() *SimplePipeline[TestPayload, TestResult] {
+	noop := func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error { return nil }
+
+	return NewSimplePipeline[TestPayload, TestResult]("order").
+		WithDescription("Places an order.").
+		WithLabels(map[string]string{"team": "checkout"}).
+		AddHookWithOptions(NewHook(noop).
+			WithName("load-user").
+			WithDescription("Loads the user profile.").
+			WithTags("io").
+			Writes("user").
+			WithTimeout(time.Second).
+			Build()).
+		AddHookWithOptions(Parallel[Context, NoOption, TestPayload, TestResult]().
+			BranchHooks(NewHook(noop).WithName("price").Reads("user").Writes("price").Build()).
+			BranchHooks(NewHook(noop).WithName("stock").Reads("user").Build()).
+			Build("enrich")).
+		AddHookWithOptions(NewHook(noop).
+			WithName("persist").
+			Reads("user", "price").
+			WithDeprecated("use persist-v2", time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)).
+			Build())
+}
+
+// TestExportDocsJSON 测试文档的 JSON 导出
+func TestExportDocsJSON(t *testing.T) {
+	data, err := json.Marshal(newDocsPipeline().ExportDocs())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var doc PipelineDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if doc.Name != "order" || doc.Labels["team"] != "checkout" || len(doc.Hooks) != 3 {
+		t.Fatalf("Unexpected doc: %+v", doc)
+	}
+
+	load := doc.Hooks[0]
+	if load.Description != "Loads the user profile." || load.Tags[0] != "io" || load.Writes[0] != "user" || load.Timeout != time.Second {
+		t.Errorf("Unexpected hook doc: %+v", load)
+	}
+
+	enrich := doc.Hooks[1]
+	if enrich.Kind != "parallel" || len(enrich.Groups) != 2 || enrich.Groups[0].Hooks[0].Name != "price" {
+		t.Errorf("Unexpected parallel doc: %+v", enrich)
+	}
+
+	if d := doc.Hooks[2].Deprecated; d == nil || d.Message != "use persist-v2" || d.Sunset.Year() != 2030 {
+		t.Errorf("Unexpected deprecation: %+v", d)
+	}
+	if !strings.Contains(string(data), `"timeout_ns":1000000000`) {
+		t.Errorf("Expected timeout in nanoseconds, got %s", data)
+	}
+}
+
+// TestExportDocsMarkdown 测试文档的 Markdown 导出
+func TestExportDocsMarkdown(t *testing.T) {
+	md := newDocsPipeline().ExportDocs().Markdown()
+
+	for _, want := range []string{
+		"# order\n\nPlaces an order.\n\nLabels: `team=checkout`\n",
+		"## 1. load-user\n\nLoads the user profile.\n\n- Tags: io\n- Writes: `user`\n- Timeout: 1s\n",
+		"## 2. enrich (parallel)\n",
+		"### branch 0: price\n\n- Reads: `user`\n- Writes: `price`\n",
+		"### branch 1: stock\n",
+		"## 3. persist\n\n- Reads: `user`, `price`\n- Deprecated: use persist-v2 (sunset 2030-01-01)\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+}
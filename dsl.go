@@ -0,0 +1,226 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FlowStage DSL 中的一个阶段：单个分支为顺序执行，多个分支为并行组
+type FlowStage struct {
+	Branches [][]string // 各分支依次执行的 Hook 注册名称
+}
+
+// ParseFlow 解析管道装配 DSL，例如：
+//
+//	validate -> enrich | score -> [notify, audit -> archive] -> persist
+//
+// "->" 分隔依次执行的阶段；"a | b" 为并行组的简写，每个分支一个 Hook；
+// "[a, b -> c]" 为并行组，逗号分隔分支，分支内可用 "->" 串联多个 Hook。
+// 名称由字母、数字和 _ . : / - 组成；语法错误返回 ErrInvalidFlow（含位置）
+func ParseFlow(src string) ([]FlowStage, error) {
+	tokens, err := lexFlow(src)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &flowParser{tokens: tokens}
+	return parser.parse()
+}
+
+// AddFlow 按 DSL 从管道的 Hook 注册表（见 WithHookRegistry）引用 Hook 并依次添加
+// 并行组以 "[a, b]" 形式命名；语法错误返回 ErrInvalidFlow，引用未注册的名称返回 ErrHookNotRegistered，出错时管道不变
+func (p *Pipeline[C, Option, Payload, Result]) AddFlow(src string) error {
+	stages, err := ParseFlow(src)
+	if err != nil {
+		return err
+	}
+
+	registry := p.HookRegistry()
+	hooks := make([]*Hook[C, Option, Payload, Result], 0, len(stages))
+	for _, stage := range stages {
+		branches := make([][]*Hook[C, Option, Payload, Result], 0, len(stage.Branches))
+		for _, names := range stage.Branches {
+			branch := make([]*Hook[C, Option, Payload, Result], 0, len(names))
+			for _, name := range names {
+				hook, err := registry.Hook(name)
+				if err != nil {
+					return err
+				}
+				branch = append(branch, hook)
+			}
+			branches = append(branches, branch)
+		}
+
+		if len(branches) == 1 {
+			hooks = append(hooks, branches[0]...)
+			continue
+		}
+
+		parallel := Parallel[C, Option, Payload, Result]()
+		for _, branch := range branches {
+			parallel.BranchHooks(branch...)
+		}
+		hooks = append(hooks, parallel.Build(stage.String()))
+	}
+
+	p.hooks = append(p.hooks, hooks...)
+	return nil
+}
+
+// String 以 DSL 形式输出阶段（并行组统一为方括号形式）
+func (s FlowStage) String() string {
+	branches := make([]string, 0, len(s.Branches))
+	for _, branch := range s.Branches {
+		branches = append(branches, strings.Join(branch, " -> "))
+	}
+	if len(branches) == 1 {
+		return branches[0]
+	}
+	return "[" + strings.Join(branches, ", ") + "]"
+}
+
+// flowToken DSL 词法单元
+type flowToken struct {
+	kind string // name、->、|、[、]、,
+	text string
+	pos  int
+}
+
+// lexFlow 将 DSL 切分为词法单元
+func lexFlow(src string) ([]flowToken, error) {
+	var tokens []flowToken
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "->"):
+			tokens = append(tokens, flowToken{kind: "->", pos: i})
+			i += 2
+		case c == '|' || c == '[' || c == ']' || c == ',':
+			tokens = append(tokens, flowToken{kind: string(c), pos: i})
+			i++
+		case isFlowNameChar(c):
+			start := i
+			for i < len(src) && isFlowNameChar(src[i]) && !strings.HasPrefix(src[i:], "->") {
+				i++
+			}
+			tokens = append(tokens, flowToken{kind: "name", text: src[start:i], pos: start})
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q at %d", ErrInvalidFlow, c, i)
+		}
+	}
+	return tokens, nil
+}
+
+// isFlowNameChar 是否为名称允许的字符
+func isFlowNameChar(c byte) bool {
+	return c == '_' || c == '.' || c == ':' || c == '/' || c == '-' ||
+		('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// flowParser DSL 递归下降解析器
+type flowParser struct {
+	tokens []flowToken
+	pos    int
+}
+
+// parse flow := stage ("->" stage)*
+func (p *flowParser) parse() ([]FlowStage, error) {
+	var stages []FlowStage
+	for {
+		stage, err := p.stage()
+		if err != nil {
+			return nil, err
+		}
+		stages = append(stages, stage)
+
+		if p.done() {
+			return stages, nil
+		}
+		if _, err := p.expect("->"); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// stage := "[" chain ("," chain)* "]" | name ("|" name)*
+func (p *flowParser) stage() (FlowStage, error) {
+	if p.peek("[") {
+		p.pos++
+		var stage FlowStage
+		for {
+			chain, err := p.chain()
+			if err != nil {
+				return FlowStage{}, err
+			}
+			stage.Branches = append(stage.Branches, chain)
+
+			if p.peek("]") {
+				p.pos++
+				return stage, nil
+			}
+			if _, err := p.expect(","); err != nil {
+				return FlowStage{}, err
+			}
+		}
+	}
+
+	var stage FlowStage
+	for {
+		name, err := p.expect("name")
+		if err != nil {
+			return FlowStage{}, err
+		}
+		stage.Branches = append(stage.Branches, []string{name})
+
+		if !p.peek("|") {
+			return stage, nil
+		}
+		p.pos++
+	}
+}
+
+// chain := name ("->" name)*
+func (p *flowParser) chain() ([]string, error) {
+	var names []string
+	for {
+		name, err := p.expect("name")
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+
+		if !p.peek("->") {
+			return names, nil
+		}
+		p.pos++
+	}
+}
+
+// done 是否已读完所有词法单元
+func (p *flowParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// peek 下一个词法单元是否为 kind
+func (p *flowParser) peek(kind string) bool {
+	return !p.done() && p.tokens[p.pos].kind == kind
+}
+
+// expect 读取类型为 kind 的词法单元
+func (p *flowParser) expect(kind string) (string, error) {
+	if p.done() {
+		return "", fmt.Errorf("%w: expected %s at end of input", ErrInvalidFlow, kind)
+	}
+	token := p.tokens[p.pos]
+	if token.kind != kind {
+		found := token.kind
+		if found == "name" {
+			found = token.text
+		}
+		return "", fmt.Errorf("%w: expected %s, found %q at %d", ErrInvalidFlow, kind, found, token.pos)
+	}
+	p.pos++
+	return token.text, nil
+}
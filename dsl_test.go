@@ -0,0 +1,73 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// TestParseFlow 测试 DSL 解析
+func TestParseFlow(t *testing.T) {
+	stages, err := ParseFlow("validate -> enrich | score -> [notify, audit -> archive] -> persist")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []string{"validate", "[enrich, score]", "[notify, audit -> archive]", "persist"}
+	var got []string
+	for _, stage := range stages {
+		got = append(got, stage.String())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	for _, src := range []string{"", "a ->", "a -> [b, c", "a | -> b", "a ; b", "[a,]"} {
+		if _, err := ParseFlow(src); !errors.Is(err, ErrInvalidFlow) {
+			t.Errorf("Expected ErrInvalidFlow for %q, got %v", src, err)
+		}
+	}
+}
+
+// TestAddFlow 测试按 DSL 从注册表装配管道
+func TestAddFlow(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	record := func(name string) HookFactory[Context, NoOption, TestPayload, TestResult] {
+		return func() *Hook[Context, NoOption, TestPayload, TestResult] {
+			return NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, name)
+				return nil
+			}).Build()
+		}
+	}
+
+	registry := NewHookRegistry[Context, NoOption, TestPayload, TestResult]()
+	for _, name := range []string{"validate", "notify", "audit", "persist"} {
+		registry.Register(name, record(name))
+	}
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").WithHookRegistry(registry)
+	if err := pipeline.AddFlow("validate -> [notify, audit] -> persist"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(order) != 4 || order[0] != "validate" || order[3] != "persist" {
+		t.Errorf("Expected validate, parallel group, persist; got %v", order)
+	}
+
+	if err := pipeline.AddFlow("validate -> missing"); !errors.Is(err, ErrHookNotRegistered) {
+		t.Errorf("Expected ErrHookNotRegistered, got %v", err)
+	}
+	if len(pipeline.hooks) != 3 {
+		t.Errorf("Expected failed AddFlow to leave the pipeline unchanged, got %d hooks", len(pipeline.hooks))
+	}
+}
@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Redacted 脱敏后输出的占位文本
+const Redacted = "[REDACTED]"
+
+// Redactor 由共享数据值实现，DumpData 等调试输出时使用 Redact 的返回值代替原值
+type Redactor interface {
+	Redact() any
+}
+
+// WithRedactedKeys 指定需要脱敏的共享数据键，DumpData 等调试输出时显示为 Redacted
+func (p *Pipeline[C, Option, Payload, Result]) WithRedactedKeys(keys ...string) *Pipeline[C, Option, Payload, Result] {
+	if p.redactedKeys == nil {
+		p.redactedKeys = make(map[string]bool, len(keys))
+	}
+	for _, key := range keys {
+		p.redactedKeys[key] = true
+	}
+	return p
+}
+
+// Keys 返回共享数据的键（按字典序）
+func (p *PipeContext[Option, Payload, Result]) Keys() []string {
+	p.state.mu.RLock()
+	data := p.state.copyData()
+	p.state.mu.RUnlock()
+	return slices.Sorted(data.keys())
+}
+
+// Range 按键的字典序遍历共享数据，fn 返回 false 时停止
+// 遍历的是调用时的副本，fn 中可以安全地读写共享数据
+func (p *PipeContext[Option, Payload, Result]) Range(fn func(key string, value any) bool) {
+	p.state.mu.RLock()
+	data := p.state.copyData()
+	p.state.mu.RUnlock()
+
+	for _, key := range slices.Sorted(data.keys()) {
+		value, _ := data.get(key)
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
+// DumpData 按键的字典序输出共享数据及其类型，每行一个键：
+//
+//	key (type): value
+//
+// WithRedactedKeys 指定的键输出为 Redacted，实现 Redactor 的值输出 Redact 的结果
+func (p *PipeContext[Option, Payload, Result]) DumpData(w io.Writer) error {
+	var err error
+	p.Range(func(key string, value any) bool {
+		_, err = fmt.Fprintf(w, "%s (%T): %+v\n", key, value, p.state.redact(key, value))
+		return err == nil
+	})
+	return err
+}
+
+// redact 返回用于调试输出的值
+func (s *sharedState) redact(key string, value any) any {
+	if s.redacted[key] {
+		return Redacted
+	}
+	if r, ok := value.(Redactor); ok {
+		return r.Redact()
+	}
+	return value
+}
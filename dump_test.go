@@ -0,0 +1,65 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type secretToken string
+
+func (secretToken) Redact() any { return "tok-***" }
+
+// TestDumpData 测试共享数据的有序输出与脱敏
+func TestDumpData(t *testing.T) {
+	var dump strings.Builder
+	var keys []string
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("dump").
+		WithRedactedKeys("password").
+		AddNamedHook("fill", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			pipeCtx.Set("user", "alice")
+			pipeCtx.Set("password", "hunter2")
+			pipeCtx.Set("token", secretToken("tok-123456"))
+			pipeCtx.Set("amounts", map[string]int{"b": 2, "a": 1})
+			pipeCtx.Set("count", 3)
+
+			keys = pipeCtx.Keys()
+			return pipeCtx.DumpData(&dump)
+		})
+
+	if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := strings.Join(keys, ","); got != "amounts,count,password,token,user" {
+		t.Errorf("Expected sorted keys, got %s", got)
+	}
+
+	expected := `amounts (map[string]int): map[a:1 b:2]
+count (int): 3
+password (string): [REDACTED]
+token (pipeline.secretToken): tok-***
+user (string): alice
+`
+	if dump.String() != expected {
+		t.Errorf("Expected dump:\n%s\ngot:\n%s", expected, dump.String())
+	}
+}
+
+// TestRangeStops 测试 Range 提前停止
+func TestRangeStops(t *testing.T) {
+	pipeCtx := NewPipeContext[NoOption, TestPayload, TestResult]("range", nil, &TestPayload{}, nil)
+	for _, key := range []string{"c", "a", "b"} {
+		pipeCtx.Set(key, key)
+	}
+
+	var visited []string
+	pipeCtx.Range(func(key string, value any) bool {
+		visited = append(visited, key)
+		pipeCtx.Set("d", 4) // 回调中可以写入
+		return key != "b"
+	})
+	if got := strings.Join(visited, ","); got != "a,b" {
+		t.Errorf("Expected a,b, got %s", got)
+	}
+}
@@ -0,0 +1,64 @@
+package pipeline
+
+import "errors"
+
+// ErrorCoder 可由错误分类器（见 WithErrorClassifier）实现，
+// 为没有 WithCode 标注、所在 Hook 也未声明错误码的失败提供错误码
+type ErrorCoder interface {
+	ErrorCode(err error, class ErrorClass) string
+}
+
+// coded 携带错误码的错误
+type coded struct {
+	code string
+	err  error
+}
+
+func (e *coded) Error() string {
+	return e.err.Error()
+}
+
+func (e *coded) Unwrap() error {
+	return e.err
+}
+
+func (e *coded) errorCode() string {
+	return e.code
+}
+
+// codeCarrier 携带错误码的错误（coded 与 PipeError）
+type codeCarrier interface {
+	errorCode() string
+}
+
+// WithCode 为错误标注稳定的机器可读错误码（如 "ORDER_OUT_OF_STOCK"），写入 PipeError.Code
+func WithCode(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+	return &coded{code: code, err: err}
+}
+
+// CodeOf 错误的错误码：管道返回的错误取 PipeError.Code，其余取 WithCode 标注，没有时返回空字符串
+// API 层可据此映射稳定的错误码，而无需解析错误信息
+func CodeOf(err error) string {
+	var carrier codeCarrier
+	if errors.As(err, &carrier) {
+		return carrier.errorCode()
+	}
+	return ""
+}
+
+// errorCode 解析 PipeError.Code：WithCode 标注优先，其次 Hook 声明的错误码，最后由分类器提供
+func (p *PipeContext[Option, Payload, Result]) errorCode(err error, hookCode string, class ErrorClass) string {
+	if code := CodeOf(err); code != "" {
+		return code
+	}
+	if hookCode != "" {
+		return hookCode
+	}
+	if coder, ok := p.state.classifier.(ErrorCoder); ok {
+		return coder.ErrorCode(err, class)
+	}
+	return ""
+}
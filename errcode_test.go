@@ -0,0 +1,101 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// codingClassifier 同时提供错误码的分类器
+type codingClassifier struct{}
+
+func (codingClassifier) Classify(err error) ErrorClass {
+	return DefaultClassifier.Classify(err)
+}
+
+func (codingClassifier) ErrorCode(err error, class ErrorClass) string {
+	return "E_" + class.Label()
+}
+
+// TestErrorCode 测试错误码的来源优先级
+func TestErrorCode(t *testing.T) {
+	failWith := func(err error) HookHandler[Context, NoOption, TestPayload, TestResult] {
+		return func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			return err
+		}
+	}
+
+	cases := []struct {
+		name       string
+		hook       *Hook[Context, NoOption, TestPayload, TestResult]
+		classifier ErrorClassifier
+		expected   string
+	}{
+		{
+			name:     "annotated",
+			hook:     NewHook(failWith(WithCode(errors.New("no stock"), "OUT_OF_STOCK"))).WithErrorCode("ORDER_FAILED").Build(),
+			expected: "OUT_OF_STOCK",
+		},
+		{
+			name:     "wrapped annotation",
+			hook:     NewHook(failWith(fmt.Errorf("reserve: %w", WithCode(errors.New("no stock"), "OUT_OF_STOCK")))).Build(),
+			expected: "OUT_OF_STOCK",
+		},
+		{
+			name:       "hook declared",
+			hook:       NewHook(failWith(errors.New("boom"))).WithErrorCode("ORDER_FAILED").Build(),
+			classifier: codingClassifier{},
+			expected:   "ORDER_FAILED",
+		},
+		{
+			name:       "classifier",
+			hook:       NewHook(failWith(Classify(errors.New("bad"), ClassValidation))).Build(),
+			classifier: codingClassifier{},
+			expected:   "E_validation",
+		},
+		{
+			name:     "none",
+			hook:     NewHook(failWith(errors.New("boom"))).Build(),
+			expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		pipeline := NewSimplePipeline[TestPayload, TestResult]("codes").AddHookWithOptions(tc.hook)
+		if tc.classifier != nil {
+			pipeline.WithErrorClassifier(tc.classifier)
+		}
+
+		_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+		var pipeErr *PipeError
+		if !errors.As(err, &pipeErr) {
+			t.Fatalf("%s: expected PipeError, got %v", tc.name, err)
+		}
+		if pipeErr.Code != tc.expected {
+			t.Errorf("%s: expected code %q, got %q", tc.name, tc.expected, pipeErr.Code)
+		}
+		if got := CodeOf(err); got != tc.expected {
+			t.Errorf("%s: expected CodeOf %q, got %q", tc.name, tc.expected, got)
+		}
+	}
+}
+
+// TestErrorCodeParallel 测试并行分支的错误码
+func TestErrorCodeParallel(t *testing.T) {
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("codes").
+		AddHookWithOptions(Parallel[Context, NoOption, TestPayload, TestResult]().
+			BranchHooks(NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+				return errors.New("timeout")
+			}).WithErrorCode("PRICING_UNAVAILABLE").Build()).
+			Build("fanout"))
+
+	_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	if got := CodeOf(err); got != "PRICING_UNAVAILABLE" {
+		t.Errorf("Expected branch code, got %q (%v)", got, err)
+	}
+	var errs PipeErrors
+	if !errors.As(err, &errs) || errs[0].Code != "PRICING_UNAVAILABLE" {
+		t.Errorf("Expected branch PipeError with code, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package pipeline
+
+import "errors"
+
+// ErrorAction Hook 失败后的处理动作
+type ErrorAction int
+
+const (
+	ActionAbort    ErrorAction = iota // 中断管道（默认行为）
+	ActionContinue                    // 记录错误后继续执行后续 Hook
+	ActionRetry                       // 重新执行 Hook
+	ActionReplace                     // 以替换后的错误中断管道
+)
+
+// ErrorDecision OnErrorHandle 对 Hook 错误的处理决定
+type ErrorDecision struct {
+	Action ErrorAction
+	Err    error // ActionReplace 时替换原错误
+}
+
+// DecideContinue 记录错误后继续执行（仅本次，相当于一次 SkipOnError）
+func DecideContinue() ErrorDecision {
+	return ErrorDecision{Action: ActionContinue}
+}
+
+// DecideAbort 以原错误中断管道（即使设置了 SkipOnError）
+func DecideAbort() ErrorDecision {
+	return ErrorDecision{Action: ActionAbort}
+}
+
+// DecideRetry 重新执行 Hook（经过中间件、超时、降级处理），再次失败时会再次调用处理函数；Permanent 错误不重试
+func DecideRetry() ErrorDecision {
+	return ErrorDecision{Action: ActionRetry}
+}
+
+// ReplaceError 以 err 替换原错误并中断管道（如转换为业务错误码）
+func ReplaceError(err error) ErrorDecision {
+	return ErrorDecision{Action: ActionReplace, Err: err}
+}
+
+// ErrorHandleFunc Hook 级错误处理函数
+type ErrorHandleFunc[C Context] func(ctx C, err error) ErrorDecision
+
+// OnErrorHandle 设置 Hook 失败（含降级处理之后）时的处理函数，由其决定继续、中断、重试或替换错误。
+// 设置后 SkipOnError 不再生效；处理函数需要自行限制 DecideRetry 的次数，上下文取消后不再重试
+func (b *HookBuilder[C, Option, Payload, Result]) OnErrorHandle(fn ErrorHandleFunc[C]) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.errorHandle = fn
+	return b
+}
+
+// continuedError 处理函数决定继续执行的错误（仍记录在 HookStat 中）
+type continuedError struct {
+	error
+}
+
+func (e continuedError) Unwrap() error {
+	return e.error
+}
+
+// handleErrors 按 Hook 的错误处理函数处理失败
+func handleErrors[C Context, Option any, Payload any, Result any](
+	handler HookHandler[C, Option, Payload, Result],
+	handle ErrorHandleFunc[C],
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+		for {
+			err := handler(ctx, pipeCtx)
+			if err == nil {
+				return nil
+			}
+
+			decision := handle(ctx, err)
+			switch decision.Action {
+			case ActionContinue:
+				return continuedError{err}
+			case ActionRetry:
+				// 上下文已取消或错误标注为 Permanent 时不再重试
+				if retryable, marked := RetryableOf(err); ctx.Err() != nil || (marked && !retryable) {
+					return err
+				}
+				continue
+			case ActionReplace:
+				if decision.Err != nil {
+					return decision.Err
+				}
+			}
+			return err
+		}
+	}
+}
+
+// skips 错误是否跳过而不中断管道：设置了错误处理函数时由其决定，否则取 SkipOnError 与 SkipOnErrorIf 的条件
+func (h *Hook[C, Option, Payload, Result]) skips(err error) bool {
+	if h.errorHandle != nil {
+		var continued continuedError
+		return errors.As(err, &continued)
+	}
+	if h.SkipOnError {
+		return true
+	}
+	for _, skip := range h.skipErrors {
+		if skip(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorIs 返回匹配任一目标错误（errors.Is）的条件，用于 SkipOnErrorIf
+func ErrorIs(targets ...error) func(err error) bool {
+	return func(err error) bool {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,134 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestOnErrorHandle 测试 Hook 级错误处理决定
+func TestOnErrorHandle(t *testing.T) {
+	errTransient := errors.New("transient")
+	errBusiness := errors.New("order rejected")
+
+	type handler = func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error
+	failing := func(err error) handler {
+		return func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error { return err }
+	}
+	appending := func(name string) handler {
+		return func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			pipeCtx.Result.Output = append(pipeCtx.Result.Output, name)
+			return nil
+		}
+	}
+
+	t.Run("retry", func(t *testing.T) {
+		attempts := 0
+		pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+			AddHookWithOptions(NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+				attempts++
+				if attempts < 3 {
+					return errTransient
+				}
+				return nil
+			}).WithName("flaky").OnErrorHandle(func(ctx Context, err error) ErrorDecision {
+				if errors.Is(err, errTransient) {
+					return DecideRetry()
+				}
+				return DecideAbort()
+			}).Build())
+
+		if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil || attempts != 3 {
+			t.Errorf("Expected success after 3 attempts, got %v after %d", err, attempts)
+		}
+	})
+
+	t.Run("continue", func(t *testing.T) {
+		var stats *ExecutionStats
+		pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+			AddHookWithOptions(NewHook(failing(errTransient)).WithName("optional").
+				OnErrorHandle(func(ctx Context, err error) ErrorDecision { return DecideContinue() }).Build()).
+			AddNamedHook("after", appending("after")).
+			OnAfterExecute(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult], err error) {
+				stats = pipeCtx.Stats()
+			})
+
+		result, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+		if err != nil || len(result.Output) != 1 {
+			t.Fatalf("Expected pipeline to continue, got %v", err)
+		}
+		if !errors.Is(stats.HookStats[0].Error, errTransient) {
+			t.Errorf("Expected continued error recorded, got %v", stats.HookStats[0].Error)
+		}
+	})
+
+	t.Run("abort overrides SkipOnError", func(t *testing.T) {
+		pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+			AddHookWithOptions(NewHook(failing(errTransient)).WithName("strict").SkipOnError().
+				OnErrorHandle(func(ctx Context, err error) ErrorDecision { return DecideAbort() }).Build()).
+			AddNamedHook("after", appending("after"))
+
+		if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); !errors.Is(err, errTransient) {
+			t.Errorf("Expected abort with original error, got %v", err)
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+			AddHookWithOptions(NewHook(failing(errTransient)).WithName("submit").
+				OnErrorHandle(func(ctx Context, err error) ErrorDecision { return ReplaceError(errBusiness) }).Build())
+
+		_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+		var pipeErr *PipeError
+		if !errors.Is(err, errBusiness) || errors.Is(err, errTransient) || !errors.As(err, &pipeErr) || pipeErr.HookName != "submit" {
+			t.Errorf("Expected replaced error at submit, got %v", err)
+		}
+	})
+
+	t.Run("retry stops on cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+			AddHookWithOptions(NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+				attempts++
+				cancel()
+				return errTransient
+			}).WithName("flaky").OnErrorHandle(func(ctx Context, err error) ErrorDecision { return DecideRetry() }).Build())
+
+		if _, err := pipeline.ExecuteStd(ctx, &TestPayload{}); !errors.Is(err, errTransient) || attempts != 1 {
+			t.Errorf("Expected no retry after cancel, got %v after %d attempts", err, attempts)
+		}
+	})
+}
+
+// TestSkipOnErrorIf 测试只跳过预期的错误
+func TestSkipOnErrorIf(t *testing.T) {
+	errNotFound := errors.New("not found")
+	errUnexpected := errors.New("connection reset")
+
+	newPipeline := func(hookErr error) *Pipeline[Context, NoOption, TestPayload, TestResult] {
+		return NewSimplePipeline[TestPayload, TestResult]("test").
+			AddHookWithOptions(NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+				return hookErr
+			}).WithName("lookup").
+				SkipOnErrorIf(ErrorIs(errNotFound)).
+				SkipOnErrorIf(func(err error) bool { return err.Error() == "empty" }).
+				Build()).
+			AddNamedHook("after", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+				pipeCtx.Result.Output = append(pipeCtx.Result.Output, "after")
+				return nil
+			})
+	}
+
+	for _, hookErr := range []error{fmt.Errorf("user 7: %w", errNotFound), errors.New("empty")} {
+		result, err := newPipeline(hookErr).ExecuteStd(context.Background(), &TestPayload{})
+		if err != nil || len(result.Output) != 1 {
+			t.Errorf("Expected %v to be skipped, got %v", hookErr, err)
+		}
+	}
+
+	if _, err := newPipeline(errUnexpected).ExecuteStd(context.Background(), &TestPayload{}); !errors.Is(err, errUnexpected) {
+		t.Errorf("Expected unexpected error to abort, got %v", err)
+	}
+}
@@ -1,13 +1,172 @@
 package pipeline
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// ErrIncompatibleContext 标准 context.Context 无法转换为管道的上下文类型
+var ErrIncompatibleContext = errors.New("context is not compatible with pipeline context type")
+
+// ErrMaxIterationsExceeded 循环超过最大迭代次数
+var ErrMaxIterationsExceeded = errors.New("max iterations exceeded")
+
+// ErrPayloadMutated Hook 修改了只读 Payload（仅在 pipedebug 构建下检测）
+var ErrPayloadMutated = errors.New("payload mutated by hook")
+
+// ErrPipelineClosed 管道已 Close
+var ErrPipelineClosed = errors.New("pipeline closed")
+
+// ErrExpvarExists expvar 变量名已被占用
+var ErrExpvarExists = errors.New("expvar name already published")
+
+// ErrHookNotCancelled 上下文取消后 Hook 超过宽限时间仍未返回（见 WithCancelGrace）
+var ErrHookNotCancelled = errors.New("hook ignored cancellation")
+
+// ErrHookNotRegistered Hook 名称未在注册表中注册
+var ErrHookNotRegistered = errors.New("hook not registered")
+
+// ErrInvalidFlow 管道装配 DSL 语法错误
+var ErrInvalidFlow = errors.New("invalid flow")
+
+// ErrHookNotFound 管道中不存在指定名称的 Hook
+var ErrHookNotFound = errors.New("hook not found")
+
+// ErrMissingDataKey Hook 声明读取的共享数据键没有被之前的 Hook 写入（见 Validate）
+var ErrMissingDataKey = errors.New("data key read before written")
+
+// ErrHookSunset 弃用 Hook 已超过下线日期（见 EnforceSunset）
+var ErrHookSunset = errors.New("deprecated hook past sunset")
+
+// ErrHookOutputNotFound Hook 未发布输出（见 HookOutput）
+var ErrHookOutputNotFound = errors.New("hook output not found")
+
+// ErrHookOutputType Hook 输出的类型与读取的类型不符
+var ErrHookOutputType = errors.New("hook output type mismatch")
+
+// ErrNoResultReducer 未设置 Result 归并函数时调用 Contribute（见 WithResultReducer）
+var ErrNoResultReducer = errors.New("no result reducer")
+
+// ErrAborted 执行已被 Abort 中断（作为被取消分支的原因）
+var ErrAborted = errors.New("pipeline aborted")
+
+// ErrQueueFull 异步执行排队已满
+var ErrQueueFull = errors.New("async queue full")
+
+// ErrStandbyClosed 预热资源池已关闭
+var ErrStandbyClosed = errors.New("standby pool closed")
+
+// ErrSchemaMismatch 序列化数据的类型或版本与当前结构体不匹配
+var ErrSchemaMismatch = errors.New("schema mismatch")
+
+// ErrCyclicValue 值中存在循环引用，无法计算哈希
+var ErrCyclicValue = errors.New("cyclic value")
+
+// ErrHookTimeout Hook 执行超时
+var ErrHookTimeout = errors.New("hook timeout")
+
+// ErrInsufficientTime 剩余截止时间不足以完成 Hook
+var ErrInsufficientTime = errors.New("insufficient time before deadline")
+
+// ErrHookPanic Hook 在独立的 goroutine（并行分支、集合元素等）中 panic
+var ErrHookPanic = errors.New("hook panic")
+
+// PanicError 从 goroutine 中恢复的 panic，保留 panic 值和堆栈
+type PanicError struct {
+	Value any    // panic 值
+	Stack []byte // panic 时的堆栈
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrHookPanic, e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+	return ErrHookPanic
+}
+
+// NewPanicError 在 recover 所在的 defer 中调用，记录当前堆栈（供自定义恢复中间件使用）
+func NewPanicError(r any) *PanicError {
+	return &PanicError{Value: r, Stack: debug.Stack()}
+}
+
+// ErrMergeConflict 并行分支对同一字段写入了不同的值
+var ErrMergeConflict = errors.New("result merge conflict")
+
+// MergeConflictError 结果合并冲突（调试模式下由 FieldMerger 返回）
+type MergeConflictError struct {
+	Field    string // 冲突字段
+	Branches []int  // 写入该字段的分支索引
+}
+
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("%v: field '%s' written by branches %v", ErrMergeConflict, e.Field, e.Branches)
+}
+
+func (e *MergeConflictError) Unwrap() error {
+	return ErrMergeConflict
+}
+
+// ValidationError Payload/Result 校验失败，可通过 errors.As 从 PipeError 中取出
+type ValidationError struct {
+	Target string       // 校验对象（如 payload）
+	Fields []FieldError // 字段错误明细
+	Err    error        // 原始错误
+}
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	Field   string // 字段路径（如 Order.Items[0].SKU）
+	Tag     string // 校验规则（如 required、min）
+	Param   string // 规则参数
+	Message string // 错误描述
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return fmt.Sprintf("%s validation failed: %v", e.Target, e.Err)
+	}
+
+	msg := fmt.Sprintf("%s validation failed:", e.Target)
+	for _, f := range e.Fields {
+		msg += fmt.Sprintf(" %s(%s)", f.Field, f.Tag)
+	}
+	return msg
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ResultInvalidError 结果未通过 OnValidateResult 校验
+type ResultInvalidError struct {
+	PipelineName string // 管道名称
+	Err          error  // 校验错误
+}
+
+func (e *ResultInvalidError) Error() string {
+	return fmt.Sprintf("pipeline '%s' produced invalid result: %v", e.PipelineName, e.Err)
+}
+
+func (e *ResultInvalidError) Unwrap() error {
+	return e.Err
+}
 
 // PipeError 管道执行错误
 type PipeError struct {
-	PipelineName string // 管道名称
-	HookName     string // Hook 名称
-	HookIndex    int    // Hook 索引
-	Err          error  // 原始错误
+	PipelineName string         // 管道名称
+	HookName     string         // Hook 名称
+	HookIndex    int            // Hook 索引
+	Fields       map[string]any // Hook 通过 AddLogField 添加的结构化字段
+	Class        ErrorClass     // 错误分类
+	Severity     Severity       // 错误严重程度
+	Code         string         // 机器可读的错误码（见 WithCode、HookBuilder.WithErrorCode、ErrorCoder）
+	Retryable    bool           // 是否值得重试（见 Retryable/Permanent、ShouldRetry），供死信路由等使用
+	Data         map[string]any // 失败时的共享数据快照（见 WithErrorContextCapture）
+	Result       string         // 失败时的 Result 摘要（见 WithErrorContextCapture）
+	Err          error          // 原始错误
 }
 
 func (e *PipeError) Error() string {
@@ -27,6 +186,83 @@ func (e *PipeError) Unwrap() error {
 	return e.Err
 }
 
+// errorCode 实现 codeCarrier：未设置 Code 时取内部错误的错误码
+func (e *PipeError) errorCode() string {
+	if e.Code != "" {
+		return e.Code
+	}
+	return CodeOf(e.Err)
+}
+
+// PipeErrors 多个 Hook 的失败（如并行分支），每个 PipeError 保留各自的 Hook 名称与索引
+// 实现 Unwrap() []error，errors.Is/As 会检查其中所有错误
+type PipeErrors []*PipeError
+
+func (e PipeErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d hooks failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+func (e PipeErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// newPipeErrors 收集非 nil 的 PipeError，全部为 nil 时返回 nil
+func newPipeErrors(errs []*PipeError) error {
+	var collected PipeErrors
+	for _, err := range errs {
+		if err != nil {
+			collected = append(collected, err)
+		}
+	}
+	if len(collected) == 0 {
+		return nil
+	}
+	return collected
+}
+
+// fieldsError 携带 Hook 结构化字段的错误（传给 OnError）
+type fieldsError struct {
+	fields map[string]any
+	err    error
+}
+
+func (e *fieldsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fieldsError) Unwrap() error {
+	return e.err
+}
+
+// withFields 为错误附加结构化字段，没有字段时原样返回
+func withFields(err error, fields map[string]any) error {
+	if len(fields) == 0 {
+		return err
+	}
+	return &fieldsError{fields: fields, err: err}
+}
+
+// ErrorFields 获取错误携带的 Hook 结构化字段（OnError 收到的错误或 PipeError）
+func ErrorFields(err error) map[string]any {
+	var fe *fieldsError
+	if errors.As(err, &fe) {
+		return fe.fields
+	}
+	var pe *PipeError
+	if errors.As(err, &pe) {
+		return pe.Fields
+	}
+	return nil
+}
+
 // newPipeError 创建管道错误
 func newPipeError(pipelineName, hookName string, hookIndex int, err error) *PipeError {
 	return &PipeError{
@@ -0,0 +1,81 @@
+package pipeline
+
+import (
+	"sync"
+	"time"
+)
+
+// estimateWeight 历史平均耗时的指数移动平均权重
+const estimateWeight = 0.2
+
+// durationHistory Hook 历史耗时（指数移动平均）
+type durationHistory struct {
+	mu    sync.Mutex
+	avg   time.Duration
+	count int
+}
+
+// observe 记录一次执行耗时
+func (h *durationHistory) observe(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.count == 0 {
+		h.avg = d
+	} else {
+		h.avg = time.Duration(float64(h.avg)*(1-estimateWeight) + float64(d)*estimateWeight)
+	}
+	h.count++
+}
+
+// average 获取平均耗时，没有历史记录时 ok 为 false
+func (h *durationHistory) average() (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.avg, h.count > 0
+}
+
+// WithEstimatedDuration 声明 Hook 的预估耗时（没有历史数据时用于执行估算和截止时间检查）
+func (b *HookBuilder[C, Option, Payload, Result]) WithEstimatedDuration(d time.Duration) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.estimate = func(*Payload) time.Duration { return d }
+	return b
+}
+
+// WithCostFunc 声明与 Payload 相关的预估耗时（如按条目数估算），优先于历史数据
+func (b *HookBuilder[C, Option, Payload, Result]) WithCostFunc(fn func(payload *Payload) time.Duration) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.cost = fn
+	return b
+}
+
+// EstimateDuration 估算执行 payload 所需的总时间，便于调用方提前选择同步或异步处理
+// 每个 Hook 依次取：WithCostFunc > 历史平均耗时 > WithEstimatedDuration
+func (p *Pipeline[C, Option, Payload, Result]) EstimateDuration(payload *Payload) time.Duration {
+	var total time.Duration
+	for _, hook := range p.hooks {
+		total += p.estimateHook(hook, payload)
+	}
+	return total
+}
+
+// estimateHook 估算单个 Hook 的耗时
+func (p *Pipeline[C, Option, Payload, Result]) estimateHook(hook *Hook[C, Option, Payload, Result], payload *Payload) time.Duration {
+	if hook.cost != nil {
+		return hook.cost(payload)
+	}
+	if avg, ok := p.history(hook).average(); ok {
+		return avg
+	}
+	if hook.estimate != nil {
+		return hook.estimate(payload)
+	}
+	return 0
+}
+
+// history 获取 Hook 的历史耗时记录
+func (p *Pipeline[C, Option, Payload, Result]) history(hook *Hook[C, Option, Payload, Result]) *durationHistory {
+	if h, ok := p.durations.Load(hook); ok {
+		return h.(*durationHistory)
+	}
+	h, _ := p.durations.LoadOrStore(hook, &durationHistory{})
+	return h.(*durationHistory)
+}
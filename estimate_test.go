@@ -0,0 +1,33 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestEstimateDuration 测试执行耗时估算
+func TestEstimateDuration(t *testing.T) {
+	declared := NewHook(processHook).WithEstimatedDuration(time.Hour).Build()
+	perItem := NewHook(processHook).WithCostFunc(func(p *TestPayload) time.Duration {
+		return time.Duration(p.UserID) * time.Second
+	}).Build()
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHookWithOptions(declared).
+		AddHookWithOptions(perItem)
+
+	if got := pipeline.EstimateDuration(&TestPayload{UserID: 3}); got != time.Hour+3*time.Second {
+		t.Errorf("Expected declared estimates, got %v", got)
+	}
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 3}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 执行后声明值被历史耗时替代，WithCostFunc 仍然优先
+	if got := pipeline.EstimateDuration(&TestPayload{UserID: 3}); got >= time.Hour || got < 3*time.Second {
+		t.Errorf("Expected history-based estimate, got %v", got)
+	}
+}
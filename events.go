@@ -0,0 +1,122 @@
+package pipeline
+
+import "time"
+
+// RetryEvent 重试事件
+type RetryEvent struct {
+	Hook    string        // Hook 名称
+	Index   int           // Hook 索引
+	Attempt int           // 即将进行的重试次数（从 1 开始）
+	Delay   time.Duration // 重试前的等待时间
+	Err     error         // 上一次尝试的错误
+}
+
+// TimeoutEvent 超时事件
+type TimeoutEvent struct {
+	Hook    string        // Hook 名称
+	Index   int           // Hook 索引
+	Timeout time.Duration // 超时时间
+}
+
+// ZombieEvent 僵尸 Hook 事件：超时或取消后执行不再等待、但 goroutine 仍在运行的 Hook
+type ZombieEvent struct {
+	Hook   string // Hook 名称
+	Index  int    // Hook 索引
+	Reason error  // 放弃等待的原因（ErrHookTimeout 或 ErrHookNotCancelled）
+}
+
+// OnRetry 注册重试回调，由重试中间件在每次重试前触发
+func (p *Pipeline[C, Option, Payload, Result]) OnRetry(
+	fn func(ctx C, event RetryEvent),
+) *Pipeline[C, Option, Payload, Result] {
+	p.onRetry = append(p.onRetry, fn)
+	return p
+}
+
+// OnTimeout 注册超时回调，由超时中间件在 Hook 超时时触发
+func (p *Pipeline[C, Option, Payload, Result]) OnTimeout(
+	fn func(ctx C, event TimeoutEvent),
+) *Pipeline[C, Option, Payload, Result] {
+	p.onTimeout = append(p.onTimeout, fn)
+	return p
+}
+
+// OnZombie 注册僵尸 Hook 回调：Hook 超时（或取消后超过 WithCancelGrace）而其 goroutine 仍在运行时触发，
+// 便于在泄漏耗尽资源前发现不响应取消的 Hook；当前数量见 Zombies
+func (p *Pipeline[C, Option, Payload, Result]) OnZombie(
+	fn func(ctx C, event ZombieEvent),
+) *Pipeline[C, Option, Payload, Result] {
+	p.onZombie = append(p.onZombie, fn)
+	return p
+}
+
+// Zombies 当前仍在后台运行的僵尸 Hook goroutine 数量
+func (p *Pipeline[C, Option, Payload, Result]) Zombies() int64 {
+	return p.zombies.Load()
+}
+
+// NotifyRetry 上报重试事件（供重试中间件调用）
+func (p *PipeContext[Option, Payload, Result]) NotifyRetry(attempt int, delay time.Duration, err error) {
+	if p.state.events.retry == nil {
+		return
+	}
+	name, index := p.CurrentHook()
+	p.state.events.retry(RetryEvent{Hook: name, Index: index, Attempt: attempt, Delay: delay, Err: err})
+}
+
+// NotifyTimeout 上报超时事件（供超时中间件调用）
+func (p *PipeContext[Option, Payload, Result]) NotifyTimeout(timeout time.Duration) {
+	if p.state.events.timeout == nil {
+		return
+	}
+	name, index := p.CurrentHook()
+	p.state.events.timeout(TimeoutEvent{Hook: name, Index: index, Timeout: timeout})
+}
+
+// reportZombie 记录被放弃的 Hook goroutine，exited 在其结束时关闭
+func (p *PipeContext[Option, Payload, Result]) reportZombie(reason error, exited <-chan struct{}) {
+	p.stats.addZombie()
+	if p.state.events.zombie == nil {
+		return
+	}
+	name, index := p.CurrentHook()
+	p.state.events.zombie(ZombieEvent{Hook: name, Index: index, Reason: reason}, exited)
+}
+
+// eventHandlers 执行期间的事件回调（由 Execute 绑定 ctx 后设置）
+type eventHandlers struct {
+	retry   func(RetryEvent)
+	timeout func(TimeoutEvent)
+	zombie  func(ZombieEvent, <-chan struct{})
+}
+
+// bindEvents 将管道的事件回调绑定到本次执行
+func (p *Pipeline[C, Option, Payload, Result]) bindEvents(ctx C) eventHandlers {
+	var events eventHandlers
+
+	if len(p.onRetry) > 0 {
+		events.retry = func(event RetryEvent) {
+			for _, fn := range p.onRetry {
+				fn(ctx, event)
+			}
+		}
+	}
+	if len(p.onTimeout) > 0 {
+		events.timeout = func(event TimeoutEvent) {
+			for _, fn := range p.onTimeout {
+				fn(ctx, event)
+			}
+		}
+	}
+	events.zombie = func(event ZombieEvent, exited <-chan struct{}) {
+		p.zombies.Add(1)
+		go func() {
+			<-exited
+			p.zombies.Add(-1)
+		}()
+		for _, fn := range p.onZombie {
+			fn(ctx, event)
+		}
+	}
+	return events
+}
@@ -0,0 +1,42 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestRetryAndTimeoutEvents 测试重试与超时事件回调
+func TestRetryAndTimeoutEvents(t *testing.T) {
+	var (
+		retries  []RetryEvent
+		timeouts []TimeoutEvent
+	)
+	errFlaky := errors.New("flaky")
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		OnRetry(func(ctx sylph.Context, event RetryEvent) {
+			retries = append(retries, event)
+		}).
+		OnTimeout(func(ctx sylph.Context, event TimeoutEvent) {
+			timeouts = append(timeouts, event)
+		}).
+		AddNamedHook("call", func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			pipeCtx.NotifyRetry(1, 10*time.Millisecond, errFlaky)
+			pipeCtx.NotifyTimeout(time.Second)
+			return nil
+		})
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(retries) != 1 || retries[0].Hook != "call" || retries[0].Attempt != 1 || !errors.Is(retries[0].Err, errFlaky) {
+		t.Errorf("Unexpected retry events: %+v", retries)
+	}
+	if len(timeouts) != 1 || timeouts[0].Timeout != time.Second {
+		t.Errorf("Unexpected timeout events: %+v", timeouts)
+	}
+}
@@ -0,0 +1,217 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventStoreHookName 写入事件存储出错时传给 OnError 的 Hook 名称
+const EventStoreHookName = "event-store"
+
+// ExecutionEventType 执行事件类型
+type ExecutionEventType string
+
+const (
+	EventExecutionStarted  ExecutionEventType = "execution_started"  // 执行开始
+	EventExecutionFinished ExecutionEventType = "execution_finished" // 执行结束
+	EventHookStarted       ExecutionEventType = "hook_started"       // Hook 开始
+	EventHookFinished      ExecutionEventType = "hook_finished"      // Hook 结束（含失败）
+	EventHookSkipped       ExecutionEventType = "hook_skipped"       // Hook 被跳过
+	EventDataSet           ExecutionEventType = "data_set"           // 共享数据写入（需 WithDataEvents）
+	EventDataDeleted       ExecutionEventType = "data_deleted"       // 共享数据删除（需 WithDataEvents）
+)
+
+// ExecutionEvent 执行过程中的单个事件
+type ExecutionEvent struct {
+	ExecutionID string             // 执行 ID（与 ExecutionStats.ExecutionID 一致）
+	Pipeline    string             // 管道名称
+	Seq         int64              // 执行内的事件序号（从 1 开始）
+	Type        ExecutionEventType // 事件类型
+	Hook        string             // Hook 名称（执行级事件为空）
+	Stage       string             // Hook 所属的阶段（见 Stage）
+	Index       int                // Hook 索引
+	Key         string             // 共享数据 key（数据事件）
+	Value       any                // 共享数据写入时的值快照（data_set，深拷贝，不随后续修改变化）
+	Data        []byte             // 经 WithCodecs 编码的 Payload（execution_started）或 Result（成功的 execution_finished）
+	Seed        uint64             // 本次执行的随机种子（execution_started）
+	Duration    time.Duration      // 耗时（结束事件）
+	Err         string             // 错误信息（结束事件）
+	At          time.Time          // 发生时间
+}
+
+// EventQuery 事件查询条件，零值字段表示不过滤
+type EventQuery struct {
+	ExecutionID string               // 执行 ID
+	Pipeline    string               // 管道名称
+	Types       []ExecutionEventType // 事件类型
+	Since       time.Time            // 不早于该时间
+	Until       time.Time            // 早于该时间
+}
+
+// Match 事件是否满足查询条件
+func (q EventQuery) Match(event ExecutionEvent) bool {
+	switch {
+	case q.ExecutionID != "" && event.ExecutionID != q.ExecutionID:
+		return false
+	case q.Pipeline != "" && event.Pipeline != q.Pipeline:
+		return false
+	case len(q.Types) > 0 && !slices.Contains(q.Types, event.Type):
+		return false
+	case !q.Since.IsZero() && event.At.Before(q.Since):
+		return false
+	case !q.Until.IsZero() && !event.At.Before(q.Until):
+		return false
+	}
+	return true
+}
+
+// EventStore 执行事件存储，用于事后排查特定的生产执行
+type EventStore interface {
+	Append(ctx context.Context, event ExecutionEvent) error
+	Query(ctx context.Context, query EventQuery) ([]ExecutionEvent, error)
+}
+
+// MemoryEventStore 基于内存的事件存储（用于测试和单机场景）
+type MemoryEventStore struct {
+	mu     sync.RWMutex
+	events []ExecutionEvent
+}
+
+// NewMemoryEventStore 创建内存事件存储
+func NewMemoryEventStore() *MemoryEventStore {
+	return &MemoryEventStore{}
+}
+
+// Append 追加事件
+func (s *MemoryEventStore) Append(ctx context.Context, event ExecutionEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+// Query 按写入顺序返回满足条件的事件
+func (s *MemoryEventStore) Query(ctx context.Context, query EventQuery) ([]ExecutionEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var events []ExecutionEvent
+	for _, event := range s.events {
+		if query.Match(event) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// WithEventStore 将每次执行的事件流（执行/Hook 开始与结束）按执行 ID 写入 store
+// 配置了 WithCodecs 时，开始事件携带编码后的 Payload，成功的结束事件携带编码后的 Result，可用于重放
+func (p *Pipeline[C, Option, Payload, Result]) WithEventStore(store EventStore) *Pipeline[C, Option, Payload, Result] {
+	p.eventStore = store
+	return p
+}
+
+// WithDataEvents 配合 WithEventStore 同时记录共享数据的 Set/Delete
+func (p *Pipeline[C, Option, Payload, Result]) WithDataEvents() *Pipeline[C, Option, Payload, Result] {
+	p.dataEvents = true
+	return p
+}
+
+// journal 单次执行的事件记录器，未配置事件存储时为 nil
+type journal struct {
+	store  EventStore
+	ctx    context.Context
+	stats  *ExecutionStats
+	data   bool
+	seq    atomic.Int64
+	report func(err error)
+}
+
+// newJournal 为本次执行创建事件记录器
+func (p *Pipeline[C, Option, Payload, Result]) newJournal(ctx C, stats *ExecutionStats) *journal {
+	if p.eventStore == nil {
+		return nil
+	}
+
+	// 执行被取消后仍需记录收尾事件，写入存储时不继承取消信号
+	return &journal{
+		store: p.eventStore,
+		ctx:   context.WithoutCancel(ctx),
+		stats: stats,
+		data:  p.dataEvents,
+		report: func(err error) {
+			for _, errFn := range p.onError {
+				errFn(ctx, EventStoreHookName, err)
+			}
+		},
+	}
+}
+
+// record 补全公共字段并写入事件存储
+func (j *journal) record(event ExecutionEvent) {
+	if j == nil {
+		return
+	}
+
+	event.ExecutionID = j.stats.ExecutionID
+	event.Pipeline = j.stats.PipelineName
+	event.Seq = j.seq.Add(1)
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+	if err := j.store.Append(j.ctx, event); err != nil {
+		j.report(err)
+	}
+}
+
+// hookFinished 记录 Hook 结束或跳过
+func (j *journal) hookFinished(stat HookStat) {
+	if j == nil {
+		return
+	}
+
+	eventType := EventHookFinished
+	if stat.Skipped {
+		eventType = EventHookSkipped
+	}
+	j.record(ExecutionEvent{
+		Type:     eventType,
+		Hook:     stat.Name,
+		Index:    stat.Index,
+		Stage:    stat.Stage,
+		Duration: stat.Duration,
+		Err:      errString(stat.Error),
+		At:       stat.EndTime,
+	})
+}
+
+// dataChanged 记录共享数据变更（未开启 WithDataEvents 时忽略）
+func (j *journal) dataChanged(eventType ExecutionEventType, hook string, index int, key string, value any) {
+	if j == nil || !j.data {
+		return
+	}
+	// 记录写入时的快照，避免事件随共享数据的后续修改而变化
+	if value != nil {
+		value = *DeepCopy(&value)
+	}
+	j.record(ExecutionEvent{Type: eventType, Hook: hook, Index: index, Key: key, Value: value})
+}
+
+// encodeEvent 为事件编码 Payload/Result，未配置事件存储或编解码器时返回 nil
+// 编码失败通过 OnError 报告，不影响执行
+func encodeEvent[T any](j *journal, codec Codec[T], v *T) []byte {
+	if j == nil || codec == nil || v == nil {
+		return nil
+	}
+
+	data, err := codec.Encode(v)
+	if err != nil {
+		j.report(fmt.Errorf("encode %s: %w", typeName[T](), err))
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,147 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestEventStore 测试执行事件流写入与按执行 ID 查询
+func TestEventStore(t *testing.T) {
+	store := NewMemoryEventStore()
+	hookErr := errors.New("failed")
+
+	var executionID string
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddNamedHook("load", func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			pipeCtx.Set("user", 1)
+			return nil
+		}).
+		AddNamedHook("save", func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			return hookErr
+		}).
+		WithEventStore(store).
+		WithDataEvents().
+		OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+			executionID = pipeCtx.Stats().ExecutionID
+		})
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); !errors.Is(err, hookErr) {
+		t.Fatalf("Expected hook error, got %v", err)
+	}
+	// 另一次执行的事件不应出现在查询结果中
+	_, _ = pipeline.Execute(newMockContext(), &TestPayload{})
+
+	events, err := store.Query(context.Background(), EventQuery{ExecutionID: executionID})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []ExecutionEventType{
+		EventExecutionStarted,
+		EventHookStarted, EventDataSet, EventHookFinished,
+		EventHookStarted, EventHookFinished,
+		EventExecutionFinished,
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %+v", len(want), events)
+	}
+	for i, event := range events {
+		if event.Type != want[i] || event.Seq != int64(i+1) {
+			t.Errorf("Expected event %d to be %s, got %s (seq %d)", i, want[i], event.Type, event.Seq)
+		}
+	}
+	if events[2].Hook != "load" || events[2].Key != "user" || events[2].Value != 1 {
+		t.Errorf("Expected data event from load, got %+v", events[2])
+	}
+	if events[5].Hook != "save" || events[5].Err != "failed" {
+		t.Errorf("Expected failed save event, got %+v", events[5])
+	}
+
+	finished, _ := store.Query(context.Background(), EventQuery{Types: []ExecutionEventType{EventExecutionFinished}})
+	if len(finished) != 2 {
+		t.Errorf("Expected 2 finished executions, got %d", len(finished))
+	}
+}
+
+// TestEventStoreCodecs 测试事件携带编码后的 Payload 和 Result，可解码重放
+func TestEventStoreCodecs(t *testing.T) {
+	store := NewMemoryEventStore()
+	payloadCodec := NewJSONCodec[TestPayload](1)
+	resultCodec := NewJSONCodec[TestResult](1)
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHook(processHook).
+		WithCodecs(payloadCodec, resultCodec).
+		WithEventStore(store)
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 7, Data: "x"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	events, _ := store.Query(context.Background(), EventQuery{
+		Types: []ExecutionEventType{EventExecutionStarted, EventExecutionFinished},
+	})
+	if len(events) != 2 {
+		t.Fatalf("Expected start and finish events, got %+v", events)
+	}
+
+	payload, err := payloadCodec.Decode(events[0].Data)
+	if err != nil || payload.UserID != 7 || payload.Data != "x" {
+		t.Errorf("Expected decodable payload, got %+v, %v", payload, err)
+	}
+	if result, err := resultCodec.Decode(events[1].Data); err != nil || len(result.Output) == 0 {
+		t.Errorf("Expected decodable result, got %+v, %v", result, err)
+	}
+}
+
+// cancelAwareStore 上下文已取消时拒绝写入的事件存储
+type cancelAwareStore struct {
+	*MemoryEventStore
+}
+
+func (s cancelAwareStore) Append(ctx context.Context, event ExecutionEvent) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.MemoryEventStore.Append(ctx, event)
+}
+
+// TestEventStoreAfterCancel 测试执行被取消后收尾事件仍会写入，且数据事件保存写入时的快照
+func TestEventStoreAfterCancel(t *testing.T) {
+	store := cancelAwareStore{NewMemoryEventStore()}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		AddHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			tags := []string{"a"}
+			pipeCtx.Set("tags", tags)
+			tags[0] = "changed"
+			cancel()
+			return nil
+		}).
+		WithEventStore(store).
+		WithDataEvents()
+
+	var reported error
+	pipeline.OnError(func(ctx Context, hookName string, err error) { reported = err })
+	_, _ = pipeline.ExecuteStd(ctx, &TestPayload{})
+	if reported != nil {
+		t.Errorf("Expected no event store errors, got %v", reported)
+	}
+
+	events, _ := store.Query(context.Background(), EventQuery{})
+	if len(events) == 0 || events[len(events)-1].Type != EventExecutionFinished {
+		t.Fatalf("Expected execution_finished after cancellation, got %+v", events)
+	}
+	for _, event := range events {
+		if event.Type == EventDataSet {
+			if tags := event.Value.([]string); tags[0] != "a" {
+				t.Errorf("Expected data event snapshot, got %v", tags)
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package pipeline
+
+// Executor 管道执行器接口
+// *Pipeline 实现了该接口；组合和适配相关的 API 均接受 Executor，
+// 便于在单元测试中替换为 mock 实现
+type Executor[C Context, Payload any, Result any] interface {
+	Execute(ctx C, payload *Payload) (*Result, error)
+}
+
+// ExecutorFunc 函数形式的 Executor
+type ExecutorFunc[C Context, Payload any, Result any] func(ctx C, payload *Payload) (*Result, error)
+
+// Execute 调用函数本身
+func (f ExecutorFunc[C, Payload, Result]) Execute(ctx C, payload *Payload) (*Result, error) {
+	return f(ctx, payload)
+}
+
+var _ Executor[Context, NoOption, NoOption] = (*Pipeline[Context, NoOption, NoOption, NoOption])(nil)
+
+// SubPipeline 将子管道（或任意 Executor）适配为 HookHandler
+// in 从当前上下文构造子管道的 Payload，out 将子管道的 Result 写回当前上下文
+func SubPipeline[C Context, Option any, Payload any, Result any, SubPayload any, SubResult any](
+	exec Executor[C, SubPayload, SubResult],
+	in func(pipeCtx *PipeContext[Option, Payload, Result]) *SubPayload,
+	out func(pipeCtx *PipeContext[Option, Payload, Result], result *SubResult),
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+		result, err := exec.Execute(ctx, in(pipeCtx))
+		if err != nil {
+			return err
+		}
+
+		if out != nil {
+			out(pipeCtx, result)
+		}
+
+		return nil
+	}
+}
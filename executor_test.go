@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestSubPipelineWithMockExecutor 测试使用 mock Executor 组合子管道
+func TestSubPipelineWithMockExecutor(t *testing.T) {
+	var mock Executor[sylph.Context, string, int] = ExecutorFunc[sylph.Context, string, int](
+		func(ctx sylph.Context, payload *string) (*int, error) {
+			if *payload == "" {
+				return nil, errors.New("empty")
+			}
+			n := len(*payload)
+			return &n, nil
+		},
+	)
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("parent").
+		AddNamedHook("sub", SubPipeline(
+			mock,
+			func(pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) *string {
+				return &pipeCtx.Payload.Data
+			},
+			func(pipeCtx *PipeContext[TestOption, TestPayload, TestResult], n *int) {
+				pipeCtx.Result.Metadata = map[string]any{"length": *n}
+			},
+		))
+
+	result, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1, Data: "abcd"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.Metadata["length"] != 4 {
+		t.Errorf("Expected length 4, got %v", result.Metadata["length"])
+	}
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1}); err == nil {
+		t.Error("Expected sub-pipeline error to propagate")
+	}
+}
+
+// TestPipelineIsExecutor 测试 Pipeline 实现 Executor
+func TestPipelineIsExecutor(t *testing.T) {
+	var exec Executor[sylph.Context, TestPayload, TestResult] = NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHook(processHook)
+
+	result, err := exec.Execute(newMockContext(), &TestPayload{UserID: 1, Data: "x"})
+	if err != nil || len(result.Output) != 1 {
+		t.Errorf("Expected successful execution through Executor, got %v, %v", result, err)
+	}
+}
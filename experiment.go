@@ -0,0 +1,114 @@
+package pipeline
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+)
+
+// experimentKind 实验步骤的复合 Hook 类型
+const experimentKind = "experiment"
+
+// ExperimentBuilder 实验步骤构建器：按权重（或 Payload 键的确定性哈希）从多个候选 Hook 中选择一个执行，
+// 用于对单个步骤做 A/B 测试，选中的变体记录在 HookStat.Variant 和累计统计中
+type ExperimentBuilder[C Context, Option any, Payload any, Result any] struct {
+	variants []experimentVariant[C, Option, Payload, Result]
+	splitBy  func(payload *Payload) string
+}
+
+// experimentVariant 实验变体
+type experimentVariant[C Context, Option any, Payload any, Result any] struct {
+	name     string
+	weight   int
+	handlers []HookHandler[C, Option, Payload, Result]
+}
+
+// Experiment 创建实验步骤
+func Experiment[C Context, Option any, Payload any, Result any]() *ExperimentBuilder[C, Option, Payload, Result] {
+	return &ExperimentBuilder[C, Option, Payload, Result]{}
+}
+
+// Variant 添加权重为 weight 的变体（权重为 0 时不会被选中，可用于暂停流量）；名称重复或权重为负时 panic
+func (e *ExperimentBuilder[C, Option, Payload, Result]) Variant(
+	name string,
+	weight int,
+	handlers ...HookHandler[C, Option, Payload, Result],
+) *ExperimentBuilder[C, Option, Payload, Result] {
+	if weight < 0 {
+		panic(fmt.Sprintf("pipeline: experiment variant '%s' has negative weight %d", name, weight))
+	}
+	if slices.ContainsFunc(e.variants, func(v experimentVariant[C, Option, Payload, Result]) bool { return v.name == name }) {
+		panic(fmt.Sprintf("pipeline: experiment variant '%s' already defined", name))
+	}
+	e.variants = append(e.variants, experimentVariant[C, Option, Payload, Result]{name: name, weight: weight, handlers: handlers})
+	return e
+}
+
+// SplitBy 按 Payload 键（如用户 ID）的哈希确定性分流：同一个键总是选中同一个变体（权重不变时）
+// 未设置时每次执行按权重随机选择
+func (e *ExperimentBuilder[C, Option, Payload, Result]) SplitBy(key func(payload *Payload) string) *ExperimentBuilder[C, Option, Payload, Result] {
+	e.splitBy = key
+	return e
+}
+
+// Build 构建实验 Hook；没有权重为正的变体时 panic
+func (e *ExperimentBuilder[C, Option, Payload, Result]) Build(name string) *Hook[C, Option, Payload, Result] {
+	total := 0
+	groups := make([]hookGroup[C, Option, Payload, Result], 0, len(e.variants))
+	for _, v := range e.variants {
+		total += v.weight
+		groups = append(groups, newHookGroup(name, "variant "+v.name, v.handlers))
+	}
+	if total == 0 {
+		panic(fmt.Sprintf("pipeline: experiment '%s' has no variant with positive weight", name))
+	}
+	variants := slices.Clone(e.variants)
+	splitBy := e.splitBy
+
+	return &Hook[C, Option, Payload, Result]{
+		Name: name,
+		Handler: func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+			var bucket int
+			if splitBy != nil {
+				h := fnv.New64a()
+				h.Write([]byte(name + "/" + splitBy(pipeCtx.Payload)))
+				bucket = int(h.Sum64() % uint64(total))
+			} else {
+				bucket = rand.IntN(total)
+			}
+
+			// 按累计权重定位变体
+			i := 0
+			for ; bucket >= variants[i].weight; i++ {
+				bucket -= variants[i].weight
+			}
+			pipeCtx.setVariant(variants[i].name)
+			return groups[i].run(ctx, pipeCtx)
+		},
+		kind:   experimentKind,
+		groups: groups,
+	}
+}
+
+// AddExperiment 添加实验步骤
+func (p *Pipeline[C, Option, Payload, Result]) AddExperiment(
+	name string,
+	experiment *ExperimentBuilder[C, Option, Payload, Result],
+) *Pipeline[C, Option, Payload, Result] {
+	return p.AddHookWithOptions(experiment.Build(name))
+}
+
+// setVariant 记录当前 Hook 选中的实验变体
+func (p *PipeContext[Option, Payload, Result]) setVariant(variant string) {
+	p.hookMu.Lock()
+	defer p.hookMu.Unlock()
+	p.hookVariant = variant
+}
+
+// CurrentVariant 返回当前 Hook 选中的实验变体（非实验步骤为空）
+func (p *PipeContext[Option, Payload, Result]) CurrentVariant() string {
+	p.hookMu.RLock()
+	defer p.hookMu.RUnlock()
+	return p.hookVariant
+}
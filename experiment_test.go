@@ -0,0 +1,114 @@
+package pipeline
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// newRankingExperiment 创建 control/treatment 两个变体的实验管道
+func newRankingExperiment(experiment *ExperimentBuilder[sylph.Context, TestOption, TestPayload, TestResult]) (*Pipeline[sylph.Context, TestOption, TestPayload, TestResult], *AggregateStats) {
+	agg := NewAggregateStats("search")
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("search").
+		AddExperiment("ranking", experiment.
+			Variant("control", 1, appendHook("control")).
+			Variant("treatment", 1, appendHook("treatment")))
+	pipeline.WithStatsSink(agg)
+	return pipeline, agg
+}
+
+// TestExperimentSplitBy 测试按 Payload 键确定性分流并记录变体
+func TestExperimentSplitBy(t *testing.T) {
+	pipeline, agg := newRankingExperiment(Experiment[sylph.Context, TestOption, TestPayload, TestResult]().
+		SplitBy(func(payload *TestPayload) string { return strconv.Itoa(payload.UserID) }))
+
+	chosen := make(map[int]string)
+	for round := 0; round < 3; round++ {
+		for user := 0; user < 20; user++ {
+			result, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: user})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(result.Output) != 1 {
+				t.Fatalf("Expected exactly one variant to run, got %v", result.Output)
+			}
+			if prev, ok := chosen[user]; ok && prev != result.Output[0] {
+				t.Errorf("User %d switched from %s to %s", user, prev, result.Output[0])
+			}
+			chosen[user] = result.Output[0]
+		}
+	}
+
+	hook, _ := agg.Hook("ranking")
+	control, treatment := hook.Variants["control"], hook.Variants["treatment"]
+	if control.Calls+treatment.Calls != 60 || control.Calls == 0 || treatment.Calls == 0 {
+		t.Errorf("Expected both variants recorded over 60 calls, got %+v", hook.Variants)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteOpenMetrics(&buf, agg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `pipeline_hook_variant_calls_total{pipeline="search",hook="ranking",variant="control"} ` + strconv.Itoa(control.Calls)
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected %q in metrics, got:\n%s", expected, buf.String())
+	}
+
+	if desc := pipeline.Describe(); !strings.Contains(desc, "ranking (experiment)") || !strings.Contains(desc, "variant treatment:") {
+		t.Errorf("Expected experiment in description, got:\n%s", desc)
+	}
+}
+
+// TestExperimentWeights 测试按权重选择，权重为 0 的变体不会被选中
+func TestExperimentWeights(t *testing.T) {
+	var stats *ExecutionStats
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("search").
+		AddExperiment("ranking", Experiment[sylph.Context, TestOption, TestPayload, TestResult]().
+			Variant("control", 3, appendHook("control")).
+			Variant("paused", 0, appendHook("paused")))
+	pipeline.OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+		stats = pipeCtx.Stats()
+	})
+
+	for i := 0; i < 20; i++ {
+		result, err := pipeline.Execute(newMockContext(), &TestPayload{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Output[0] != "control" {
+			t.Fatalf("Expected paused variant never chosen, got %v", result.Output)
+		}
+		if stats.HookStats[0].Variant != "control" {
+			t.Errorf("Expected variant in HookStat, got %q", stats.HookStats[0].Variant)
+		}
+	}
+}
+
+// TestExperimentPanics 测试实验步骤的构建期错误
+func TestExperimentPanics(t *testing.T) {
+	cases := map[string]func(){
+		"negative weight": func() {
+			Experiment[sylph.Context, TestOption, TestPayload, TestResult]().Variant("a", -1)
+		},
+		"duplicate variant": func() {
+			Experiment[sylph.Context, TestOption, TestPayload, TestResult]().Variant("a", 1).Variant("a", 1)
+		},
+		"no positive weight": func() {
+			Experiment[sylph.Context, TestOption, TestPayload, TestResult]().Variant("a", 0).Build("exp")
+		},
+	}
+
+	for name, fn := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
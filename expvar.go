@@ -0,0 +1,104 @@
+package pipeline
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+)
+
+// ExpvarSink 通过标准库 expvar 发布累计统计（/debug/vars），无需 Prometheus 依赖
+type ExpvarSink struct {
+	set aggregateSet
+}
+
+// expvarSinks 已发布的 expvar 统计接收端（按名称）
+var (
+	expvarMu    sync.Mutex
+	expvarSinks = make(map[string]*ExpvarSink)
+)
+
+// NewExpvarSink 创建 expvar 统计接收端，并以 name 发布
+// name 已由 NewExpvarSink 发布时返回同一个接收端；已被其他变量占用时返回 ErrExpvarExists
+func NewExpvarSink(name string) (*ExpvarSink, error) {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+
+	if s, ok := expvarSinks[name]; ok {
+		return s, nil
+	}
+	if expvar.Get(name) != nil {
+		return nil, fmt.Errorf("%w: %s", ErrExpvarExists, name)
+	}
+
+	s := &ExpvarSink{}
+	expvar.Publish(name, expvar.Func(s.snapshot))
+	expvarSinks[name] = s
+	return s, nil
+}
+
+// Record 实现 StatsSink
+func (s *ExpvarSink) Record(stats *ExecutionStats) {
+	s.set.record(stats)
+}
+
+// snapshot 生成 expvar 输出：管道名称 -> 累计统计
+func (s *ExpvarSink) snapshot() any {
+	out := make(map[string]any)
+	for _, agg := range s.set.list() {
+		hooks := make(map[string]any)
+		for _, h := range agg.Hooks() {
+			hook := map[string]any{
+				"calls":             h.Calls,
+				"errors":            h.Errors,
+				"skipped":           h.Skipped,
+				"fallbacks":         h.Fallbacks,
+				"panics":            h.Panics,
+				"deprecated_calls":  h.Deprecated,
+				"total_duration_ms": millis(h.TotalDuration),
+				"mean_duration_ms":  millis(h.MeanDuration()),
+				"handler_ms":        millis(h.TotalHandler),
+				"middleware_ms":     millis(h.MiddlewareDuration()),
+				"max_duration_ms":   millis(h.MaxDuration),
+			}
+			if len(h.Variants) > 0 {
+				variants := make(map[string]any, len(h.Variants))
+				for name, v := range h.Variants {
+					variants[name] = map[string]any{
+						"calls":             v.Calls,
+						"errors":            v.Errors,
+						"total_duration_ms": millis(v.TotalDuration),
+					}
+				}
+				hook["variants"] = variants
+			}
+			if h.Profile.Samples > 0 {
+				layers := make(map[string]any, len(h.Profile.Layers))
+				for name, d := range h.Profile.Layers {
+					layers[name] = millis(d)
+				}
+				hook["profile"] = map[string]any{
+					"samples":    h.Profile.Samples,
+					"handler_ms": millis(h.Profile.Handler),
+					"layers_ms":  layers,
+				}
+			}
+			hooks[h.Name] = hook
+		}
+
+		failures := make(map[string]int)
+		for kind, n := range agg.FailuresByKind() {
+			failures[kind.Class.Label()] += n
+		}
+
+		out[agg.PipelineName] = map[string]any{
+			"executions":        agg.Executions(),
+			"failures":          agg.Failures(),
+			"failures_by_class": failures,
+			"zombies":           agg.Zombies(),
+			"total_duration_ms": millis(agg.TotalDuration()),
+			"labels":            agg.Labels(),
+			"hooks":             hooks,
+		}
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package pipeline
+
+import "time"
+
+// WithoutStats 不记录 Hook 级统计（ExecutionStats.HookStats 为空，执行级统计和 StatsSink 不受影响）。
+// 没有中间件和其他 Hook 包装时，Execute 走快速路径：直接依次调用 Hook，不为每个 Hook 创建闭包和 HookStat
+func (p *Pipeline[C, Option, Payload, Result]) WithoutStats() *Pipeline[C, Option, Payload, Result] {
+	p.noHookStats = true
+	return p
+}
+
+// fastPath 本次执行能否走快速路径：不记录 Hook 统计，且没有任何需要包装 Handler 或记录 Hook 事件的配置
+func (p *Pipeline[C, Option, Payload, Result]) fastPath() bool {
+	if !p.noHookStats || len(p.middlewares) > 0 || p.conditionalMiddlewares || len(p.stagePolicies) > 0 ||
+		p.panicIsolation || p.panicRecovery || p.immutablePayload || p.cancelGrace > 0 ||
+		p.deadlinePolicy != DeadlineIgnore || p.eventStore != nil || p.profileRate > 0 || p.coverage != nil {
+		return false
+	}
+	for _, hook := range p.hooks {
+		if !hook.plain() {
+			return false
+		}
+	}
+	return true
+}
+
+// plain Hook 是否不需要 runHook 的任何包装
+func (h *Hook[C, Option, Payload, Result]) plain() bool {
+	return h.Timeout == 0 && h.fallback == nil && h.shadow == nil && h.errorHandle == nil && h.once == nil
+}
+
+// runPlainHooks 快速路径：依次直接调用 Hook，错误处理与常规路径一致
+func (p *Pipeline[C, Option, Payload, Result]) runPlainHooks(
+	ctx C,
+	pipeCtx *PipeContext[Option, Payload, Result],
+) error {
+	for i, hook := range p.hooks {
+		if pipeCtx.IsAborted() {
+			return nil
+		}
+		if hook.isSlot() || hook.skipIf != nil && hook.skipIf(pipeCtx.Option) {
+			continue
+		}
+
+		name := p.hookName(hook)
+		pipeCtx.setCurrentHook(name, i, hook.stage)
+
+		// 上下文已取消时不再启动后续 Hook
+		err := ctx.Err()
+		if err == nil {
+			start := time.Now()
+			err = hook.Handler(ctx, pipeCtx)
+			if err == nil {
+				// 与常规路径一致记录历史耗时，EstimateDuration 和截止时间检查依赖它
+				p.history(hook).observe(time.Since(start))
+			}
+			if hook.deprecated != nil {
+				warnDeprecated(ctx, p.Name, name, hook.deprecated)
+			}
+		}
+		hook.complete(ctx, pipeCtx, err)
+		if err == nil {
+			continue
+		}
+
+		fields := pipeCtx.LogFields()
+		for _, errFn := range p.onError {
+			errFn(ctx, name, withFields(err, fields))
+		}
+		if p.skips(hook, err) {
+			continue
+		}
+		return p.hookError(pipeCtx, hook, name, i, err, pipeCtx.ClassifyError(err), fields)
+	}
+	return nil
+}
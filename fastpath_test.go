@@ -0,0 +1,99 @@
+package pipeline
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestWithoutStats 测试快速路径的执行语义与常规路径一致，且不记录 Hook 统计
+func TestWithoutStats(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		fast bool
+	}{{"fast path", true}, {"with middleware", false}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var stats *ExecutionStats
+			var failed []string
+			pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+				AddNamedHook("first", appendHook("first")).
+				AddHookWithOptions(NewHook(errorHook).WithName("optional").SkipOnError().Build()).
+				AddNamedHook("second", appendHook("second")).
+				AddNamedHook("broken", errorHook).
+				AddNamedHook("unreached", appendHook("unreached")).
+				WithoutStats()
+			if !tc.fast {
+				pipeline.Use(traceMiddleware("trace", new([]string)))
+			}
+			pipeline.OnError(func(ctx sylph.Context, hookName string, err error) {
+				failed = append(failed, hookName)
+			})
+			pipeline.OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+				stats = pipeCtx.Stats()
+			})
+
+			if got := pipeline.fastPath(); got != tc.fast {
+				t.Fatalf("Expected fastPath() = %v, got %v", tc.fast, got)
+			}
+
+			result, err := pipeline.Execute(newMockContext(), &TestPayload{})
+			var pipeErr *PipeError
+			if !errors.As(err, &pipeErr) || pipeErr.HookName != "broken" || pipeErr.HookIndex != 3 {
+				t.Fatalf("Expected PipeError from 'broken' at index 3, got %v", err)
+			}
+			if result != nil {
+				t.Errorf("Expected nil result on failure, got %+v", result)
+			}
+			if !reflect.DeepEqual(failed, []string{"optional", "broken"}) {
+				t.Errorf("Expected OnError for optional and broken, got %v", failed)
+			}
+			if len(stats.HookStats) != 0 || stats.Success {
+				t.Errorf("Expected failed execution without hook stats, got %+v", stats.HookStats)
+			}
+		})
+	}
+}
+
+// TestFastPathHistory 测试快速路径仍记录 Hook 历史耗时
+func TestFastPathHistory(t *testing.T) {
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddNamedHook("slow", func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}).
+		WithoutStats()
+	if !pipeline.fastPath() {
+		t.Fatal("Expected fast path")
+	}
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d := pipeline.EstimateDuration(&TestPayload{}); d < 10*time.Millisecond {
+		t.Errorf("Expected estimate from recorded history >= 10ms, got %v", d)
+	}
+}
+
+// TestFastPathAbort 测试快速路径遵循中断与 Option 跳过
+func TestFastPathAbort(t *testing.T) {
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHookWithOptions(NewHook(appendHook("skipped")).SkipIfOption(func(option *TestOption) bool { return true }).Build()).
+		AddNamedHook("first", appendHook("first")).
+		AddNamedHook("abort", func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			pipeCtx.Abort()
+			return nil
+		}).
+		AddNamedHook("after", appendHook("after")).
+		WithoutStats()
+
+	result, err := pipeline.Execute(newMockContext(), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Output, []string{"first"}) {
+		t.Errorf("Expected only 'first' to run, got %v", result.Output)
+	}
+}
@@ -0,0 +1,153 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ItemFunc 处理集合中单个元素的函数
+type ItemFunc[C Context, Item any, Out any] func(ctx C, index int, item Item) (Out, error)
+
+// ItemError 单个元素的处理错误
+type ItemError struct {
+	Index int   // 元素索引
+	Err   error // 原始错误
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// ItemErrors 按索引排序的元素错误集合
+type ItemErrors []*ItemError
+
+func (e ItemErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, itemErr := range e {
+		msgs = append(msgs, itemErr.Error())
+	}
+	return fmt.Sprintf("%d item(s) failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Unwrap 支持 errors.Is / errors.As 匹配任一元素错误
+func (e ItemErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, itemErr := range e {
+		errs = append(errs, itemErr)
+	}
+	return errs
+}
+
+// ForEachBuilder 集合遍历构建器
+type ForEachBuilder[C Context, Option any, Payload any, Result any, Item any, Out any] struct {
+	items       func(pipeCtx *PipeContext[Option, Payload, Result]) []Item
+	fn          ItemFunc[C, Item, Out]
+	parallelism int
+	reduce      func(pipeCtx *PipeContext[Option, Payload, Result], index int, out Out)
+}
+
+// ForEach 创建集合遍历：对 items 提取出的每个元素执行 fn
+func ForEach[C Context, Option any, Payload any, Result any, Item any, Out any](
+	items func(pipeCtx *PipeContext[Option, Payload, Result]) []Item,
+	fn ItemFunc[C, Item, Out],
+) *ForEachBuilder[C, Option, Payload, Result, Item, Out] {
+	return &ForEachBuilder[C, Option, Payload, Result, Item, Out]{
+		items:       items,
+		fn:          fn,
+		parallelism: 1,
+	}
+}
+
+// ItemPipeline 将子管道（或任意 Executor）适配为 ItemFunc
+func ItemPipeline[C Context, Item any, Out any](exec Executor[C, Item, Out]) ItemFunc[C, Item, *Out] {
+	return func(ctx C, index int, item Item) (*Out, error) {
+		return exec.Execute(ctx, &item)
+	}
+}
+
+// Parallelism 设置并发数（默认 1，即顺序执行）
+func (b *ForEachBuilder[C, Option, Payload, Result, Item, Out]) Parallelism(n int) *ForEachBuilder[C, Option, Payload, Result, Item, Out] {
+	if n < 1 {
+		n = 1
+	}
+	b.parallelism = n
+	return b
+}
+
+// Reduce 设置结果归并函数
+// 所有元素处理完成后，按索引顺序对每个成功的元素调用一次（单线程，可安全写入 Result）
+func (b *ForEachBuilder[C, Option, Payload, Result, Item, Out]) Reduce(
+	reduce func(pipeCtx *PipeContext[Option, Payload, Result], index int, out Out),
+) *ForEachBuilder[C, Option, Payload, Result, Item, Out] {
+	b.reduce = reduce
+	return b
+}
+
+// Build 构建集合遍历 Hook
+// 任一元素失败（包括 panic，记为 PanicError）时返回 ItemErrors（成功元素的结果仍会被归并）
+func (b *ForEachBuilder[C, Option, Payload, Result, Item, Out]) Build(name string) *Hook[C, Option, Payload, Result] {
+	items := b.items
+	fn := b.fn
+	parallelism := b.parallelism
+	reduce := b.reduce
+
+	return &Hook[C, Option, Payload, Result]{
+		Name: name,
+		Handler: func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+			list := items(pipeCtx)
+			outs := make([]Out, len(list))
+			errs := make([]error, len(list))
+
+			var wg sync.WaitGroup
+			sem := make(chan struct{}, parallelism)
+
+			for i, item := range list {
+				sem <- struct{}{}
+				wg.Add(1)
+
+				go func(i int, item Item) {
+					defer func() {
+						// 单个元素 panic 记为该元素的错误，不影响其他元素
+						if r := recover(); r != nil {
+							errs[i] = NewPanicError(r)
+						}
+						<-sem
+						wg.Done()
+					}()
+
+					if err := ctx.Err(); err != nil {
+						errs[i] = err
+						return
+					}
+
+					outs[i], errs[i] = fn(ctx, i, item)
+				}(i, item)
+			}
+			wg.Wait()
+
+			var itemErrs ItemErrors
+			for i := range list {
+				if errs[i] != nil {
+					itemErrs = append(itemErrs, &ItemError{Index: i, Err: errs[i]})
+					continue
+				}
+
+				if reduce != nil {
+					reduce(pipeCtx, i, outs[i])
+				}
+			}
+
+			if len(itemErrs) > 0 {
+				return itemErrs
+			}
+
+			return nil
+		},
+		kind: fmt.Sprintf("foreach, parallelism %d", parallelism),
+	}
+}
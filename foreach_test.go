@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestForEach 测试集合遍历与归并
+func TestForEach(t *testing.T) {
+	errOdd := errors.New("odd item")
+
+	items := func(pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) []string {
+		return strings.Split(pipeCtx.Payload.Data, ",")
+	}
+
+	upper := func(ctx sylph.Context, index int, item string) (string, error) {
+		if item == "odd" {
+			return "", errOdd
+		}
+		return strings.ToUpper(item), nil
+	}
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHookWithOptions(ForEach(items, upper).
+			Parallelism(4).
+			Reduce(func(pipeCtx *PipeContext[TestOption, TestPayload, TestResult], index int, out string) {
+				pipeCtx.Result.Output = append(pipeCtx.Result.Output, out)
+			}).
+			Build("upper-all"))
+
+	result, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1, Data: "a,b,c,d,e"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := strings.Join(result.Output, ","); got != "A,B,C,D,E" {
+		t.Errorf("Expected ordered output A,B,C,D,E, got %s", got)
+	}
+
+	_, err = pipeline.Execute(newMockContext(), &TestPayload{UserID: 1, Data: "a,odd,c,odd"})
+
+	var itemErrs ItemErrors
+	if !errors.As(err, &itemErrs) {
+		t.Fatalf("Expected ItemErrors, got %v", err)
+	}
+
+	if len(itemErrs) != 2 || itemErrs[0].Index != 1 || itemErrs[1].Index != 3 {
+		t.Errorf("Unexpected item errors: %v", itemErrs)
+	}
+
+	if !errors.Is(err, errOdd) {
+		t.Error("Expected errors.Is to match item error")
+	}
+}
+
+// TestForEachPanic 测试单个元素 panic 被记为该元素的错误
+func TestForEachPanic(t *testing.T) {
+	items := func(pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) []string {
+		return strings.Split(pipeCtx.Payload.Data, ",")
+	}
+
+	var outputs []string
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHookWithOptions(ForEach(items, func(ctx sylph.Context, index int, item string) (string, error) {
+			if item == "bad" {
+				panic("bad item")
+			}
+			return item, nil
+		}).
+			Parallelism(2).
+			Reduce(func(pipeCtx *PipeContext[TestOption, TestPayload, TestResult], index int, out string) {
+				outputs = append(outputs, out)
+			}).
+			Build("each"))
+
+	_, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1, Data: "a,bad,c"})
+
+	var itemErrs ItemErrors
+	if !errors.As(err, &itemErrs) || len(itemErrs) != 1 || itemErrs[0].Index != 1 {
+		t.Fatalf("Expected a single ItemError at index 1, got %v", err)
+	}
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "bad item" || len(panicErr.Stack) == 0 {
+		t.Errorf("Expected PanicError with stack, got %v", err)
+	}
+	if strings.Join(outputs, ",") != "a,c" {
+		t.Errorf("Expected healthy items reduced, got %v", outputs)
+	}
+}
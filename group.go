@@ -0,0 +1,80 @@
+package pipeline
+
+// hookGroup 复合 Hook（分支、循环等）中的一组子 Hook
+type hookGroup[C Context, Option any, Payload any, Result any] struct {
+	owner         string                              // 所属复合 Hook 的名称
+	label         string                              // 分组标签（用于 Describe）
+	hooks         []*Hook[C, Option, Payload, Result] // 子 Hook 列表
+	recoverPanics bool                                // 子 Hook 的 panic 转换为 PanicError（在独立 goroutine 中执行时使用）
+}
+
+// newHookGroup 由 Handler 列表创建子 Hook 分组
+func newHookGroup[C Context, Option any, Payload any, Result any](
+	owner string,
+	label string,
+	handlers []HookHandler[C, Option, Payload, Result],
+) hookGroup[C, Option, Payload, Result] {
+	hooks := make([]*Hook[C, Option, Payload, Result], 0, len(handlers))
+	for _, handler := range handlers {
+		hooks = append(hooks, &Hook[C, Option, Payload, Result]{Handler: handler})
+	}
+	return hookGroup[C, Option, Payload, Result]{owner: owner, label: label, hooks: hooks}
+}
+
+// edge 分组对应的分支边名称（"复合 Hook 名称/分组标签"）
+func (g hookGroup[C, Option, Payload, Result]) edge() string {
+	owner := g.owner
+	if owner == "" {
+		owner = "<unnamed>"
+	}
+	return owner + "/" + g.label
+}
+
+// run 依次执行分组内的 Hook
+// 遇到 Abort 时停止，遇到错误时返回（SkipOnError 的 Hook 除外）
+func (g hookGroup[C, Option, Payload, Result]) run(
+	ctx C,
+	pipeCtx *PipeContext[Option, Payload, Result],
+) error {
+	_, err := g.runIndexed(ctx, pipeCtx)
+	return err
+}
+
+// runIndexed 与 run 相同，同时返回出错的子 Hook 索引（无错误时为 -1）
+func (g hookGroup[C, Option, Payload, Result]) runIndexed(
+	ctx C,
+	pipeCtx *PipeContext[Option, Payload, Result],
+) (int, error) {
+	pipeCtx.state.coverage.hitEdge(pipeCtx.Name, g.edge())
+
+	for i, hook := range g.hooks {
+		if pipeCtx.IsAborted() {
+			return -1, nil
+		}
+		// 上下文无法派生时，兄弟分支的失败或中断只能在子 Hook 之间感知
+		if pipeCtx.branch != nil && pipeCtx.branch.Err() != nil {
+			return i, pipeCtx.branch.Err()
+		}
+
+		if err := g.call(hook, ctx, pipeCtx); err != nil && !hook.skips(err) {
+			return i, err
+		}
+	}
+	return -1, nil
+}
+
+// call 执行单个子 Hook，recoverPanics 为 true 时将 panic 转换为 PanicError
+func (g hookGroup[C, Option, Payload, Result]) call(
+	hook *Hook[C, Option, Payload, Result],
+	ctx C,
+	pipeCtx *PipeContext[Option, Payload, Result],
+) (err error) {
+	if g.recoverPanics {
+		defer func() {
+			if r := recover(); r != nil {
+				err = NewPanicError(r)
+			}
+		}()
+	}
+	return hook.Handler(ctx, pipeCtx)
+}
@@ -0,0 +1,172 @@
+package pipeline
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// HashPayload 计算 Payload 的确定性哈希（sha256 十六进制）
+// 字段按名称排序后序列化，带 `hash:"-"` 标签的字段（以及 `json:"-"`）不参与计算，
+// 供幂等、缓存、singleflight 等中间件统一派生 key
+func HashPayload[Payload any](payload *Payload) (string, error) {
+	return Hash(payload)
+}
+
+// Hash 计算任意值的确定性哈希，规则同 HashPayload
+// 值中存在循环引用时返回 ErrCyclicValue
+func Hash(v any) (string, error) {
+	c := canonicalizer{path: make(map[visit]bool)}
+	value, err := c.canonical(reflect.ValueOf(v))
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// visit 遍历路径上的引用（指针、map、切片）
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// canonicalizer 规范化遍历，path 记录当前路径上的引用用于检测循环
+// 只检测路径上的重复，同一对象被多处引用（非循环）时仍会完整展开
+type canonicalizer struct {
+	path map[visit]bool
+}
+
+// enter 进入引用，已在路径上时返回 ErrCyclicValue
+func (c *canonicalizer) enter(v reflect.Value) (func(), error) {
+	key := visit{ptr: v.Pointer(), typ: v.Type()}
+	if c.path[key] {
+		return nil, fmt.Errorf("%w: %s", ErrCyclicValue, v.Type())
+	}
+	c.path[key] = true
+	return func() { delete(c.path, key) }, nil
+}
+
+// canonical 将值转换为只包含 map/slice/基础类型的规范形式（map 键由 json 排序）
+func (c *canonicalizer) canonical(v reflect.Value) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	if v.CanInterface() {
+		switch m := v.Interface().(type) {
+		case json.Marshaler, encoding.TextMarshaler:
+			return m, nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return c.canonical(v.Elem())
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil, nil
+		}
+		leave, err := c.enter(v)
+		if err != nil {
+			return nil, err
+		}
+		defer leave()
+		return c.canonical(v.Elem())
+
+	case reflect.Struct:
+		out := make(map[string]any, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || field.Tag.Get("hash") == "-" {
+				continue
+			}
+
+			name := field.Name
+			if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+
+			value, err := c.canonical(v.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			out[name] = value
+		}
+		return out, nil
+
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		leave, err := c.enter(v)
+		if err != nil {
+			return nil, err
+		}
+		defer leave()
+
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := c.canonical(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			keyData, _ := json.Marshal(key)
+
+			value, err := c.canonical(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			out[string(keyData)] = value
+		}
+		return out, nil
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice {
+			if v.IsNil() {
+				return nil, nil
+			}
+			if v.Type().Elem().Kind() == reflect.Uint8 {
+				return v.Bytes(), nil
+			}
+			leave, err := c.enter(v)
+			if err != nil {
+				return nil, err
+			}
+			defer leave()
+		}
+
+		out := make([]any, v.Len())
+		for i := range out {
+			value, err := c.canonical(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = value
+		}
+		return out, nil
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil, nil
+
+	default:
+		return v.Interface(), nil
+	}
+}
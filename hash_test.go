@@ -0,0 +1,58 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+)
+
+type hashPayload struct {
+	UserID    int
+	Tags      map[string]int
+	RequestID string `hash:"-"`
+	Secret    string `json:"-"`
+}
+
+// TestHashPayload 测试确定性哈希与字段排除
+func TestHashPayload(t *testing.T) {
+	a := &hashPayload{UserID: 1, Tags: map[string]int{"a": 1, "b": 2}, RequestID: "r1", Secret: "s1"}
+	b := &hashPayload{UserID: 1, Tags: map[string]int{"b": 2, "a": 1}, RequestID: "r2", Secret: "s2"}
+
+	ha, err := HashPayload(a)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	hb, _ := HashPayload(b)
+	if ha != hb {
+		t.Errorf("Expected equal hashes ignoring excluded fields, got %s and %s", ha, hb)
+	}
+
+	b.UserID = 2
+	if hc, _ := HashPayload(b); hc == ha {
+		t.Error("Expected different hash after changing UserID")
+	}
+}
+
+type hashNode struct {
+	Name string
+	Next *hashNode
+}
+
+// TestHashCycle 测试循环引用返回 ErrCyclicValue，共享（非循环）引用正常计算
+func TestHashCycle(t *testing.T) {
+	node := &hashNode{Name: "a"}
+	node.Next = node
+	if _, err := Hash(node); !errors.Is(err, ErrCyclicValue) {
+		t.Errorf("Expected ErrCyclicValue, got %v", err)
+	}
+
+	self := map[string]any{}
+	self["self"] = self
+	if _, err := Hash(self); !errors.Is(err, ErrCyclicValue) {
+		t.Errorf("Expected ErrCyclicValue for map cycle, got %v", err)
+	}
+
+	shared := &hashNode{Name: "leaf"}
+	if _, err := Hash([]*hashNode{shared, shared}); err != nil {
+		t.Errorf("Expected shared references to hash, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"sync"
 	"time"
 )
 
@@ -17,6 +18,29 @@ type Hook[C Context, Option any, Payload any, Result any] struct {
 	Handler     HookHandler[C, Option, Payload, Result] // 处理函数
 	Timeout     time.Duration                           // 超时时间（0 表示无超时）
 	SkipOnError bool                                    // 错误时是否跳过而非中断整个管道
+	ErrorCode   string                                  // 失败时的错误码（错误本身未用 WithCode 标注时使用）
+
+	kind   string                                  // 复合 Hook 类型（branch/switch 等，普通 Hook 为空）
+	stage  string                                  // 所属的命名阶段（见 Stage，未分组时为空）
+	groups []hookGroup[C, Option, Payload, Result] // 复合 Hook 的子 Hook 分组
+	once   *sync.Once                              // 非 nil 时在管道实例内最多执行一次
+	skipIf func(option *Option) bool               // 返回 true 时本次执行跳过该 Hook
+
+	fallback    HookHandler[C, Option, Payload, Result] // 主处理失败（含重试）后的降级处理
+	shadow      HookHandler[C, Option, Payload, Result] // 与主处理并行、只用于对比的影子候选处理
+	errorHandle ErrorHandleFunc[C]                      // 失败后的处理决定（设置后 SkipOnError 不生效）
+	skipErrors  []func(err error) bool                  // 满足任一条件的错误跳过（SkipOnErrorIf）
+	deprecated  *Deprecation                            // 弃用信息（未弃用时为 nil）
+
+	tags   []string // 文档标签
+	reads  []string // 声明读取的共享数据键
+	writes []string // 声明写入的共享数据键
+
+	onSuccess []func(ctx C, pipeCtx *PipeContext[Option, Payload, Result])            // Hook 成功后的回调
+	onFailure []func(ctx C, pipeCtx *PipeContext[Option, Payload, Result], err error) // Hook 失败后的回调
+
+	estimate func(payload *Payload) time.Duration // 声明的预估耗时
+	cost     func(payload *Payload) time.Duration // 与 Payload 相关的预估耗时
 }
 
 // Execute 执行 Hook
@@ -27,6 +51,23 @@ func (h *Hook[C, Option, Payload, Result]) Execute(
 	return h.Handler(ctx, pipeCtx)
 }
 
+// complete 按执行结果调用 Hook 的 OnSuccess/OnFailure 回调
+func (h *Hook[C, Option, Payload, Result]) complete(
+	ctx C,
+	pipeCtx *PipeContext[Option, Payload, Result],
+	err error,
+) {
+	if err != nil {
+		for _, fn := range h.onFailure {
+			fn(ctx, pipeCtx, err)
+		}
+		return
+	}
+	for _, fn := range h.onSuccess {
+		fn(ctx, pipeCtx)
+	}
+}
+
 // HookBuilder Hook 构建器
 type HookBuilder[C Context, Option any, Payload any, Result any] struct {
 	hook *Hook[C, Option, Payload, Result]
@@ -44,6 +85,24 @@ func (b *HookBuilder[C, Option, Payload, Result]) WithDescription(desc string) *
 	return b
 }
 
+// WithTags 设置 Hook 的文档标签（如 "io"、"billing"），与已有标签合并
+func (b *HookBuilder[C, Option, Payload, Result]) WithTags(tags ...string) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.tags = append(b.hook.tags, tags...)
+	return b
+}
+
+// Reads 声明 Hook 读取的共享数据键（用于文档导出，Validate 检查之前的 Hook 已写入）
+func (b *HookBuilder[C, Option, Payload, Result]) Reads(keys ...string) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.reads = append(b.hook.reads, keys...)
+	return b
+}
+
+// Writes 声明 Hook 写入的共享数据键（用于文档导出和 Validate 的读写检查）
+func (b *HookBuilder[C, Option, Payload, Result]) Writes(keys ...string) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.writes = append(b.hook.writes, keys...)
+	return b
+}
+
 // WithTimeout 设置超时时间
 func (b *HookBuilder[C, Option, Payload, Result]) WithTimeout(timeout time.Duration) *HookBuilder[C, Option, Payload, Result] {
 	b.hook.Timeout = timeout
@@ -56,6 +115,56 @@ func (b *HookBuilder[C, Option, Payload, Result]) SkipOnError() *HookBuilder[C,
 	return b
 }
 
+// SkipOnErrorIf 只跳过满足任一条件的错误（如 ErrorIs(ErrNotFound)），其他错误仍中断管道
+// 可多次调用追加条件；同时设置 SkipOnError 时跳过所有错误
+func (b *HookBuilder[C, Option, Payload, Result]) SkipOnErrorIf(predicates ...func(err error) bool) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.skipErrors = append(b.hook.skipErrors, predicates...)
+	return b
+}
+
+// WithErrorCode 设置 Hook 失败时的错误码（写入 PipeError.Code，错误本身的 WithCode 标注优先）
+func (b *HookBuilder[C, Option, Payload, Result]) WithErrorCode(code string) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.ErrorCode = code
+	return b
+}
+
+// Once 设置 Hook 在管道实例内最多执行一次（如嵌入流程中的延迟初始化），之后的执行记录为跳过
+func (b *HookBuilder[C, Option, Payload, Result]) Once() *HookBuilder[C, Option, Payload, Result] {
+	b.hook.once = new(sync.Once)
+	return b
+}
+
+// SkipIfOption 根据本次执行的 Option 决定是否跳过 Hook（如 EnableCache 为 false 时跳过缓存 Hook）
+func (b *HookBuilder[C, Option, Payload, Result]) SkipIfOption(fn func(option *Option) bool) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.skipIf = fn
+	return b
+}
+
+// WithFallback 设置降级处理：主处理（经过重试等中间件后）仍失败时执行 fallback，
+// fallback 成功则视为 Hook 成功，并在 HookStat 中记录走了降级路径
+func (b *HookBuilder[C, Option, Payload, Result]) WithFallback(
+	fallback HookHandler[C, Option, Payload, Result],
+) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.fallback = fallback
+	return b
+}
+
+// OnSuccess 注册 Hook 成功完成后的回调（如递增业务指标、发送事件），跳过时不调用
+func (b *HookBuilder[C, Option, Payload, Result]) OnSuccess(
+	fn func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]),
+) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.onSuccess = append(b.hook.onSuccess, fn)
+	return b
+}
+
+// OnFailure 注册 Hook 失败后的回调（降级处理成功时不视为失败）
+func (b *HookBuilder[C, Option, Payload, Result]) OnFailure(
+	fn func(ctx C, pipeCtx *PipeContext[Option, Payload, Result], err error),
+) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.onFailure = append(b.hook.onFailure, fn)
+	return b
+}
+
 // Build 构建 Hook
 func (b *HookBuilder[C, Option, Payload, Result]) Build() *Hook[C, Option, Payload, Result] {
 	return b.hook
@@ -0,0 +1,149 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestOnceHook 测试 Once Hook 跨执行只运行一次
+func TestOnceHook(t *testing.T) {
+	var calls int
+
+	hook := NewHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		calls++
+		return nil
+	}).WithName("warmup").Once().Build()
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHookWithOptions(hook)
+
+	var last *ExecutionStats
+	pipeline.OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+		last = pipeCtx.Stats()
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected hook called once, got %d", calls)
+	}
+	if len(last.HookStats) != 1 || !last.HookStats[0].Skipped {
+		t.Errorf("Expected hook recorded as skipped, got %+v", last.HookStats)
+	}
+}
+
+// TestSkipIfOption 测试根据 Option 跳过 Hook
+func TestSkipIfOption(t *testing.T) {
+	var calls int
+
+	hook := NewHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		calls++
+		return nil
+	}).WithName("cache").SkipIfOption(func(opt *TestOption) bool { return !opt.EnableCache }).Build()
+
+	disabled := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").AddHookWithOptions(hook)
+	enabled := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test", func(opt *TestOption) {
+		opt.EnableCache = true
+	}).AddHookWithOptions(hook)
+
+	for _, p := range []*Pipeline[sylph.Context, TestOption, TestPayload, TestResult]{disabled, enabled} {
+		if _, err := p.Execute(newMockContext(), &TestPayload{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected hook called only when cache enabled, got %d", calls)
+	}
+}
+
+// TestHookFallback 测试主处理失败时执行降级处理
+func TestHookFallback(t *testing.T) {
+	primaryErr := errors.New("downstream unavailable")
+
+	hook := NewHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		return primaryErr
+	}).WithName("fetch").WithFallback(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		pipeCtx.Result.Output = append(pipeCtx.Result.Output, "stale")
+		return nil
+	}).Build()
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHookWithOptions(hook)
+
+	var stats *ExecutionStats
+	pipeline.OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+		stats = pipeCtx.Stats()
+	})
+
+	result, err := pipeline.Execute(newMockContext(), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Output) != 1 || result.Output[0] != "stale" {
+		t.Errorf("Expected fallback output, got %v", result.Output)
+	}
+
+	stat := stats.HookStats[0]
+	if !stat.Fallback || !errors.Is(stat.Cause, primaryErr) || stat.Error != nil {
+		t.Errorf("Expected fallback recorded with cause, got %+v", stat)
+	}
+
+	// 降级处理也失败时返回两者的错误
+	fallbackErr := errors.New("cache miss")
+	failing := NewHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		return primaryErr
+	}).WithFallback(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		return fallbackErr
+	}).Build()
+
+	_, err = NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHookWithOptions(failing).
+		Execute(newMockContext(), &TestPayload{})
+	if !errors.Is(err, primaryErr) || !errors.Is(err, fallbackErr) {
+		t.Errorf("Expected both errors, got %v", err)
+	}
+}
+
+// TestHookCallbacks 测试 Hook 级别的成功/失败回调
+func TestHookCallbacks(t *testing.T) {
+	hookErr := errors.New("failed")
+	var succeeded []string
+	var failed []error
+
+	ok := NewHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		return nil
+	}).OnSuccess(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) {
+		name, _ := pipeCtx.CurrentHook()
+		succeeded = append(succeeded, name)
+	}).OnFailure(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+		t.Errorf("Unexpected failure callback: %v", err)
+	}).WithName("ok").Build()
+
+	bad := NewHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		return hookErr
+	}).OnFailure(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+		failed = append(failed, err)
+	}).WithName("bad").SkipOnError().Build()
+
+	_, err := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHookWithOptions(ok).
+		AddHookWithOptions(bad).
+		Execute(newMockContext(), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(succeeded) != 1 || succeeded[0] != "ok" {
+		t.Errorf("Expected success callback for 'ok', got %v", succeeded)
+	}
+	if len(failed) != 1 || !errors.Is(failed[0], hookErr) {
+		t.Errorf("Expected failure callback with hook error, got %v", failed)
+	}
+}
@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"iter"
+	"slices"
+	"time"
+)
+
+// HookInfo Hook 的只读视图，供管理接口、文档导出和测试检查管道结构
+type HookInfo struct {
+	Name        string        // Hook 名称（未命名时为生成的名称）
+	Index       int           // 在管道中的索引（与 HookStat.Index 一致）
+	Description string        // Hook 描述
+	Kind        string        // 复合 Hook 类型（branch/switch 等，普通 Hook 为空）
+	Stage       string        // 所属的命名阶段（未分组时为空）
+	Tags        []string      // 文档标签（副本）
+	Timeout     time.Duration // 超时时间（0 表示无超时，不含阶段策略的超时）
+	SkipOnError bool          // 错误时是否跳过而非中断整个管道
+}
+
+// Hooks 按执行顺序遍历顶层 Hook（不含扩展插槽占位），遍历期间不应修改管道
+func (p *Pipeline[C, Option, Payload, Result]) Hooks() iter.Seq[HookInfo] {
+	return func(yield func(HookInfo) bool) {
+		for i, hook := range p.hooks {
+			if hook.isSlot() {
+				continue
+			}
+			if !yield(p.hookInfo(hook, i)) {
+				return
+			}
+		}
+	}
+}
+
+// HookInfos 按执行顺序返回顶层 Hook 的只读视图（不含扩展插槽占位）
+func (p *Pipeline[C, Option, Payload, Result]) HookInfos() []HookInfo {
+	return slices.Collect(p.Hooks())
+}
+
+// hookInfo 生成 Hook 的只读视图
+func (p *Pipeline[C, Option, Payload, Result]) hookInfo(hook *Hook[C, Option, Payload, Result], index int) HookInfo {
+	return HookInfo{
+		Name:        p.hookName(hook),
+		Index:       index,
+		Description: hook.Description,
+		Kind:        hook.kind,
+		Stage:       hook.stage,
+		Tags:        slices.Clone(hook.tags),
+		Timeout:     hook.Timeout,
+		SkipOnError: hook.SkipOnError,
+	}
+}
+
+// Iter 遍历 Hook 统计（遍历的是调用时的副本，可在并行分支仍在记录时安全使用）
+func (s *ExecutionStats) Iter() iter.Seq[HookStat] {
+	s.mu.Lock()
+	stats := slices.Clone(s.HookStats)
+	s.mu.Unlock()
+	return slices.Values(stats)
+}
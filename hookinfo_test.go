@@ -0,0 +1,87 @@
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestHooksIterator 测试遍历 Hook 的只读视图
+func TestHooksIterator(t *testing.T) {
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddNamedHook("load", appendHook("load")).
+		DefineSlot("extensions").
+		Stage("checkout", NewHook(appendHook("pay")).WithName("pay").Build())
+
+	var infos []HookInfo
+	for info := range pipeline.Hooks() {
+		infos = append(infos, info)
+	}
+	if len(infos) != 2 || infos[0].Name != "load" || infos[0].Index != 0 {
+		t.Fatalf("Expected load and pay without the slot, got %+v", infos)
+	}
+	if infos[1].Name != "pay" || infos[1].Index != 2 || infos[1].Stage != "checkout" {
+		t.Errorf("Expected pay at index 2 in stage checkout, got %+v", infos[1])
+	}
+
+	for info := range pipeline.Hooks() {
+		if info.Name != "load" {
+			t.Errorf("Expected iteration to stop after first hook, got %s", info.Name)
+		}
+		break
+	}
+}
+
+// TestHookInfos 测试 Hook 只读视图的字段与副本隔离
+func TestHookInfos(t *testing.T) {
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHookWithOptions(NewHook(appendHook("load")).
+			WithName("load").
+			WithDescription("load user").
+			WithTags("io", "db").
+			WithTimeout(time.Second).
+			SkipOnError().
+			Build()).
+		AddHook(processHook)
+
+	infos := pipeline.HookInfos()
+	if len(infos) != 2 {
+		t.Fatalf("Expected 2 hooks, got %+v", infos)
+	}
+	expected := HookInfo{
+		Name:        "load",
+		Description: "load user",
+		Tags:        []string{"io", "db"},
+		Timeout:     time.Second,
+		SkipOnError: true,
+	}
+	if !reflect.DeepEqual(infos[0], expected) {
+		t.Errorf("Expected %+v, got %+v", expected, infos[0])
+	}
+	if infos[1].Name != "pipeline.processHook" || infos[1].Index != 1 {
+		t.Errorf("Expected generated name for unnamed hook, got %+v", infos[1])
+	}
+
+	infos[0].Tags[0] = "changed"
+	if tags := pipeline.HookInfos()[0].Tags; tags[0] != "io" {
+		t.Errorf("Expected tags to be copied, got %v", tags)
+	}
+}
+
+// TestExecutionStatsIter 测试遍历 Hook 统计
+func TestExecutionStatsIter(t *testing.T) {
+	stats := NewExecutionStats("test")
+	stats.AddHookStat(HookStat{Name: "load", Index: 0})
+	stats.AddHookStat(HookStat{Name: "save", Index: 1})
+
+	var names []string
+	for stat := range stats.Iter() {
+		names = append(names, stat.Name)
+		stats.AddHookStat(HookStat{Name: "late"}) // 遍历副本，不影响本次遍历也不会死锁
+	}
+	if len(names) != 2 || names[0] != "load" || names[1] != "save" {
+		t.Errorf("Expected [load save], got %v", names)
+	}
+}
@@ -0,0 +1,53 @@
+package pipeline
+
+// 请求信息与身份在共享数据中的键
+const (
+	requestInfoKey = "__request"
+	identityKey    = "__identity"
+)
+
+// RequestInfo 与框架无关的请求元信息
+type RequestInfo struct {
+	Endpoint string // 请求来源端点（web、worker 等）
+	Path     string // 请求路径
+	Mark     string // 请求标记
+	TraceID  string // 追踪 ID
+	Ref      string // 引用标识
+}
+
+// Identity 与框架无关的调用方身份
+type Identity struct {
+	ID     string // 用户 ID
+	Token  string // 原始令牌
+	Issuer string // 颁发者
+}
+
+// SetRequest 设置请求元信息（通常由 sylphctx.Inject 等集成在执行开始时注入）
+func (p *PipeContext[Option, Payload, Result]) SetRequest(info RequestInfo) {
+	p.Set(requestInfoKey, info)
+}
+
+// Request 获取请求元信息，未注入时 ok 为 false
+func (p *PipeContext[Option, Payload, Result]) Request() (info RequestInfo, ok bool) {
+	v, found := p.Get(requestInfoKey)
+	if !found {
+		return RequestInfo{}, false
+	}
+	info, ok = v.(RequestInfo)
+	return info, ok
+}
+
+// SetIdentity 设置调用方身份
+func (p *PipeContext[Option, Payload, Result]) SetIdentity(identity Identity) {
+	p.Set(identityKey, identity)
+}
+
+// Identity 获取调用方身份，未认证时 ok 为 false
+func (p *PipeContext[Option, Payload, Result]) Identity() (identity Identity, ok bool) {
+	v, found := p.Get(identityKey)
+	if !found {
+		return Identity{}, false
+	}
+	identity, ok = v.(Identity)
+	return identity, ok
+}
@@ -0,0 +1,66 @@
+package pipeline
+
+import "errors"
+
+// WithPanicIsolation 每个 Hook 在独立的 goroutine 中执行，panic 被恢复并转换为带堆栈的 PanicError，
+// 与 RecoveryWithError 中间件一致，即使第三方代码 panic 也不会影响调用方。
+// 隔离只覆盖 Hook 本身（在中间件之内，重试等中间件可以看到 PanicError）；Hook 自行启动的 goroutine 中的 panic 无法恢复
+func (p *Pipeline[C, Option, Payload, Result]) WithPanicIsolation() *Pipeline[C, Option, Payload, Result] {
+	p.panicIsolation = true
+	return p
+}
+
+// isolatePanics 在独立 goroutine 中执行 Handler 并等待其返回，panic 转换为 PanicError
+func isolatePanics[C Context, Option any, Payload any, Result any](
+	handler HookHandler[C, Option, Payload, Result],
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+		done := make(chan error, 1)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					done <- NewPanicError(r)
+				}
+			}()
+			done <- handler(ctx, pipeCtx)
+		}()
+		return <-done
+	}
+}
+
+// WithPanicRecovery 在当前 goroutine 中恢复 Hook（含中间件）的 panic 并转换为带堆栈的 PanicError：
+// 与普通错误一样调用 OnError、将 HookStat 标记为 Panicked，之后的降级处理、SkipOnError 照常生效
+func (p *Pipeline[C, Option, Payload, Result]) WithPanicRecovery() *Pipeline[C, Option, Payload, Result] {
+	p.panicRecovery = true
+	return p
+}
+
+// WithPanicRethrow 执行因 panic 失败时（PanicError，无论由哪种方式恢复），在记录统计、调用 OnError 和
+// AfterExecute 之后重新 panic（panic 值为 *PanicError，保留原始堆栈），交由上层的恢复机制处理
+func (p *Pipeline[C, Option, Payload, Result]) WithPanicRethrow() *Pipeline[C, Option, Payload, Result] {
+	p.panicRethrow = true
+	return p
+}
+
+// recoverPanics 将 Handler 的 panic 转换为 PanicError
+func recoverPanics[C Context, Option any, Payload any, Result any](
+	handler HookHandler[C, Option, Payload, Result],
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = NewPanicError(r)
+			}
+		}()
+		return handler(ctx, pipeCtx)
+	}
+}
+
+// isPanic 错误是否由 panic 转换而来
+func isPanic(err error) bool {
+	if err == nil {
+		return false
+	}
+	var panicErr *PanicError
+	return errors.As(err, &panicErr)
+}
@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestPanicIsolation 测试 Hook 的 panic 被转换为带堆栈的 PanicError
+func TestPanicIsolation(t *testing.T) {
+	var ran bool
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		WithPanicIsolation().
+		AddNamedHook("explode", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			panic("boom")
+		}).
+		AddNamedHook("after", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			ran = true
+			return nil
+		})
+
+	_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "boom" || len(panicErr.Stack) == 0 {
+		t.Fatalf("Expected PanicError with stack, got %v", err)
+	}
+	var pipeErr *PipeError
+	if !errors.As(err, &pipeErr) || pipeErr.HookName != "explode" {
+		t.Errorf("Expected failure at explode, got %v", err)
+	}
+	if ran {
+		t.Error("Expected hooks after the panic not to run")
+	}
+}
+
+// TestPanicRecovery 测试引擎级 panic 恢复：调用 OnError、标记 HookStat，可选择重新 panic
+func TestPanicRecovery(t *testing.T) {
+	newPipeline := func() (*Pipeline[Context, NoOption, TestPayload, TestResult], *[]string, **ExecutionStats) {
+		var errorHooks []string
+		var stats *ExecutionStats
+		p := NewSimplePipeline[TestPayload, TestResult]("test").
+			WithPanicRecovery().
+			AddHookWithOptions(NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+				panic("optional")
+			}).WithName("optional").SkipOnError().Build()).
+			AddNamedHook("explode", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+				panic("boom")
+			}).
+			OnError(func(ctx Context, hookName string, err error) {
+				errorHooks = append(errorHooks, hookName)
+			}).
+			OnAfterExecute(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult], err error) {
+				stats = pipeCtx.Stats()
+			})
+		return p, &errorHooks, &stats
+	}
+
+	pipeline, errorHooks, stats := newPipeline()
+	_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	if !errors.Is(err, ErrHookPanic) {
+		t.Fatalf("Expected panic error, got %v", err)
+	}
+	if len(*errorHooks) != 2 || (*errorHooks)[1] != "explode" {
+		t.Errorf("Expected OnError for both hooks, got %v", *errorHooks)
+	}
+	for _, stat := range (*stats).HookStats {
+		if !stat.Panicked {
+			t.Errorf("Expected %s marked as panicked", stat.Name)
+		}
+	}
+	agg := NewAggregateStats("test")
+	agg.Record(*stats)
+	if h, _ := agg.Hook("explode"); h.Panics != 1 {
+		t.Errorf("Expected aggregated panic count 1, got %d", h.Panics)
+	}
+
+	pipeline, errorHooks, stats = newPipeline()
+	pipeline.WithPanicRethrow()
+	func() {
+		defer func() {
+			r := recover()
+			panicErr, ok := r.(*PanicError)
+			if !ok || panicErr.Value != "boom" {
+				t.Errorf("Expected rethrown PanicError, got %v", r)
+			}
+		}()
+		pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	}()
+	if len(*errorHooks) != 2 || *stats == nil {
+		t.Error("Expected OnError and AfterExecute before rethrow")
+	}
+}
@@ -0,0 +1,94 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// pipelineLifecycle 管道级（跨执行）的初始化与释放状态
+type pipelineLifecycle[C Context] struct {
+	mu        sync.Mutex
+	onInit    []func(ctx C) error
+	finalize  []func() error
+	initDone  atomic.Bool // 初始化已成功（置位后 Execute 不再获取 mu）
+	closed    atomic.Bool // 已关闭（持有 mu 写入）
+	closeOnce sync.Once
+	closeErr  error
+
+	workers  chan struct{}  // 异步执行的并发槽位
+	pending  chan struct{}  // 运行中与排队中的异步执行（有界）
+	inflight sync.WaitGroup // 已接受但未完成的异步执行（含影子执行）
+}
+
+// OnInit 注册初始化钩子，在首次 Execute 时执行一次（如缓存预热）
+// 初始化失败时本次执行返回错误，下一次 Execute 会重新尝试
+func (p *Pipeline[C, Option, Payload, Result]) OnInit(fn func(ctx C) error) *Pipeline[C, Option, Payload, Result] {
+	p.lifecycle.mu.Lock()
+	defer p.lifecycle.mu.Unlock()
+	p.lifecycle.onInit = append(p.lifecycle.onInit, fn)
+	return p
+}
+
+// OnFinalize 注册释放钩子，在 Close 时按后进先出顺序执行（如关闭连接池）
+func (p *Pipeline[C, Option, Payload, Result]) OnFinalize(fn func() error) *Pipeline[C, Option, Payload, Result] {
+	p.lifecycle.mu.Lock()
+	defer p.lifecycle.mu.Unlock()
+	p.lifecycle.finalize = append(p.lifecycle.finalize, fn)
+	return p
+}
+
+// Close 释放管道持有的长期资源，重复调用返回首次的结果
+// 先等待已接受的异步执行（ExecuteAndForget）完成，再按后进先出顺序执行释放钩子；
+// 关闭后的管道执行时返回 ErrPipelineClosed
+func (p *Pipeline[C, Option, Payload, Result]) Close() error {
+	p.lifecycle.closeOnce.Do(func() {
+		// 持有 mu 置位，与 ExecuteAndForget/ExecuteStream 的检查和 inflight.Add 互斥
+		p.lifecycle.mu.Lock()
+		p.lifecycle.closed.Store(true)
+		p.lifecycle.mu.Unlock()
+
+		p.lifecycle.inflight.Wait()
+
+		var errs []error
+		for i := len(p.lifecycle.finalize) - 1; i >= 0; i-- {
+			if err := safeCleanup(p.lifecycle.finalize[i]); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		p.lifecycle.closeErr = errors.Join(errs...)
+	})
+	return p.lifecycle.closeErr
+}
+
+// Finalize 同 Close
+func (p *Pipeline[C, Option, Payload, Result]) Finalize() error {
+	return p.Close()
+}
+
+// init 确保初始化钩子已成功执行
+// accepted 为 true 表示关闭前已接受的异步执行，关闭过程中仍允许运行；
+// 初始化成功后只做原子读取，不再获取 lifecycle.mu
+func (p *Pipeline[C, Option, Payload, Result]) init(ctx C, accepted bool) error {
+	if p.lifecycle.closed.Load() && !accepted {
+		return fmt.Errorf("%w: %s", ErrPipelineClosed, p.Name)
+	}
+	if p.lifecycle.initDone.Load() {
+		return nil
+	}
+
+	p.lifecycle.mu.Lock()
+	defer p.lifecycle.mu.Unlock()
+	if p.lifecycle.initDone.Load() {
+		return nil
+	}
+
+	for _, fn := range p.lifecycle.onInit {
+		if err := fn(ctx); err != nil {
+			return fmt.Errorf("pipeline '%s' init failed: %w", p.Name, err)
+		}
+	}
+	p.lifecycle.initDone.Store(true)
+	return nil
+}
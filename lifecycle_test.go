@@ -0,0 +1,91 @@
+package pipeline
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestInitAndClose 测试初始化只执行一次以及关闭后的行为
+func TestInitAndClose(t *testing.T) {
+	var inits, finalized int
+	initErr := errors.New("warmup failed")
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		OnInit(func(ctx sylph.Context) error {
+			inits++
+			if inits == 1 {
+				return initErr
+			}
+			return nil
+		}).
+		OnFinalize(func() error {
+			finalized++
+			return nil
+		}).
+		AddHook(processHook)
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); !errors.Is(err, initErr) {
+		t.Fatalf("Expected init error, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if inits != 2 {
+		t.Errorf("Expected init retried once then cached, got %d calls", inits)
+	}
+
+	_ = pipeline.Close()
+	_ = pipeline.Close()
+	if finalized != 1 {
+		t.Errorf("Expected finalize once, got %d", finalized)
+	}
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); !errors.Is(err, ErrPipelineClosed) {
+		t.Errorf("Expected ErrPipelineClosed, got %v", err)
+	}
+}
+
+// TestInitConcurrent 测试并发的首次执行只初始化一次，且都在初始化完成后才执行 Hook
+func TestInitConcurrent(t *testing.T) {
+	var inits atomic.Int32
+	var ready atomic.Bool
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		OnInit(func(ctx sylph.Context) error {
+			inits.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			ready.Store(true)
+			return nil
+		}).
+		AddHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			if !ready.Load() {
+				return errors.New("hook ran before init finished")
+			}
+			return nil
+		})
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if inits.Load() != 1 {
+		t.Errorf("Expected init once, got %d", inits.Load())
+	}
+}
@@ -0,0 +1,41 @@
+// Package logadapter 将常见的日志库适配为 pipe.Logger / pipe.Context，
+// 使中间件和 Hook 中的结构化日志流入应用已有的日志系统。
+//
+// 日志方法的参数按如下规则映射：
+//
+//   - pkg 作为 "pkg" 字段
+//   - action 作为日志消息
+//   - data 为 map[string]any 时展开为独立字段（按 key 排序），否则作为 "data" 字段
+//   - err 作为 "error" 字段
+package logadapter
+
+import "sort"
+
+// field 单个日志字段
+type field struct {
+	Key   string
+	Value any
+}
+
+// expandFields 将日志 data 展开为有序字段列表
+func expandFields(pkg string, data any) []field {
+	fields := []field{{Key: "pkg", Value: pkg}}
+
+	switch d := data.(type) {
+	case nil:
+	case map[string]any:
+		keys := make([]string, 0, len(d))
+		for k := range d {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			fields = append(fields, field{Key: k, Value: d[k]})
+		}
+	default:
+		fields = append(fields, field{Key: "data", Value: d})
+	}
+
+	return fields
+}
@@ -0,0 +1,17 @@
+module github.com/sylphbyte/pipeline/logadapter
+
+go 1.25.4
+
+require (
+	github.com/rs/zerolog v1.33.0
+	github.com/sylphbyte/pipeline v0.1.0
+	go.uber.org/zap v1.21.0
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	go.uber.org/atomic v1.9.0 // indirect
+	go.uber.org/multierr v1.9.0 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+)
@@ -0,0 +1,57 @@
+package logadapter
+
+import (
+	"context"
+	"log/slog"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// SlogLogger 基于 *slog.Logger 的 pipe.Logger 实现
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger 创建 slog 适配器（logger 为 nil 时使用 slog.Default()）
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogLogger{logger: logger}
+}
+
+// Slog 将父 context.Context 与 *slog.Logger 组合为 pipe.Context
+func Slog(parent context.Context, logger *slog.Logger) pipe.Context {
+	return pipe.WrapContextWithLogger(parent, NewSlogLogger(logger))
+}
+
+func (l *SlogLogger) Info(pkg, action string, data any) {
+	l.logger.Info(action, slogArgs(pkg, data, nil)...)
+}
+
+func (l *SlogLogger) Warn(pkg, action string, data any) {
+	l.logger.Warn(action, slogArgs(pkg, data, nil)...)
+}
+
+func (l *SlogLogger) Error(pkg, action string, err error, data any) {
+	l.logger.Error(action, slogArgs(pkg, data, err)...)
+}
+
+func (l *SlogLogger) Debug(pkg, action string, data any) {
+	l.logger.Debug(action, slogArgs(pkg, data, nil)...)
+}
+
+// slogArgs 将字段转换为 slog 参数
+func slogArgs(pkg string, data any, err error) []any {
+	fields := expandFields(pkg, data)
+
+	args := make([]any, 0, len(fields)+1)
+	for _, f := range fields {
+		args = append(args, slog.Any(f.Key, f.Value))
+	}
+	if err != nil {
+		args = append(args, slog.Any("error", err))
+	}
+
+	return args
+}
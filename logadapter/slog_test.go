@@ -0,0 +1,40 @@
+package logadapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+// TestSlogFields 测试 slog 适配器字段映射
+func TestSlogFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	ctx := Slog(context.Background(), logger)
+	ctx.Error("pipeline", "hook failed", errors.New("boom"), map[string]any{
+		"pipeline": "order",
+		"hook":     "persist",
+	})
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Invalid JSON log: %v", err)
+	}
+
+	expected := map[string]any{
+		"msg":      "hook failed",
+		"pkg":      "pipeline",
+		"pipeline": "order",
+		"hook":     "persist",
+		"error":    "boom",
+	}
+	for k, v := range expected {
+		if record[k] != v {
+			t.Errorf("Expected %s=%v, got %v", k, v, record[k])
+		}
+	}
+}
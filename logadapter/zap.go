@@ -0,0 +1,57 @@
+package logadapter
+
+import (
+	"context"
+
+	pipe "github.com/sylphbyte/pipeline"
+	"go.uber.org/zap"
+)
+
+// ZapLogger 基于 *zap.Logger 的 pipe.Logger 实现
+type ZapLogger struct {
+	logger *zap.Logger
+}
+
+// NewZapLogger 创建 zap 适配器（logger 为 nil 时使用 zap.L()）
+func NewZapLogger(logger *zap.Logger) *ZapLogger {
+	if logger == nil {
+		logger = zap.L()
+	}
+	return &ZapLogger{logger: logger}
+}
+
+// Zap 将父 context.Context 与 *zap.Logger 组合为 pipe.Context
+func Zap(parent context.Context, logger *zap.Logger) pipe.Context {
+	return pipe.WrapContextWithLogger(parent, NewZapLogger(logger))
+}
+
+func (l *ZapLogger) Info(pkg, action string, data any) {
+	l.logger.Info(action, zapFields(pkg, data, nil)...)
+}
+
+func (l *ZapLogger) Warn(pkg, action string, data any) {
+	l.logger.Warn(action, zapFields(pkg, data, nil)...)
+}
+
+func (l *ZapLogger) Error(pkg, action string, err error, data any) {
+	l.logger.Error(action, zapFields(pkg, data, err)...)
+}
+
+func (l *ZapLogger) Debug(pkg, action string, data any) {
+	l.logger.Debug(action, zapFields(pkg, data, nil)...)
+}
+
+// zapFields 将字段转换为 zap.Field
+func zapFields(pkg string, data any, err error) []zap.Field {
+	fields := expandFields(pkg, data)
+
+	zfs := make([]zap.Field, 0, len(fields)+1)
+	for _, f := range fields {
+		zfs = append(zfs, zap.Any(f.Key, f.Value))
+	}
+	if err != nil {
+		zfs = append(zfs, zap.Error(err))
+	}
+
+	return zfs
+}
@@ -0,0 +1,47 @@
+package logadapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestZapFields 测试 zap 适配器字段映射与日志级别
+func TestZapFields(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	ctx := Zap(context.Background(), zap.New(core))
+
+	ctx.Debug("pipeline", "hook started", nil)
+	ctx.Info("pipeline", "hook finished", map[string]any{"hook": "persist", "attempt": 2})
+	ctx.Warn("pipeline", "slow hook", "raw")
+	ctx.Error("pipeline", "hook failed", errors.New("boom"), map[string]any{"hook": "persist"})
+
+	entries := logs.AllUntimed()
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d", len(entries))
+	}
+
+	levels := []zapcore.Level{zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel}
+	for i, entry := range entries {
+		if entry.Level != levels[i] {
+			t.Errorf("Entry %d: expected level %v, got %v", i, levels[i], entry.Level)
+		}
+		if entry.ContextMap()["pkg"] != "pipeline" {
+			t.Errorf("Entry %d: expected pkg field, got %v", i, entry.ContextMap())
+		}
+	}
+
+	if fields := entries[1].ContextMap(); fields["hook"] != "persist" || fields["attempt"] != int64(2) {
+		t.Errorf("Expected expanded map fields, got %v", fields)
+	}
+	if fields := entries[2].ContextMap(); fields["data"] != "raw" {
+		t.Errorf("Expected data field, got %v", fields)
+	}
+	if entry := entries[3]; entry.Message != "hook failed" || entry.ContextMap()["error"] != "boom" {
+		t.Errorf("Unexpected error entry: %s %v", entry.Message, entry.ContextMap())
+	}
+}
@@ -0,0 +1,47 @@
+package logadapter
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// ZerologLogger 基于 zerolog.Logger 的 pipe.Logger 实现
+type ZerologLogger struct {
+	logger zerolog.Logger
+}
+
+// NewZerologLogger 创建 zerolog 适配器
+func NewZerologLogger(logger zerolog.Logger) *ZerologLogger {
+	return &ZerologLogger{logger: logger}
+}
+
+// Zerolog 将父 context.Context 与 zerolog.Logger 组合为 pipe.Context
+func Zerolog(parent context.Context, logger zerolog.Logger) pipe.Context {
+	return pipe.WrapContextWithLogger(parent, NewZerologLogger(logger))
+}
+
+func (l *ZerologLogger) Info(pkg, action string, data any) {
+	zerologEvent(l.logger.Info(), pkg, data).Msg(action)
+}
+
+func (l *ZerologLogger) Warn(pkg, action string, data any) {
+	zerologEvent(l.logger.Warn(), pkg, data).Msg(action)
+}
+
+func (l *ZerologLogger) Error(pkg, action string, err error, data any) {
+	zerologEvent(l.logger.Error().Err(err), pkg, data).Msg(action)
+}
+
+func (l *ZerologLogger) Debug(pkg, action string, data any) {
+	zerologEvent(l.logger.Debug(), pkg, data).Msg(action)
+}
+
+// zerologEvent 将字段写入 zerolog 事件
+func zerologEvent(event *zerolog.Event, pkg string, data any) *zerolog.Event {
+	for _, f := range expandFields(pkg, data) {
+		event = event.Interface(f.Key, f.Value)
+	}
+	return event
+}
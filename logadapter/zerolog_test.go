@@ -0,0 +1,53 @@
+package logadapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// TestZerologFields 测试 zerolog 适配器字段映射与日志级别
+func TestZerologFields(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := Zerolog(context.Background(), zerolog.New(&buf).Level(zerolog.DebugLevel))
+
+	ctx.Debug("pipeline", "hook started", nil)
+	ctx.Info("pipeline", "hook finished", map[string]any{"hook": "persist"})
+	ctx.Warn("pipeline", "slow hook", "raw")
+	ctx.Error("pipeline", "hook failed", errors.New("boom"), map[string]any{"hook": "persist"})
+
+	var records []map[string]any
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Invalid JSON log: %v", err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 4 {
+		t.Fatalf("Expected 4 records, got %d", len(records))
+	}
+
+	levels := []string{"debug", "info", "warn", "error"}
+	for i, record := range records {
+		if record["level"] != levels[i] || record["pkg"] != "pipeline" {
+			t.Errorf("Record %d: unexpected level or pkg: %v", i, record)
+		}
+	}
+
+	if records[1]["hook"] != "persist" || records[1]["message"] != "hook finished" {
+		t.Errorf("Expected expanded map fields, got %v", records[1])
+	}
+	if records[2]["data"] != "raw" {
+		t.Errorf("Expected data field, got %v", records[2])
+	}
+	if records[3]["error"] != "boom" || records[3]["hook"] != "persist" {
+		t.Errorf("Unexpected error record: %v", records[3])
+	}
+}
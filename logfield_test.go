@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestAddLogField 测试 Hook 结构化字段出现在统计和错误中
+func TestAddLogField(t *testing.T) {
+	var (
+		stats     *ExecutionStats
+		errFields map[string]any
+	)
+	errPay := errors.New("payment declined")
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		OnError(func(ctx sylph.Context, hookName string, err error) {
+			errFields = ErrorFields(err)
+		}).
+		OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+			stats = pipeCtx.Stats()
+		}).
+		AddNamedHook("load", func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			pipeCtx.AddLogField("orderID", 42)
+			return nil
+		}).
+		AddNamedHook("pay", func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			pipeCtx.AddLogField("amount", 100)
+			return errPay
+		})
+
+	_, err := pipeline.Execute(newMockContext(), &TestPayload{})
+	if !errors.Is(err, errPay) {
+		t.Fatalf("Expected payment error, got %v", err)
+	}
+
+	if stats.HookStats[0].Fields["orderID"] != 42 {
+		t.Errorf("Expected orderID field on first hook, got %v", stats.HookStats[0].Fields)
+	}
+	if _, leaked := stats.HookStats[1].Fields["orderID"]; leaked {
+		t.Error("Expected fields reset between hooks")
+	}
+	if errFields["amount"] != 100 || ErrorFields(err)["amount"] != 100 {
+		t.Errorf("Expected amount field in OnError and PipeError, got %v / %v", errFields, ErrorFields(err))
+	}
+}
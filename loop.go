@@ -0,0 +1,125 @@
+package pipeline
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultMaxIterations 循环默认的最大迭代次数（安全上限）
+const DefaultMaxIterations = 100
+
+// LoopBuilder 循环构建器
+type LoopBuilder[C Context, Option any, Payload any, Result any] struct {
+	cond          BranchCondition[C, Option, Payload, Result]
+	until         bool
+	body          []HookHandler[C, Option, Payload, Result]
+	maxIterations int
+	interval      time.Duration
+}
+
+// Loop 创建循环：条件成立时重复执行循环体（while 语义）
+func Loop[C Context, Option any, Payload any, Result any](
+	cond BranchCondition[C, Option, Payload, Result],
+) *LoopBuilder[C, Option, Payload, Result] {
+	return &LoopBuilder[C, Option, Payload, Result]{
+		cond:          cond,
+		maxIterations: DefaultMaxIterations,
+	}
+}
+
+// Until 创建循环：重复执行循环体直到条件成立（至少执行一次）
+func Until[C Context, Option any, Payload any, Result any](
+	cond BranchCondition[C, Option, Payload, Result],
+) *LoopBuilder[C, Option, Payload, Result] {
+	loop := Loop(cond)
+	loop.until = true
+	return loop
+}
+
+// Body 设置循环体
+func (l *LoopBuilder[C, Option, Payload, Result]) Body(
+	handlers ...HookHandler[C, Option, Payload, Result],
+) *LoopBuilder[C, Option, Payload, Result] {
+	l.body = append(l.body, handlers...)
+	return l
+}
+
+// MaxIterations 设置最大迭代次数，超过后循环以 ErrMaxIterationsExceeded 失败
+// n <= 0 时使用 DefaultMaxIterations
+func (l *LoopBuilder[C, Option, Payload, Result]) MaxIterations(n int) *LoopBuilder[C, Option, Payload, Result] {
+	if n <= 0 {
+		n = DefaultMaxIterations
+	}
+	l.maxIterations = n
+	return l
+}
+
+// Interval 设置两次迭代之间的等待时间（用于轮询），等待期间响应 ctx 取消
+func (l *LoopBuilder[C, Option, Payload, Result]) Interval(d time.Duration) *LoopBuilder[C, Option, Payload, Result] {
+	l.interval = d
+	return l
+}
+
+// Build 构建循环 Hook
+func (l *LoopBuilder[C, Option, Payload, Result]) Build(name string) *Hook[C, Option, Payload, Result] {
+	body := newHookGroup(name, "body", l.body)
+	cond := l.cond
+	until := l.until
+	maxIterations := l.maxIterations
+	interval := l.interval
+
+	return &Hook[C, Option, Payload, Result]{
+		Name: name,
+		Handler: func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+			// while：条件成立时继续；until：首次无条件执行，之后条件不成立时继续
+			next := func(i int) bool {
+				if until {
+					return i == 0 || !cond(ctx, pipeCtx)
+				}
+				return cond(ctx, pipeCtx)
+			}
+
+			for i := 0; next(i); i++ {
+				if i >= maxIterations {
+					return fmt.Errorf("%w: loop '%s' reached %d iterations", ErrMaxIterationsExceeded, name, maxIterations)
+				}
+
+				if i > 0 && interval > 0 {
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case <-time.After(interval):
+					}
+				}
+
+				start := time.Now()
+				err := body.run(ctx, pipeCtx)
+				pipeCtx.stats.AddIterationStat(IterationStat{
+					Name:      name,
+					Iteration: i,
+					Duration:  time.Since(start),
+					Error:     err,
+				})
+
+				if err != nil {
+					return err
+				}
+
+				if pipeCtx.IsAborted() {
+					return nil
+				}
+			}
+			return nil
+		},
+		kind:   fmt.Sprintf("loop, max %d", maxIterations),
+		groups: []hookGroup[C, Option, Payload, Result]{body},
+	}
+}
+
+// AddLoop 添加循环
+func (p *Pipeline[C, Option, Payload, Result]) AddLoop(
+	name string,
+	loop *LoopBuilder[C, Option, Payload, Result],
+) *Pipeline[C, Option, Payload, Result] {
+	return p.AddHookWithOptions(loop.Build(name))
+}
@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestLoop 测试循环
+func TestLoop(t *testing.T) {
+	var stats *ExecutionStats
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddLoop("paginate", Loop(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) bool {
+			page, _ := pipeCtx.Get("page")
+			return page == nil || page.(int) < 3
+		}).Body(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			page, _ := pipeCtx.Get("page")
+			next := 1
+			if page != nil {
+				next = page.(int) + 1
+			}
+			pipeCtx.Set("page", next)
+			return nil
+		})).
+		OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+			stats = pipeCtx.Stats()
+		})
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(stats.Iterations) != 3 {
+		t.Fatalf("Expected 3 iterations, got %d", len(stats.Iterations))
+	}
+
+	if stats.Iterations[2].Name != "paginate" || stats.Iterations[2].Iteration != 2 {
+		t.Errorf("Unexpected iteration stat: %+v", stats.Iterations[2])
+	}
+}
+
+// TestLoopMaxIterations 测试循环安全上限
+func TestLoopMaxIterations(t *testing.T) {
+	var runs int
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddLoop("forever", Until(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) bool {
+			return false
+		}).Body(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			runs++
+			return nil
+		}).MaxIterations(5))
+
+	_, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1})
+	if !errors.Is(err, ErrMaxIterationsExceeded) {
+		t.Fatalf("Expected ErrMaxIterationsExceeded, got %v", err)
+	}
+
+	if runs != 5 {
+		t.Errorf("Expected 5 runs, got %d", runs)
+	}
+}
+
+// TestLoopMaxIterationsDefault 测试非正数上限回退为默认值
+func TestLoopMaxIterationsDefault(t *testing.T) {
+	var runs int
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddLoop("once", Until(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) bool {
+			return runs >= 2
+		}).Body(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			runs++
+			return nil
+		}).MaxIterations(0))
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if runs != 2 {
+		t.Errorf("Expected 2 runs, got %d", runs)
+	}
+}
@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"maps"
+	"slices"
+)
+
+// WithDescription 设置管道描述
+func (p *Pipeline[C, Option, Payload, Result]) WithDescription(desc string) *Pipeline[C, Option, Payload, Result] {
+	p.description = desc
+	return p
+}
+
+// WithLabels 设置管道标签（如 team、domain），与已有标签合并
+// 标签会写入每次执行的 ExecutionStats，便于按标签分组统计
+func (p *Pipeline[C, Option, Payload, Result]) WithLabels(labels map[string]string) *Pipeline[C, Option, Payload, Result] {
+	if p.labels == nil {
+		p.labels = make(map[string]string, len(labels))
+	}
+	maps.Copy(p.labels, labels)
+	return p
+}
+
+// Description 获取管道描述
+func (p *Pipeline[C, Option, Payload, Result]) Description() string {
+	return p.description
+}
+
+// Labels 获取管道标签的副本
+func (p *Pipeline[C, Option, Payload, Result]) Labels() map[string]string {
+	return maps.Clone(p.labels)
+}
+
+// sortedLabelKeys 按字典序返回标签键
+func sortedLabelKeys(labels map[string]string) []string {
+	return slices.Sorted(maps.Keys(labels))
+}
@@ -0,0 +1,35 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestPipelineLabels 测试管道标签写入执行统计
+func TestPipelineLabels(t *testing.T) {
+	var stats *ExecutionStats
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		WithDescription("order checkout").
+		WithLabels(map[string]string{"team": "payments"}).
+		WithLabels(map[string]string{"domain": "orders"}).
+		OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+			stats = pipeCtx.Stats()
+		}).
+		AddHook(processHook)
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if stats.Labels["team"] != "payments" || stats.Labels["domain"] != "orders" {
+		t.Errorf("Expected labels in stats, got %v", stats.Labels)
+	}
+
+	desc := pipeline.Describe()
+	if !strings.Contains(desc, "description: order checkout") || !strings.Contains(desc, "label domain=orders") {
+		t.Errorf("Expected description and labels in Describe, got:\n%s", desc)
+	}
+}
@@ -1,5 +1,11 @@
 package pipeline
 
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
 // GenericHandler 是一个通用的处理器函数类型别名
 // 用于简化 middleware 的函数签名
 type GenericHandler[C Context] func(ctx C, pipeCtx interface{}) error
@@ -9,7 +15,7 @@ type GenericHandler[C Context] func(ctx C, pipeCtx interface{}) error
 type Middleware[C Context, Option any, Payload any, Result any] func(next HookHandler[C, Option, Payload, Result]) HookHandler[C, Option, Payload, Result]
 
 // applyMiddlewares 应用中间件到 Handler
-// 中间件按照从左到右的顺序应用，最后一个中间件最先执行
+// 中间件按照从左到右的顺序由外到内包装，第一个中间件最先执行
 func applyMiddlewares[C Context, Option any, Payload any, Result any](
 	handler HookHandler[C, Option, Payload, Result],
 	middlewares []Middleware[C, Option, Payload, Result],
@@ -21,3 +27,207 @@ func applyMiddlewares[C Context, Option any, Payload any, Result any](
 
 	return handler
 }
+
+// MiddlewareOption 中间件注册选项
+type MiddlewareOption func(config *middlewareConfig)
+
+// middlewareConfig 中间件注册配置
+type middlewareConfig struct {
+	priority int
+	match    HookMatcher
+}
+
+// WithPriority 设置中间件优先级：优先级高的位于外层（先执行），相同优先级按注册顺序，Use 注册的优先级为 0
+func WithPriority(priority int) MiddlewareOption {
+	return func(config *middlewareConfig) {
+		config.priority = priority
+	}
+}
+
+// ForHooks 只对 matcher 选中的 Hook 应用中间件（如仅对幂等 Hook 重试）
+func ForHooks(matcher HookMatcher) MiddlewareOption {
+	return func(config *middlewareConfig) {
+		config.match = matcher
+	}
+}
+
+// HookMatcher 按 Hook 名称和标签（WithTags）选择 Hook
+type HookMatcher func(name string, tags []string) bool
+
+// MatchNames 选择指定名称的 Hook
+func MatchNames(names ...string) HookMatcher {
+	return func(name string, tags []string) bool {
+		return slices.Contains(names, name)
+	}
+}
+
+// MatchTags 选择带有任一指定标签的 Hook
+func MatchTags(tags ...string) HookMatcher {
+	return func(name string, hookTags []string) bool {
+		return slices.ContainsFunc(hookTags, func(tag string) bool {
+			return slices.Contains(tags, tag)
+		})
+	}
+}
+
+// Not 选择 matcher 未选中的 Hook（如排除琐碎 Hook）
+func Not(matcher HookMatcher) HookMatcher {
+	return func(name string, tags []string) bool {
+		return !matcher(name, tags)
+	}
+}
+
+// namedMiddleware 已注册的中间件（未命名时 name 为空）
+type namedMiddleware[C Context, Option any, Payload any, Result any] struct {
+	name       string
+	priority   int
+	match      HookMatcher // 为 nil 时作用于所有 Hook
+	middleware Middleware[C, Option, Payload, Result]
+}
+
+// UseNamed 使用命名中间件，名称可用于 RemoveMiddleware、SetMiddlewarePriority 并显示在 Describe 中
+// 名称重复时 panic
+func (p *Pipeline[C, Option, Payload, Result]) UseNamed(
+	name string,
+	middleware Middleware[C, Option, Payload, Result],
+	opts ...MiddlewareOption,
+) *Pipeline[C, Option, Payload, Result] {
+	if p.middlewareIndex(name) >= 0 {
+		panic(fmt.Sprintf("pipeline: middleware '%s' already registered", name))
+	}
+
+	var config middlewareConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	p.middlewareEntries = append(p.middlewareEntries, namedMiddleware[C, Option, Payload, Result]{
+		name:       name,
+		priority:   config.priority,
+		match:      config.match,
+		middleware: middleware,
+	})
+	p.rebuildMiddlewares()
+	return p
+}
+
+// UseFor 只对 matcher 选中的 Hook 使用中间件（未命名，优先级为 0）
+func (p *Pipeline[C, Option, Payload, Result]) UseFor(
+	matcher HookMatcher,
+	middlewares ...Middleware[C, Option, Payload, Result],
+) *Pipeline[C, Option, Payload, Result] {
+	for _, middleware := range middlewares {
+		p.middlewareEntries = append(p.middlewareEntries, namedMiddleware[C, Option, Payload, Result]{
+			match:      matcher,
+			middleware: middleware,
+		})
+	}
+	p.rebuildMiddlewares()
+	return p
+}
+
+// RemoveMiddleware 移除命名中间件，不存在时忽略
+func (p *Pipeline[C, Option, Payload, Result]) RemoveMiddleware(name string) *Pipeline[C, Option, Payload, Result] {
+	if i := p.middlewareIndex(name); i >= 0 {
+		p.middlewareEntries = slices.Delete(p.middlewareEntries, i, i+1)
+		p.rebuildMiddlewares()
+	}
+	return p
+}
+
+// SetMiddlewarePriority 调整命名中间件的优先级（重新排序），不存在时 panic
+func (p *Pipeline[C, Option, Payload, Result]) SetMiddlewarePriority(name string, priority int) *Pipeline[C, Option, Payload, Result] {
+	i := p.middlewareIndex(name)
+	if i < 0 {
+		panic(fmt.Sprintf("pipeline: middleware '%s' not registered", name))
+	}
+	p.middlewareEntries[i].priority = priority
+	p.rebuildMiddlewares()
+	return p
+}
+
+// Middlewares 按生效顺序（由外到内）返回中间件名称，未命名的中间件为 "<unnamed>"
+func (p *Pipeline[C, Option, Payload, Result]) Middlewares() []string {
+	names := make([]string, 0, len(p.middlewareChain))
+	for _, entry := range p.middlewareChain {
+		names = append(names, entry.displayName())
+	}
+	return names
+}
+
+// displayName 中间件的显示名称
+func (m namedMiddleware[C, Option, Payload, Result]) displayName() string {
+	if m.name == "" {
+		return "<unnamed>"
+	}
+	return m.name
+}
+
+// middlewareIndex 返回命名中间件在注册列表中的索引，不存在时返回 -1（空名称不匹配）
+func (p *Pipeline[C, Option, Payload, Result]) middlewareIndex(name string) int {
+	if name == "" {
+		return -1
+	}
+	return slices.IndexFunc(p.middlewareEntries, func(entry namedMiddleware[C, Option, Payload, Result]) bool {
+		return entry.name == name
+	})
+}
+
+// sortedMiddlewares 按优先级从高到低稳定排序的中间件
+func (p *Pipeline[C, Option, Payload, Result]) sortedMiddlewares() []namedMiddleware[C, Option, Payload, Result] {
+	sorted := slices.Clone(p.middlewareEntries)
+	slices.SortStableFunc(sorted, func(a, b namedMiddleware[C, Option, Payload, Result]) int {
+		return cmp.Compare(b.priority, a.priority)
+	})
+	return sorted
+}
+
+// rebuildMiddlewares 重新计算生效的中间件链
+func (p *Pipeline[C, Option, Payload, Result]) rebuildMiddlewares() {
+	p.middlewareChain = p.sortedMiddlewares()
+	p.conditionalMiddlewares = slices.ContainsFunc(p.middlewareChain, func(entry namedMiddleware[C, Option, Payload, Result]) bool {
+		return entry.match != nil
+	})
+	p.middlewares = make([]Middleware[C, Option, Payload, Result], 0, len(p.middlewareChain))
+	for _, entry := range p.middlewareChain {
+		p.middlewares = append(p.middlewares, entry.middleware)
+	}
+}
+
+// hookMiddlewares 返回作用于 Hook 的中间件链（含阶段策略的增减），没有条件中间件和阶段覆盖时直接返回完整链
+func (p *Pipeline[C, Option, Payload, Result]) hookMiddlewares(hook *Hook[C, Option, Payload, Result]) []Middleware[C, Option, Payload, Result] {
+	policy := p.stagePolicy(hook)
+	if !p.conditionalMiddlewares && !policy.overridesMiddlewares() {
+		return p.middlewares
+	}
+
+	chain := p.hookMiddlewareChain(hook)
+	middlewares := make([]Middleware[C, Option, Payload, Result], len(chain))
+	for i, entry := range chain {
+		middlewares[i] = entry.middleware
+	}
+	return middlewares
+}
+
+// hookMiddlewareChain 返回作用于 Hook 的中间件及其名称（由外到内），阶段中间件名为 "stage 阶段名"
+func (p *Pipeline[C, Option, Payload, Result]) hookMiddlewareChain(hook *Hook[C, Option, Payload, Result]) []namedMiddleware[C, Option, Payload, Result] {
+	policy := p.stagePolicy(hook)
+	name := p.hookName(hook)
+	chain := make([]namedMiddleware[C, Option, Payload, Result], 0, len(p.middlewareChain))
+	for _, entry := range p.middlewareChain {
+		if entry.match != nil && !entry.match(name, hook.tags) {
+			continue
+		}
+		if policy.excludes(entry.name) {
+			continue
+		}
+		chain = append(chain, entry)
+	}
+
+	// 阶段中间件位于管道中间件之内
+	if policy != nil {
+		for _, middleware := range policy.middlewares {
+			chain = append(chain, namedMiddleware[C, Option, Payload, Result]{name: "stage " + hook.stage, middleware: middleware})
+		}
+	}
+	return chain
+}
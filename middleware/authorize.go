@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sylphbyte/sylph"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+var (
+	// ErrUnauthorized 缺少身份信息（如未携带 JWT）
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden 身份合法但无权执行
+	ErrForbidden = errors.New("forbidden")
+)
+
+// authorizedKey 缓存本次执行授权结果的状态槽位
+var authorizedKey = pipe.NewStateKey[*authDecision]("authorized")
+
+// authDecision 一次执行的授权结果（err 为 nil 表示允许）
+type authDecision struct {
+	err error
+}
+
+// Authorize 权限校验中间件
+// 通过 Use 对整条管道生效，或直接包装单个 Handler：middleware.Authorize(check)(handler)
+// 每次执行只在第一个 Hook 前调用一次 check，结果（包括拒绝）缓存到本次执行结束；
+// check 返回的错误若不是 ErrUnauthorized/ErrForbidden，会被包装为 ErrForbidden
+func Authorize[C pipe.Context, Option any, Payload any, Result any](
+	check func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error,
+) pipe.Middleware[C, Option, Payload, Result] {
+	return func(next pipe.HookHandler[C, Option, Payload, Result]) pipe.HookHandler[C, Option, Payload, Result] {
+		return func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error {
+			decision, ok := pipe.LoadState(pipeCtx, authorizedKey)
+			if !ok {
+				decision = &authDecision{err: authorize(ctx, pipeCtx, check)}
+				pipe.StoreState(pipeCtx, authorizedKey, decision)
+			}
+
+			if err := decision.err; err != nil {
+				return err
+			}
+			return next(ctx, pipeCtx)
+		}
+	}
+}
+
+// authorize 执行授权检查并规范化错误
+func authorize[C pipe.Context, Option any, Payload any, Result any](
+	ctx C,
+	pipeCtx *pipe.PipeContext[Option, Payload, Result],
+	check func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error,
+) error {
+	err := check(ctx, pipeCtx)
+	if err == nil || errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrForbidden) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrForbidden, err)
+}
+
+// claimCarrier 能提供 JWT 声明的上下文（如 sylph.Context）
+type claimCarrier interface {
+	JwtClaim() sylph.IJwtClaim
+}
+
+// AuthorizeClaim 基于 sylph JWT 声明的权限校验中间件
+// ctx 未实现 JwtClaim() 或声明为空时返回 ErrUnauthorized
+func AuthorizeClaim[C pipe.Context, Option any, Payload any, Result any](
+	check func(claim sylph.IJwtClaim, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error,
+) pipe.Middleware[C, Option, Payload, Result] {
+	return Authorize(func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error {
+		carrier, ok := any(ctx).(claimCarrier)
+		if !ok {
+			return ErrUnauthorized
+		}
+
+		claim := carrier.JwtClaim()
+		if claim == nil {
+			return ErrUnauthorized
+		}
+		return check(claim, pipeCtx)
+	})
+}
+
+// RequireIssuer 要求 JWT 声明由指定颁发者签发
+func RequireIssuer[C pipe.Context, Option any, Payload any, Result any](issuer string) pipe.Middleware[C, Option, Payload, Result] {
+	return AuthorizeClaim[C](func(claim sylph.IJwtClaim, _ *pipe.PipeContext[Option, Payload, Result]) error {
+		if !claim.IssuerIs(issuer) {
+			return fmt.Errorf("%w: issuer '%s' not accepted", ErrForbidden, claim.TakeIssuer())
+		}
+		return nil
+	})
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+type testClaim struct {
+	issuer string
+}
+
+func (c testClaim) TakeId() string            { return "u1" }
+func (c testClaim) TakeToken() string         { return "token" }
+func (c testClaim) TakeIssuer() string        { return c.issuer }
+func (c testClaim) IssuerIs(name string) bool { return name == c.issuer }
+
+// claimContext 携带 JWT 声明的 pipe.Context
+type claimContext struct {
+	pipe.Context
+	claim sylph.IJwtClaim
+}
+
+func (c claimContext) JwtClaim() sylph.IJwtClaim { return c.claim }
+
+// TestAuthorizeOncePerExecution 测试授权通过时每次执行只调用一次策略
+func TestAuthorizeOncePerExecution(t *testing.T) {
+	checks, runs := 0, 0
+
+	hook := func(ctx pipe.Context, pipeCtx *testPipeCtx) error {
+		runs++
+		if keys := pipeCtx.Keys(); len(keys) != 0 {
+			t.Errorf("Expected no shared data keys, got %v", keys)
+		}
+		return nil
+	}
+	pipeline := newTestPipeline().
+		Use(Authorize(func(ctx pipe.Context, pipeCtx *testPipeCtx) error {
+			checks++
+			return nil
+		})).
+		AddHook(hook, hook, hook)
+
+	if _, err := pipeline.Execute(testContext(), &testPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if checks != 1 || runs != 3 {
+		t.Errorf("Expected 1 check and 3 hook runs, got %d checks, %d runs", checks, runs)
+	}
+}
+
+// TestAuthorizeDeny 测试拒绝结果被包装为 ErrForbidden 并缓存
+func TestAuthorizeDeny(t *testing.T) {
+	checks, runs := 0, 0
+	policyErr := errors.New("not owner")
+
+	pipeline := newTestPipeline().
+		Use(Authorize(func(ctx pipe.Context, pipeCtx *testPipeCtx) error {
+			checks++
+			return policyErr
+		})).
+		AddHookWithOptions(pipe.NewHook(func(ctx pipe.Context, pipeCtx *testPipeCtx) error {
+			runs++
+			return nil
+		}).SkipOnError().Build()).
+		AddHook(func(ctx pipe.Context, pipeCtx *testPipeCtx) error {
+			runs++
+			return nil
+		})
+
+	_, err := pipeline.Execute(testContext(), &testPayload{})
+	if !errors.Is(err, ErrForbidden) || !errors.Is(err, policyErr) {
+		t.Fatalf("Expected ErrForbidden wrapping the policy error, got %v", err)
+	}
+	if checks != 1 || runs != 0 {
+		t.Errorf("Expected a cached denial, got %d checks, %d runs", checks, runs)
+	}
+}
+
+// TestAuthorizeClaim 测试基于 JWT 声明的授权与缺少声明的情况
+func TestAuthorizeClaim(t *testing.T) {
+	pipeline := pipe.NewPipeline[claimContext, pipe.NoOption, testPayload, testResult]("test").
+		Use(RequireIssuer[claimContext, pipe.NoOption, testPayload, testResult]("auth")).
+		AddHook(func(ctx claimContext, pipeCtx *testPipeCtx) error { return nil })
+
+	if _, err := pipeline.Execute(claimContext{Context: testContext(), claim: testClaim{issuer: "auth"}}, &testPayload{}); err != nil {
+		t.Errorf("Expected accepted issuer, got %v", err)
+	}
+
+	if _, err := pipeline.Execute(claimContext{Context: testContext(), claim: testClaim{issuer: "other"}}, &testPayload{}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden for other issuer, got %v", err)
+	}
+
+	if _, err := pipeline.Execute(claimContext{Context: testContext()}, &testPayload{}); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized for missing claim, got %v", err)
+	}
+}
@@ -7,7 +7,7 @@ import (
 )
 
 // Logging 日志中间件
-// 记录每个 Hook 的执行情况（简化版，不依赖 Logger）
+// 通过 ctx 的日志方法记录每个 Hook 的执行情况，附带 pipeline/hook（及所属阶段 stage）字段以及 Hook 通过 AddLogField 添加的字段
 func Logging[C pipe.Context, Option any, Payload any, Result any]() pipe.Middleware[C, Option, Payload, Result] {
 	return func(next pipe.HookHandler[C, Option, Payload, Result]) pipe.HookHandler[C, Option, Payload, Result] {
 		return func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error {
@@ -16,7 +16,27 @@ func Logging[C pipe.Context, Option any, Payload any, Result any]() pipe.Middlew
 			// 执行下一个 Handler
 			err := next(ctx, pipeCtx)
 
-			_ = time.Since(start) // 记录执行时间（可用于统计）
+			hookName, hookIndex := pipeCtx.CurrentHook()
+			fields := map[string]any{
+				"pipeline": pipeCtx.Name,
+				"hook":     hookName,
+				"index":    hookIndex,
+				"duration": time.Since(start),
+			}
+			if stage := pipeCtx.CurrentStage(); stage != "" {
+				fields["stage"] = stage
+			}
+			for key, value := range pipeCtx.LogFields() {
+				if _, reserved := fields[key]; !reserved {
+					fields[key] = value
+				}
+			}
+
+			if err != nil {
+				ctx.Error("pipeline", "hook failed", err, fields)
+			} else {
+				ctx.Debug("pipeline", "hook done", fields)
+			}
 
 			return err
 		}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"runtime/metrics"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// ErrMemoryExceeded Hook 执行后堆内存超出限制
+var ErrMemoryExceeded = errors.New("memory limit exceeded")
+
+// heapObjectsMetric 堆上对象占用的字节数（读取无需 STW，开销远低于 runtime.ReadMemStats）
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// MemoryLimits 内存保护配置，字段为 0 表示不检查该项
+type MemoryLimits struct {
+	MaxDelta uint64        // Hook 执行前后堆内存的最大增量（字节）
+	MaxHeap  uint64        // Hook 执行后堆内存的上限（字节）
+	WarnOnly bool          // 超出时仅通过 ctx.Warn 记录，不使 Hook 失败
+	Sample   func() uint64 // 堆内存采样函数（默认读取 runtime/metrics）
+}
+
+// MemoryGuard 内存保护中间件
+// 在 Hook 执行前后采样堆内存，增量或绝对值超出限制时返回 ErrMemoryExceeded（WarnOnly 时记录警告），
+// 防止单个 Payload 在某个 Hook 中撑爆共享服务的内存；增量写入日志字段 heap_delta。
+// 堆内存是进程级的，并发执行会相互影响增量，限制应按服务的并发度留出余量
+func MemoryGuard[C pipe.Context, Option any, Payload any, Result any](limits MemoryLimits) pipe.Middleware[C, Option, Payload, Result] {
+	sample := limits.Sample
+	if sample == nil {
+		sample = heapBytes
+	}
+
+	return func(next pipe.HookHandler[C, Option, Payload, Result]) pipe.HookHandler[C, Option, Payload, Result] {
+		return func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error {
+			before := sample()
+			err := next(ctx, pipeCtx)
+			after := sample()
+
+			var delta uint64
+			if after > before {
+				delta = after - before
+			}
+			pipeCtx.AddLogField("heap_delta", delta)
+
+			var exceeded error
+			switch {
+			case limits.MaxDelta > 0 && delta > limits.MaxDelta:
+				exceeded = fmt.Errorf("%w: heap grew %d bytes (limit %d)", ErrMemoryExceeded, delta, limits.MaxDelta)
+			case limits.MaxHeap > 0 && after > limits.MaxHeap:
+				exceeded = fmt.Errorf("%w: heap %d bytes (limit %d)", ErrMemoryExceeded, after, limits.MaxHeap)
+			}
+			if exceeded == nil {
+				return err
+			}
+
+			if limits.WarnOnly {
+				hookName, _ := pipeCtx.CurrentHook()
+				ctx.Warn("pipeline", "memory limit exceeded", map[string]any{
+					"pipeline":   pipeCtx.Name,
+					"hook":       hookName,
+					"heap_delta": delta,
+					"heap":       after,
+					"error":      exceeded.Error(),
+				})
+				return err
+			}
+			return errors.Join(err, pipe.Classify(exceeded, pipe.ClassPermanent))
+		}
+	}
+}
+
+// heapBytes 读取当前堆上对象占用的字节数
+func heapBytes() uint64 {
+	sample := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
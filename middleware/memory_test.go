@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// fakeHeap 每次采样返回预设值
+func fakeHeap(values ...uint64) func() uint64 {
+	return func() uint64 {
+		v := values[0]
+		if len(values) > 1 {
+			values = values[1:]
+		}
+		return v
+	}
+}
+
+// TestMemoryGuardDelta 测试堆内存增量超限时 Hook 失败且不重试
+func TestMemoryGuardDelta(t *testing.T) {
+	pipeline := newTestPipeline().
+		Use(MemoryGuard[pipe.Context, pipe.NoOption, testPayload, testResult](MemoryLimits{
+			MaxDelta: 1 << 20,
+			Sample:   fakeHeap(10<<20, 20<<20),
+		})).
+		AddHook(func(ctx pipe.Context, pipeCtx *testPipeCtx) error { return nil })
+
+	_, err := pipeline.Execute(testContext(), &testPayload{})
+	if !errors.Is(err, ErrMemoryExceeded) {
+		t.Fatalf("Expected ErrMemoryExceeded, got %v", err)
+	}
+	var pipeErr *pipe.PipeError
+	if !errors.As(err, &pipeErr) || pipeErr.Class.Retryable() {
+		t.Errorf("Expected a non-retryable PipeError, got %v", err)
+	}
+}
+
+// TestMemoryGuardCeiling 测试绝对上限与仅告警模式
+func TestMemoryGuardCeiling(t *testing.T) {
+	limits := MemoryLimits{MaxHeap: 64 << 20, Sample: fakeHeap(100<<20, 100<<20)}
+	hook := func(ctx pipe.Context, pipeCtx *testPipeCtx) error { return nil }
+
+	failing := newTestPipeline().
+		Use(MemoryGuard[pipe.Context, pipe.NoOption, testPayload, testResult](limits)).
+		AddHook(hook)
+	if _, err := failing.Execute(testContext(), &testPayload{}); !errors.Is(err, ErrMemoryExceeded) {
+		t.Errorf("Expected ErrMemoryExceeded, got %v", err)
+	}
+
+	limits.WarnOnly = true
+	warning := newTestPipeline().
+		Use(MemoryGuard[pipe.Context, pipe.NoOption, testPayload, testResult](limits)).
+		AddHook(hook)
+	if _, err := warning.Execute(testContext(), &testPayload{}); err != nil {
+		t.Errorf("Expected warn-only guard not to fail, got %v", err)
+	}
+
+	if heapBytes() == 0 {
+		t.Error("Expected runtime heap sample to be non-zero")
+	}
+}
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// quotaRemainingKey 本次执行扣减后剩余配额的状态槽位
+var quotaRemainingKey = pipe.NewStateKey[int64]("quota.remaining")
+
+// ErrQuotaExceeded 配额不足
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaStore 配额存储
+type QuotaStore interface {
+	// Consume 为 key 扣减 cost 个单位并返回剩余配额；配额不足时返回 ErrQuotaExceeded 且不扣减
+	Consume(ctx context.Context, key string, cost int64) (remaining int64, err error)
+}
+
+// QuotaFunc 配额中间件生成函数
+// key: 计量维度（租户、API Key 等）
+// cost: 本次执行消耗的单位数
+// 每次执行只在第一个 Hook 前扣减一次，剩余配额通过 QuotaRemaining 读取
+func QuotaFunc[C pipe.Context, Option any, Payload any, Result any](
+	store QuotaStore,
+	key func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) string,
+	cost func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) int64,
+) pipe.Middleware[C, Option, Payload, Result] {
+	return func(next pipe.HookHandler[C, Option, Payload, Result]) pipe.HookHandler[C, Option, Payload, Result] {
+		return func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error {
+			if _, charged := pipe.LoadState(pipeCtx, quotaRemainingKey); !charged {
+				k := key(ctx, pipeCtx)
+				remaining, err := store.Consume(ctx, k, cost(ctx, pipeCtx))
+				if err != nil {
+					return fmt.Errorf("quota for '%s': %w", k, err)
+				}
+				pipe.StoreState(pipeCtx, quotaRemainingKey, remaining)
+			}
+
+			return next(ctx, pipeCtx)
+		}
+	}
+}
+
+// QuotaRemaining 获取本次执行扣减后的剩余配额，未经过配额中间件时 ok 为 false
+func QuotaRemaining[Option any, Payload any, Result any](pipeCtx *pipe.PipeContext[Option, Payload, Result]) (remaining int64, ok bool) {
+	return pipe.LoadState(pipeCtx, quotaRemainingKey)
+}
+
+// Quota 配额中间件（每次执行消耗 1 个单位）
+func Quota[C pipe.Context, Option any, Payload any, Result any](
+	store QuotaStore,
+	key func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) string,
+) pipe.Middleware[C, Option, Payload, Result] {
+	return QuotaFunc(store, key, func(C, *pipe.PipeContext[Option, Payload, Result]) int64 { return 1 })
+}
+
+// MemoryQuotaStore 基于内存的固定窗口配额存储
+type MemoryQuotaStore struct {
+	mu     sync.Mutex
+	limit  int64
+	window time.Duration
+	limits map[string]int64
+	usage  map[string]*quotaUsage
+}
+
+type quotaUsage struct {
+	used  int64
+	reset time.Time
+}
+
+// NewMemoryQuotaStore 创建内存配额存储
+// limit: 默认每个 key 的配额；window: 配额重置周期（0 表示不重置）
+func NewMemoryQuotaStore(limit int64, window time.Duration) *MemoryQuotaStore {
+	return &MemoryQuotaStore{
+		limit:  limit,
+		window: window,
+		limits: make(map[string]int64),
+		usage:  make(map[string]*quotaUsage),
+	}
+}
+
+// SetLimit 为指定 key 设置单独的配额
+func (s *MemoryQuotaStore) SetLimit(key string, limit int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limits[key] = limit
+}
+
+// Consume 实现 QuotaStore
+func (s *MemoryQuotaStore) Consume(ctx context.Context, key string, cost int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	limit, ok := s.limits[key]
+	if !ok {
+		limit = s.limit
+	}
+
+	now := time.Now()
+	u, ok := s.usage[key]
+	if !ok || (s.window > 0 && !now.Before(u.reset)) {
+		u = &quotaUsage{reset: now.Add(s.window)}
+		s.usage[key] = u
+	}
+
+	if u.used+cost > limit {
+		return limit - u.used, ErrQuotaExceeded
+	}
+	u.used += cost
+	return limit - u.used, nil
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// TestMemoryQuotaStoreWindow 测试固定窗口到期后配额重置
+func TestMemoryQuotaStoreWindow(t *testing.T) {
+	store := NewMemoryQuotaStore(2, 20*time.Millisecond)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := store.Consume(ctx, "tenant", 1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if remaining, err := store.Consume(ctx, "tenant", 1); !errors.Is(err, ErrQuotaExceeded) || remaining != 0 {
+		t.Fatalf("Expected ErrQuotaExceeded with 0 remaining, got %d, %v", remaining, err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if remaining, err := store.Consume(ctx, "tenant", 1); err != nil || remaining != 1 {
+		t.Errorf("Expected quota reset after window, got %d, %v", remaining, err)
+	}
+}
+
+// TestQuotaChargedOncePerExecution 测试每次执行只扣减一次，剩余配额不占用共享数据
+func TestQuotaChargedOncePerExecution(t *testing.T) {
+	store := NewMemoryQuotaStore(1, 0)
+	remaining := int64(-1)
+
+	hook := func(ctx pipe.Context, pipeCtx *testPipeCtx) error {
+		remaining, _ = QuotaRemaining(pipeCtx)
+		if keys := pipeCtx.Keys(); len(keys) != 0 {
+			t.Errorf("Expected no shared data keys, got %v", keys)
+		}
+		return nil
+	}
+	pipeline := newTestPipeline().
+		Use(Quota(store, func(ctx pipe.Context, pipeCtx *testPipeCtx) string { return pipeCtx.Payload.ID })).
+		AddHook(hook, hook, hook)
+
+	if _, err := pipeline.Execute(testContext(), &testPayload{ID: "tenant"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if remaining != 0 {
+		t.Errorf("Expected remaining 0 after a single charge, got %v", remaining)
+	}
+
+	if _, err := pipeline.Execute(testContext(), &testPayload{ID: "tenant"}); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Expected ErrQuotaExceeded on second execution, got %v", err)
+	}
+}
+
+// TestMemoryQuotaStoreConcurrent 测试同一 key 的并发扣减不超额
+func TestMemoryQuotaStoreConcurrent(t *testing.T) {
+	store := NewMemoryQuotaStore(10, 0)
+
+	var (
+		wg      sync.WaitGroup
+		granted atomic.Int64
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := store.Consume(context.Background(), "tenant", 1); err == nil {
+				granted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if granted.Load() != 10 {
+		t.Errorf("Expected exactly 10 grants, got %d", granted.Load())
+	}
+}
@@ -1,48 +1,29 @@
 package middleware
 
 import (
-	"fmt"
-
 	pipe "github.com/sylphbyte/pipeline"
 )
 
 // Recovery Panic 恢复中间件
-// 捕获 Hook 中的 panic（简化版，不依赖 Logger）
+// 捕获 Hook 中的 panic 并转换为带堆栈的 *pipe.PanicError（与 RecoveryWithError 相同）：
+// 管道按失败处理，调用 OnError 并将 HookStat 标记为 Panicked，而不是当作成功继续执行
 func Recovery[C pipe.Context, Option any, Payload any, Result any]() pipe.Middleware[C, Option, Payload, Result] {
-	return func(next pipe.HookHandler[C, Option, Payload, Result]) pipe.HookHandler[C, Option, Payload, Result] {
-		return func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error {
-			defer func() {
-				if r := recover(); r != nil {
-					// 捕获 panic，但不做处理（仅防止程序崩溃）
-					_ = r
-				}
-			}()
-
-			// 执行下一个 Handler
-			err := next(ctx, pipeCtx)
-
-			return err
-		}
-	}
+	return RecoveryWithError[C, Option, Payload, Result]()
 }
 
-// RecoveryWithError Panic 恢复中间件（将 panic 转换为 error）
+// RecoveryWithError Panic 恢复中间件（将 panic 转换为带堆栈的 *pipe.PanicError，可用 errors.Is(err, pipe.ErrHookPanic) 判断）
 func RecoveryWithError[C pipe.Context, Option any, Payload any, Result any]() pipe.Middleware[C, Option, Payload, Result] {
 	return func(next pipe.HookHandler[C, Option, Payload, Result]) pipe.HookHandler[C, Option, Payload, Result] {
-		return func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error {
-			var err error
-
+		return func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) (err error) {
 			defer func() {
 				if r := recover(); r != nil {
 					// 将 panic 转换为 error
-					err = fmt.Errorf("panic recovered: %v", r)
+					err = pipe.NewPanicError(r)
 				}
 			}()
 
 			// 执行下一个 Handler
-			err = next(ctx, pipeCtx)
-
-			return err
+			return next(ctx, pipeCtx)
 		}
 	}
 }
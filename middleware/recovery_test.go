@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// TestRecoveryWithError 测试 panic 转换为带堆栈的 PanicError
+func TestRecoveryWithError(t *testing.T) {
+	pipeline := newTestPipeline().
+		Use(RecoveryWithError[pipe.Context, pipe.NoOption, testPayload, testResult]()).
+		AddHook(func(ctx pipe.Context, pipeCtx *testPipeCtx) error { panic("boom") })
+
+	_, err := pipeline.Execute(testContext(), &testPayload{})
+	var panicErr *pipe.PanicError
+	if !errors.Is(err, pipe.ErrHookPanic) || !errors.As(err, &panicErr) || len(panicErr.Stack) == 0 {
+		t.Errorf("Expected PanicError with stack, got %v", err)
+	}
+}
+
+// TestRecovery 测试 Recovery 不再吞掉 panic：调用 OnError 并标记 HookStat
+func TestRecovery(t *testing.T) {
+	var failed string
+	var stats *pipe.ExecutionStats
+	pipeline := newTestPipeline().
+		Use(Recovery[pipe.Context, pipe.NoOption, testPayload, testResult]()).
+		AddNamedHook("explode", func(ctx pipe.Context, pipeCtx *testPipeCtx) error { panic("boom") }).
+		OnError(func(ctx pipe.Context, hookName string, err error) {
+			failed = hookName
+		}).
+		OnAfterExecute(func(ctx pipe.Context, pipeCtx *testPipeCtx, err error) {
+			stats = pipeCtx.Stats()
+		})
+
+	_, err := pipeline.Execute(testContext(), &testPayload{})
+	if !errors.Is(err, pipe.ErrHookPanic) {
+		t.Fatalf("Expected panic error, got %v", err)
+	}
+	if failed != "explode" {
+		t.Errorf("Expected OnError for explode, got %q", failed)
+	}
+	if len(stats.HookStats) != 1 || !stats.HookStats[0].Panicked {
+		t.Errorf("Expected HookStat marked as panicked, got %+v", stats.HookStats)
+	}
+}
@@ -13,12 +13,43 @@ import (
 func RetryFunc[C pipe.Context, Option any, Payload any, Result any](
 	maxRetries int,
 	backoff time.Duration,
+) pipe.Middleware[C, Option, Payload, Result] {
+	return retry[C, Option, Payload, Result](maxRetries, backoff, false)
+}
+
+// RetryIsolatedFunc 隔离重试中间件生成函数
+// 每次重试前将共享数据、Result 和中断标记回滚到首次执行前的快照，
+// 失败尝试的部分写入不会泄漏到下一次尝试
+func RetryIsolatedFunc[C pipe.Context, Option any, Payload any, Result any](
+	maxRetries int,
+	backoff time.Duration,
+) pipe.Middleware[C, Option, Payload, Result] {
+	return retry[C, Option, Payload, Result](maxRetries, backoff, true)
+}
+
+// retry 重试中间件实现
+func retry[C pipe.Context, Option any, Payload any, Result any](
+	maxRetries int,
+	backoff time.Duration,
+	isolate bool,
 ) pipe.Middleware[C, Option, Payload, Result] {
 	return func(next pipe.HookHandler[C, Option, Payload, Result]) pipe.HookHandler[C, Option, Payload, Result] {
 		return func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error {
-			var err error
+			var (
+				err  error
+				snap *pipe.Snapshot[Result]
+			)
+
+			if isolate {
+				snap = pipeCtx.Snapshot()
+			}
 
 			for i := 0; i <= maxRetries; i++ {
+				// 隔离模式下回滚上一次尝试的写入
+				if i > 0 && isolate {
+					pipeCtx.Restore(snap)
+				}
+
 				// 执行 Handler
 				err = next(ctx, pipeCtx)
 
@@ -27,13 +58,27 @@ func RetryFunc[C pipe.Context, Option any, Payload any, Result any](
 					return nil
 				}
 
+				// 不可重试的错误（Permanent 标注，或分类为永久错误、校验错误）直接返回
+				if !pipeCtx.ShouldRetry(err) {
+					if isolate {
+						pipeCtx.Restore(snap)
+					}
+					return err
+				}
+
 				// 如果不是最后一次尝试，等待后重试
 				if i < maxRetries {
 					waitTime := backoff * time.Duration(i+1)
+					pipeCtx.NotifyRetry(i+1, waitTime, err)
 					time.Sleep(waitTime)
 				}
 			}
 
+			// 隔离模式下最终失败也不保留部分写入
+			if isolate {
+				pipeCtx.Restore(snap)
+			}
+
 			return fmt.Errorf("failed after %d retries: %w", maxRetries, err)
 		}
 	}
@@ -43,3 +88,8 @@ func RetryFunc[C pipe.Context, Option any, Payload any, Result any](
 func Retry[C pipe.Context, Option any, Payload any, Result any]() pipe.Middleware[C, Option, Payload, Result] {
 	return RetryFunc[C, Option, Payload, Result](3, 100*time.Millisecond)
 }
+
+// RetryIsolated 隔离重试中间件（默认重试 3 次，退避 100ms）
+func RetryIsolated[C pipe.Context, Option any, Payload any, Result any]() pipe.Middleware[C, Option, Payload, Result] {
+	return RetryIsolatedFunc[C, Option, Payload, Result](3, 100*time.Millisecond)
+}
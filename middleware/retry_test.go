@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+	"github.com/sylphbyte/pipeline/outbox"
+)
+
+type testPayload struct {
+	ID   string `validate:"required"`
+	Size int    `validate:"min=1"`
+}
+
+type testResult struct {
+	Output []string
+}
+
+type testPipeCtx = pipe.PipeContext[pipe.NoOption, testPayload, testResult]
+
+var errFlaky = errors.New("flaky")
+
+func newTestPipeline() *pipe.Pipeline[pipe.Context, pipe.NoOption, testPayload, testResult] {
+	return pipe.NewPipeline[pipe.Context, pipe.NoOption, testPayload, testResult]("test")
+}
+
+func testContext() pipe.Context {
+	return pipe.WrapContext(context.Background())
+}
+
+// TestRetryIsolatedRollback 测试隔离重试在每次尝试前回滚共享数据和 Result
+func TestRetryIsolatedRollback(t *testing.T) {
+	attempts := 0
+
+	pipeline := newTestPipeline().
+		Use(RetryIsolatedFunc[pipe.Context, pipe.NoOption, testPayload, testResult](3, 0)).
+		AddHook(func(ctx pipe.Context, pipeCtx *testPipeCtx) error {
+			attempts++
+			if _, leaked := pipeCtx.Get("partial"); leaked {
+				t.Errorf("Attempt %d saw shared data from a failed attempt", attempts)
+			}
+
+			pipeCtx.Set("partial", attempts)
+			pipeCtx.Result.Output = append(pipeCtx.Result.Output, "write")
+			if attempts < 3 {
+				return errFlaky
+			}
+			return nil
+		})
+
+	result, err := pipeline.Execute(testContext(), &testPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if attempts != 3 || len(result.Output) != 1 {
+		t.Errorf("Expected 3 attempts and a single write, got %d attempts, %v", attempts, result.Output)
+	}
+}
+
+// TestRetryPermanentError 测试永久错误不重试
+func TestRetryPermanentError(t *testing.T) {
+	attempts := 0
+
+	pipeline := newTestPipeline().
+		Use(RetryFunc[pipe.Context, pipe.NoOption, testPayload, testResult](3, 0)).
+		AddHook(func(ctx pipe.Context, pipeCtx *testPipeCtx) error {
+			attempts++
+			return pipe.Classify(errFlaky, pipe.ClassPermanent)
+		})
+
+	if _, err := pipeline.Execute(testContext(), &testPayload{}); !errors.Is(err, errFlaky) {
+		t.Fatalf("Expected errFlaky, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt, got %d", attempts)
+	}
+}
+
+// TestRetryMarkers 测试 Retryable/Permanent 标注覆盖错误分类
+func TestRetryMarkers(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		attempts int
+	}{
+		{"permanent overrides unknown", pipe.Permanent(errFlaky), 1},
+		{"retryable overrides validation", pipe.Retryable(pipe.Classify(errFlaky, pipe.ClassValidation)), 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			pipeline := newTestPipeline().
+				Use(RetryFunc[pipe.Context, pipe.NoOption, testPayload, testResult](2, 0)).
+				AddHook(func(ctx pipe.Context, pipeCtx *testPipeCtx) error {
+					attempts++
+					return tc.err
+				})
+
+			_, err := pipeline.Execute(testContext(), &testPayload{})
+			if !errors.Is(err, errFlaky) {
+				t.Fatalf("Expected errFlaky, got %v", err)
+			}
+			if attempts != tc.attempts {
+				t.Errorf("Expected %d attempts, got %d", tc.attempts, attempts)
+			}
+		})
+	}
+}
+
+type testTx struct {
+	writes int
+}
+
+// TestRetryIsolatedPointerValues 测试隔离重试保留指针值（事务、发件箱缓冲）的同一性
+func TestRetryIsolatedPointerValues(t *testing.T) {
+	tx := &testTx{}
+	store := outbox.NewMemoryStore()
+	attempts := 0
+
+	pipeline := newTestPipeline().
+		Use(
+			outbox.Middleware[pipe.Context, pipe.NoOption, testPayload, testResult](store, nil),
+			RetryIsolatedFunc[pipe.Context, pipe.NoOption, testPayload, testResult](1, 0),
+		).
+		OnBeforeExecute(func(ctx pipe.Context, pipeCtx *testPipeCtx) {
+			pipeCtx.Set("tx", tx)
+		}).
+		AddHook(func(ctx pipe.Context, pipeCtx *testPipeCtx) error {
+			attempts++
+			val, _ := pipeCtx.Get("tx")
+			val.(*testTx).writes++
+
+			if attempts == 1 {
+				return errFlaky
+			}
+			return outbox.Emit(pipeCtx, outbox.Event{Topic: "order.created"})
+		})
+
+	if _, err := pipeline.Execute(testContext(), &testPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tx.writes != 2 {
+		t.Errorf("Expected both attempts to use the original tx, got %d writes", tx.writes)
+	}
+
+	events, _ := store.Pending(context.Background(), 10)
+	if len(events) != 1 || events[0].Topic != "order.created" {
+		t.Errorf("Expected the retried event in the outbox, got %+v", events)
+	}
+}
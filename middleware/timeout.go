@@ -1,36 +1,25 @@
 package middleware
 
 import (
-	"context"
-	"fmt"
 	"time"
 
 	pipe "github.com/sylphbyte/pipeline"
 )
 
 // TimeoutFunc 超时中间件生成函数
-// 为每个 Hook 添加超时控制
+// 为每个 Hook 添加超时控制，超时后取消传给 Hook 的上下文
 func TimeoutFunc[C pipe.Context, Option any, Payload any, Result any](timeout time.Duration) pipe.Middleware[C, Option, Payload, Result] {
-	return func(next pipe.HookHandler[C, Option, Payload, Result]) pipe.HookHandler[C, Option, Payload, Result] {
-		return func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error {
-			done := make(chan error, 1)
-
-			// 在 goroutine 中执行
-			go func() {
-				done <- next(ctx, pipeCtx)
-			}()
-
-			// 使用 context 超时控制
-			timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
-			defer cancel()
+	return TimeoutWithGrace[C, Option, Payload, Result](timeout, 0)
+}
 
-			select {
-			case err := <-done:
-				return err
-			case <-timeoutCtx.Done():
-				return fmt.Errorf("hook timeout after %v", timeout)
-			}
-		}
+// TimeoutWithGrace 超时中间件生成函数
+// 超时取消上下文后最多再等待 grace，让 Hook 响应取消（释放资源）后再返回 pipe.ErrHookTimeout
+func TimeoutWithGrace[C pipe.Context, Option any, Payload any, Result any](
+	timeout time.Duration,
+	grace time.Duration,
+) pipe.Middleware[C, Option, Payload, Result] {
+	return func(next pipe.HookHandler[C, Option, Payload, Result]) pipe.HookHandler[C, Option, Payload, Result] {
+		return pipe.TimeoutHandler(next, timeout, grace)
 	}
 }
 
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// PayloadValidator 结构体校验器（*validator.Validate 满足该接口）
+type PayloadValidator interface {
+	Struct(s any) error
+}
+
+// ValidatePayload 返回使用指定校验器的 Payload 校验函数，供 OnValidatePayload 注册
+// 校验失败返回带字段明细的 *pipe.ValidationError
+func ValidatePayload[C pipe.Context, Payload any](v PayloadValidator) func(ctx C, payload *Payload) error {
+	return func(ctx C, payload *Payload) error {
+		if err := v.Struct(payload); err != nil {
+			return toValidationError("payload", err)
+		}
+		return nil
+	}
+}
+
+// EnableValidationWith 为管道启用 Payload 校验（使用指定校验器）
+// 校验由引擎在第一个 Hook 之前执行一次，不受 Hook 的 SkipOnError / WithFallback 影响
+func EnableValidationWith[C pipe.Context, Option any, Payload any, Result any](
+	p *pipe.Pipeline[C, Option, Payload, Result],
+	v PayloadValidator,
+) *pipe.Pipeline[C, Option, Payload, Result] {
+	return p.OnValidatePayload(ValidatePayload[C, Payload](v))
+}
+
+// EnableValidation 为管道启用基于 struct tag（go-playground/validator）的 Payload 校验
+func EnableValidation[C pipe.Context, Option any, Payload any, Result any](
+	p *pipe.Pipeline[C, Option, Payload, Result],
+) *pipe.Pipeline[C, Option, Payload, Result] {
+	return EnableValidationWith(p, validator.New(validator.WithRequiredStructEnabled()))
+}
+
+// toValidationError 将校验器错误转换为结构化错误
+func toValidationError(target string, err error) *pipe.ValidationError {
+	verr := &pipe.ValidationError{Target: target, Err: err}
+
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		for _, fe := range fieldErrs {
+			verr.Fields = append(verr.Fields, pipe.FieldError{
+				Field:   fe.Namespace(),
+				Tag:     fe.Tag(),
+				Param:   fe.Param(),
+				Message: fe.Error(),
+			})
+		}
+	}
+	return verr
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// TestEnableValidation 测试校验失败时不执行任何 Hook，SkipOnError 与 WithFallback 不会吞掉校验错误
+func TestEnableValidation(t *testing.T) {
+	runs := 0
+	hook := func(ctx pipe.Context, pipeCtx *testPipeCtx) error {
+		runs++
+		return nil
+	}
+
+	pipeline := EnableValidation(newTestPipeline()).
+		AddHookWithOptions(pipe.NewHook(hook).SkipOnError().Build()).
+		AddHookWithOptions(pipe.NewHook(hook).WithFallback(hook).Build())
+
+	_, err := pipeline.Execute(testContext(), &testPayload{Size: 0})
+
+	var verr *pipe.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	if runs != 0 {
+		t.Errorf("Expected no hooks to run on invalid payload, got %d runs", runs)
+	}
+	if verr.Target != "payload" || len(verr.Fields) != 2 || verr.Fields[0].Field != "testPayload.ID" || verr.Fields[1].Tag != "min" {
+		t.Errorf("Unexpected field errors: %+v", verr.Fields)
+	}
+
+	if _, err := pipeline.Execute(testContext(), &testPayload{ID: "o1", Size: 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if runs != 2 {
+		t.Errorf("Expected both hooks to run on valid payload, got %d runs", runs)
+	}
+}
+
+// TestOnValidatePayloadWrapsErrors 测试自定义校验的普通错误被包装为 ValidationError
+func TestOnValidatePayloadWrapsErrors(t *testing.T) {
+	errEmpty := errors.New("empty id")
+
+	pipeline := newTestPipeline().
+		OnValidatePayload(func(ctx pipe.Context, payload *testPayload) error {
+			if payload.ID == "" {
+				return errEmpty
+			}
+			return nil
+		}).
+		AddHook(func(ctx pipe.Context, pipeCtx *testPipeCtx) error { return nil })
+
+	_, err := pipeline.Execute(testContext(), &testPayload{})
+
+	var verr *pipe.ValidationError
+	if !errors.As(err, &verr) || !errors.Is(err, errEmpty) {
+		t.Errorf("Expected ValidationError wrapping errEmpty, got %v", err)
+	}
+}
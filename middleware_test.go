@@ -0,0 +1,137 @@
+package pipeline
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// traceMiddleware 记录执行顺序的测试中间件
+func traceMiddleware(name string, trace *[]string) Middleware[sylph.Context, TestOption, TestPayload, TestResult] {
+	return func(next HookHandler[sylph.Context, TestOption, TestPayload, TestResult]) HookHandler[sylph.Context, TestOption, TestPayload, TestResult] {
+		return func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			*trace = append(*trace, name)
+			return next(ctx, pipeCtx)
+		}
+	}
+}
+
+// TestMiddlewareOrdering 测试中间件的命名、优先级与移除
+func TestMiddlewareOrdering(t *testing.T) {
+	var trace []string
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		Use(traceMiddleware("plain", &trace)).
+		UseNamed("metrics", traceMiddleware("metrics", &trace)).
+		UseNamed("retry", traceMiddleware("retry", &trace), WithPriority(10)).
+		UseNamed("recovery", traceMiddleware("recovery", &trace), WithPriority(100)).
+		UseNamed("tracing", traceMiddleware("tracing", &trace), WithPriority(-1)).
+		AddNamedHook("hook", appendHook("hook"))
+
+	expected := []string{"recovery", "retry", "<unnamed>", "metrics", "tracing"}
+	if got := pipeline.Middlewares(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected order %v, got %v", expected, got)
+	}
+	if desc := pipeline.Describe(); !strings.Contains(desc, "middleware recovery (priority 100)\n  middleware retry (priority 10)\n  middleware <unnamed>\n") {
+		t.Errorf("Expected effective ordering in description, got:\n%s", desc)
+	}
+
+	pipeline.RemoveMiddleware("retry").RemoveMiddleware("missing").SetMiddlewarePriority("tracing", 1000)
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected = []string{"tracing", "recovery", "plain", "metrics"}
+	if !reflect.DeepEqual(trace, expected) {
+		t.Errorf("Expected execution order %v, got %v", expected, trace)
+	}
+}
+
+// TestMiddlewareNamePanics 测试中间件名称的构建期错误
+func TestMiddlewareNamePanics(t *testing.T) {
+	var trace []string
+	tests := []struct {
+		name string
+		fn   func(p *Pipeline[sylph.Context, TestOption, TestPayload, TestResult])
+	}{
+		{"duplicate", func(p *Pipeline[sylph.Context, TestOption, TestPayload, TestResult]) {
+			p.UseNamed("retry", traceMiddleware("a", &trace)).UseNamed("retry", traceMiddleware("b", &trace))
+		}},
+		{"unknown priority", func(p *Pipeline[sylph.Context, TestOption, TestPayload, TestResult]) {
+			p.SetMiddlewarePriority("missing", 1)
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			tc.fn(NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("x"))
+		})
+	}
+}
+
+// TestUseFor 测试按 Hook 名称和标签选择性应用中间件
+func TestUseFor(t *testing.T) {
+	var trace []string
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		UseFor(MatchTags("idempotent"), traceMiddleware("retry", &trace)).
+		UseFor(Not(MatchNames("trivial")), traceMiddleware("tracing", &trace)).
+		UseNamed("audit", traceMiddleware("audit", &trace), ForHooks(func(name string, tags []string) bool {
+			return strings.HasPrefix(name, "pay")
+		}), WithPriority(1)).
+		AddHookWithOptions(NewHook(appendHook("load")).WithName("load").WithTags("idempotent").Build()).
+		AddNamedHook("trivial", appendHook("trivial")).
+		AddNamedHook("payment", appendHook("payment"))
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"retry", "tracing", "audit", "tracing"}
+	if !reflect.DeepEqual(trace, expected) {
+		t.Errorf("Expected %v, got %v", expected, trace)
+	}
+	if desc := pipeline.Describe(); !strings.Contains(desc, "middleware audit (priority 1) [conditional]") {
+		t.Errorf("Expected conditional middleware in description, got:\n%s", desc)
+	}
+}
+
+// TestMiddlewareDuration 测试 Hook 执行时长拆分为本体耗时与中间件耗时
+func TestMiddlewareDuration(t *testing.T) {
+	agg := NewAggregateStats("test")
+	var stats *ExecutionStats
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		UseNamed("slow", sleepMiddleware(20*time.Millisecond)).
+		AddNamedHook("work", func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		})
+	pipeline.WithStatsSink(agg)
+	pipeline.OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+		stats = pipeCtx.Stats()
+	})
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	stat := stats.HookStats[0]
+	if stat.Handler < 5*time.Millisecond || stat.Handler >= 20*time.Millisecond {
+		t.Errorf("Expected handler time around 5ms, got %v", stat.Handler)
+	}
+	if stat.MiddlewareDuration() < 20*time.Millisecond || stat.Handler+stat.MiddlewareDuration() != stat.Duration {
+		t.Errorf("Expected middleware time >= 20ms summing to %v, got %v", stat.Duration, stat.MiddlewareDuration())
+	}
+
+	hook, _ := agg.Hook("work")
+	if hook.TotalHandler != stat.Handler || hook.MiddlewareDuration() != stat.MiddlewareDuration() {
+		t.Errorf("Expected aggregated split %v/%v, got %v/%v",
+			stat.Handler, stat.MiddlewareDuration(), hook.TotalHandler, hook.MiddlewareDuration())
+	}
+}
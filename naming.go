@@ -0,0 +1,52 @@
+package pipeline
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// HookNamer 为未命名的 Hook 生成名称，返回空字符串表示保持未命名
+type HookNamer func(handler any) string
+
+// FuncName 默认的 HookNamer：使用 Handler 的函数名（如 "orders.ValidateHook"）
+func FuncName(handler any) string {
+	v := reflect.ValueOf(handler)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+
+	fn := runtime.FuncForPC(v.Pointer())
+	if fn == nil {
+		return ""
+	}
+
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.TrimSuffix(name, "-fm")
+}
+
+// WithHookNamer 设置未命名 Hook 的名称生成规则（默认 FuncName），传入 nil 关闭自动命名
+// 生成的名称用于执行统计、错误信息和 Describe
+func (p *Pipeline[C, Option, Payload, Result]) WithHookNamer(namer HookNamer) *Pipeline[C, Option, Payload, Result] {
+	p.hookNamer = namer
+	p.hookNames.Clear()
+	return p
+}
+
+// hookName 获取 Hook 的名称：显式名称优先，否则使用自动生成的名称
+func (p *Pipeline[C, Option, Payload, Result]) hookName(hook *Hook[C, Option, Payload, Result]) string {
+	if hook.Name != "" || p.hookNamer == nil {
+		return hook.Name
+	}
+
+	if name, ok := p.hookNames.Load(hook); ok {
+		return name.(string)
+	}
+
+	name := p.hookNamer(hook.Handler)
+	p.hookNames.Store(hook, name)
+	return name
+}
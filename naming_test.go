@@ -0,0 +1,30 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestAutoHookName 测试未命名 Hook 的自动命名
+func TestAutoHookName(t *testing.T) {
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHook(validateHook)
+
+	_, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 0})
+
+	var pipeErr *PipeError
+	if !errors.As(err, &pipeErr) {
+		t.Fatalf("Expected PipeError, got %v", err)
+	}
+	if pipeErr.HookName != "pipeline.validateHook" {
+		t.Errorf("Expected hook name pipeline.validateHook, got %q", pipeErr.HookName)
+	}
+
+	pipeline.WithHookNamer(func(handler any) string { return "custom" })
+	_, err = pipeline.Execute(newMockContext(), &TestPayload{UserID: 0})
+	if !errors.As(err, &pipeErr) || pipeErr.HookName != "custom" {
+		t.Errorf("Expected hook name custom, got %v", err)
+	}
+}
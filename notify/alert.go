@@ -0,0 +1,210 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// maxWindow 每个管道保留的最近执行结果数
+const maxWindow = 1000
+
+// Alert 告警内容
+type Alert struct {
+	Pipeline    string    `json:"pipeline"`
+	Condition   string    `json:"condition"`
+	Message     string    `json:"message"`
+	ExecutionID string    `json:"execution_id"`
+	Error       string    `json:"error,omitempty"`
+	At          time.Time `json:"at"`
+}
+
+// Alerter 告警发送器（Slack、HTTP、邮件等）
+type Alerter interface {
+	Alert(ctx context.Context, alert Alert) error
+}
+
+// AlerterFunc 函数形式的告警发送器
+type AlerterFunc func(ctx context.Context, alert Alert) error
+
+// Alert 实现 Alerter
+func (f AlerterFunc) Alert(ctx context.Context, alert Alert) error {
+	return f(ctx, alert)
+}
+
+// Window 单个管道的最近执行结果
+type Window struct {
+	Pipeline string
+
+	consecutive int    // 连续失败次数
+	outcomes    []bool // 最近的执行结果（true 表示失败），最多 maxWindow 条
+}
+
+// ConsecutiveFailures 当前连续失败次数
+func (w *Window) ConsecutiveFailures() int {
+	return w.consecutive
+}
+
+// Recent 最近 n 次执行中的失败次数与实际执行次数
+func (w *Window) Recent(n int) (failures, total int) {
+	start := max(len(w.outcomes)-n, 0)
+	for _, failed := range w.outcomes[start:] {
+		if failed {
+			failures++
+		}
+	}
+	return failures, len(w.outcomes) - start
+}
+
+// observe 记录一次执行结果
+func (w *Window) observe(failed bool) {
+	if failed {
+		w.consecutive++
+	} else {
+		w.consecutive = 0
+	}
+
+	w.outcomes = append(w.outcomes, failed)
+	if len(w.outcomes) > maxWindow {
+		w.outcomes = w.outcomes[len(w.outcomes)-maxWindow:]
+	}
+}
+
+// Condition 告警条件，Evaluate 返回条件是否成立及描述
+// Monitor 只在条件从不成立变为成立时告警，恢复后才会再次告警
+type Condition interface {
+	Name() string
+	Evaluate(w *Window) (message string, active bool)
+}
+
+// conditionFunc 函数形式的告警条件
+type conditionFunc struct {
+	name string
+	fn   func(w *Window) (string, bool)
+}
+
+func (c conditionFunc) Name() string { return c.name }
+
+func (c conditionFunc) Evaluate(w *Window) (string, bool) { return c.fn(w) }
+
+// NewCondition 创建自定义告警条件
+func NewCondition(name string, fn func(w *Window) (message string, active bool)) Condition {
+	return conditionFunc{name: name, fn: fn}
+}
+
+// ConsecutiveFailures 连续失败 n 次时告警
+func ConsecutiveFailures(n int) Condition {
+	return NewCondition(fmt.Sprintf("consecutive_failures>=%d", n), func(w *Window) (string, bool) {
+		if w.ConsecutiveFailures() < n {
+			return "", false
+		}
+		return fmt.Sprintf("%d consecutive failures", w.ConsecutiveFailures()), true
+	})
+}
+
+// ErrorRate 最近 window 次执行的错误率达到 threshold（0~1）时告警，执行次数不足 window 时不判断
+func ErrorRate(threshold float64, window int) Condition {
+	return NewCondition(fmt.Sprintf("error_rate>=%g", threshold), func(w *Window) (string, bool) {
+		failures, total := w.Recent(window)
+		if total < window {
+			return "", false
+		}
+		rate := float64(failures) / float64(total)
+		if rate < threshold {
+			return "", false
+		}
+		return fmt.Sprintf("error rate %.1f%% over last %d executions", rate*100, total), true
+	})
+}
+
+// Monitor 按条件向 Alerter 发送告警，实现 pipe.StatsSink
+// 同一个 Monitor 可挂载到多个管道，按管道名称分别统计，一份配置覆盖所有管道
+type Monitor struct {
+	alerters   []Alerter
+	conditions []Condition
+	onError    func(err error)
+
+	mu      sync.Mutex
+	windows map[string]*Window
+	firing  map[string]bool // 管道名称 + 条件名称 -> 是否处于告警状态
+
+	wg sync.WaitGroup
+}
+
+// NewMonitor 创建告警监控
+func NewMonitor(alerters ...Alerter) *Monitor {
+	return &Monitor{
+		alerters: alerters,
+		windows:  make(map[string]*Window),
+		firing:   make(map[string]bool),
+	}
+}
+
+// When 添加告警条件
+func (m *Monitor) When(conditions ...Condition) *Monitor {
+	m.conditions = append(m.conditions, conditions...)
+	return m
+}
+
+// OnError 设置告警发送失败的回调
+func (m *Monitor) OnError(fn func(err error)) *Monitor {
+	m.onError = fn
+	return m
+}
+
+// Record 实现 pipe.StatsSink
+func (m *Monitor) Record(stats *pipe.ExecutionStats) {
+	for _, alert := range m.evaluate(stats) {
+		for _, alerter := range m.alerters {
+			m.wg.Add(1)
+			go func() {
+				defer m.wg.Done()
+				if err := alerter.Alert(context.Background(), alert); err != nil && m.onError != nil {
+					m.onError(err)
+				}
+			}()
+		}
+	}
+}
+
+// Wait 等待已发出的告警完成
+func (m *Monitor) Wait() {
+	m.wg.Wait()
+}
+
+// evaluate 记录执行结果并返回新触发的告警
+func (m *Monitor) evaluate(stats *pipe.ExecutionStats) []Alert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.windows[stats.PipelineName]
+	if !ok {
+		w = &Window{Pipeline: stats.PipelineName}
+		m.windows[stats.PipelineName] = w
+	}
+	w.observe(!stats.Success)
+
+	var alerts []Alert
+	for _, cond := range m.conditions {
+		key := stats.PipelineName + "/" + cond.Name()
+		msg, active := cond.Evaluate(w)
+		if active && !m.firing[key] {
+			alert := Alert{
+				Pipeline:    stats.PipelineName,
+				Condition:   cond.Name(),
+				Message:     msg,
+				ExecutionID: stats.ExecutionID,
+				At:          stats.EndTime,
+			}
+			if stats.Error != nil {
+				alert.Error = stats.Error.Error()
+			}
+			alerts = append(alerts, alert)
+		}
+		m.firing[key] = active
+	}
+	return alerts
+}
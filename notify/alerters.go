@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// HTTPAlerter 以 HTTP POST 发送告警
+type HTTPAlerter struct {
+	url        string
+	client     *http.Client
+	format     func(alert Alert) any
+	maxRetries int
+	backoff    time.Duration
+}
+
+// NewHTTPAlerter 创建通用 HTTP 告警，请求体为 Alert 的 JSON（默认重试 3 次，初始退避 500ms）
+func NewHTTPAlerter(url string) *HTTPAlerter {
+	return &HTTPAlerter{
+		url:        url,
+		client:     &http.Client{Timeout: 5 * time.Second},
+		format:     func(alert Alert) any { return alert },
+		maxRetries: 3,
+		backoff:    500 * time.Millisecond,
+	}
+}
+
+// NewSlackAlerter 创建 Slack Incoming Webhook 告警
+func NewSlackAlerter(webhookURL string) *HTTPAlerter {
+	a := NewHTTPAlerter(webhookURL)
+	a.format = func(alert Alert) any {
+		return map[string]string{"text": alertText(alert)}
+	}
+	return a
+}
+
+// WithRetry 设置最大重试次数和初始退避时间
+func (a *HTTPAlerter) WithRetry(maxRetries int, backoff time.Duration) *HTTPAlerter {
+	a.maxRetries = maxRetries
+	a.backoff = backoff
+	return a
+}
+
+// WithClient 设置 HTTP 客户端
+func (a *HTTPAlerter) WithClient(client *http.Client) *HTTPAlerter {
+	a.client = client
+	return a
+}
+
+// Alert 实现 Alerter
+func (a *HTTPAlerter) Alert(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(a.format(alert))
+	if err != nil {
+		return err
+	}
+	return post(ctx, a.client, a.url, body, a.maxRetries, a.backoff)
+}
+
+// EmailAlerter 通过 SMTP 发送告警邮件
+type EmailAlerter struct {
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+}
+
+// NewEmailAlerter 创建邮件告警，addr 为 SMTP 服务地址（host:port）
+func NewEmailAlerter(addr string, auth smtp.Auth, from string, to ...string) *EmailAlerter {
+	return &EmailAlerter{addr: addr, auth: auth, from: from, to: to}
+}
+
+// Alert 实现 Alerter
+func (a *EmailAlerter) Alert(ctx context.Context, alert Alert) error {
+	msg := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: [pipeline] %s: %s\r\n\r\n%s\r\n",
+		a.from, strings.Join(a.to, ", "), alert.Pipeline, alert.Condition, alertText(alert),
+	)
+	return smtp.SendMail(a.addr, a.auth, a.from, a.to, []byte(msg))
+}
+
+// alertText 告警的文本描述
+func alertText(alert Alert) string {
+	text := fmt.Sprintf("pipeline '%s': %s (execution %s)", alert.Pipeline, alert.Message, alert.ExecutionID)
+	if alert.Error != "" {
+		text += "\nlast error: " + alert.Error
+	}
+	return text
+}
@@ -0,0 +1,153 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// failedStats 构造一次失败执行的统计
+func failedStats() *pipe.ExecutionStats {
+	stats := pipe.NewExecutionStats("order")
+	stats.MarkStart()
+	stats.MarkEnd(errors.New("payment declined"))
+	return stats
+}
+
+// TestWebhookRetry 测试失败通知的发送与重试
+func TestWebhookRetry(t *testing.T) {
+	var calls atomic.Int32
+	var received Summary
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	hook := NewWebhook(server.URL).WithRetry(2, time.Millisecond).OnError(func(url string, err error) {
+		t.Errorf("Unexpected error: %v", err)
+	})
+
+	stats := failedStats()
+	hook.Record(stats)
+	hook.Wait()
+
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls.Load())
+	}
+	if received.Outcome != OutcomeFailure || received.Error != "payment declined" || received.ExecutionID != stats.ExecutionID {
+		t.Errorf("Expected failure summary, got %+v", received)
+	}
+}
+
+// TestWebhookSkipsSuccess 测试成功且未超出 SLA 的执行不发送通知
+func TestWebhookSkipsSuccess(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	hook := NewWebhook(server.URL).WithSLA(time.Hour)
+
+	stats := pipe.NewExecutionStats("order")
+	stats.MarkStart()
+	stats.MarkEnd(nil)
+	hook.Record(stats)
+	hook.Wait()
+
+	if calls.Load() != 0 {
+		t.Errorf("Expected no notification, got %d", calls.Load())
+	}
+
+	// 超出 SLA
+	stats.TotalDuration = 2 * time.Hour
+	summary, ok := NewSummary(stats, time.Hour)
+	if !ok || summary.Outcome != OutcomeSLABreach {
+		t.Errorf("Expected SLA breach, got %+v", summary)
+	}
+}
+
+// TestMonitorConditions 测试告警条件只在触发时告警一次，恢复后可再次告警
+func TestMonitorConditions(t *testing.T) {
+	var mu sync.Mutex
+	var alerts []Alert
+	monitor := NewMonitor(AlerterFunc(func(ctx context.Context, alert Alert) error {
+		mu.Lock()
+		defer mu.Unlock()
+		alerts = append(alerts, alert)
+		return nil
+	})).When(ConsecutiveFailures(2))
+
+	record := func(failed bool) {
+		stats := pipe.NewExecutionStats("order")
+		if failed {
+			stats.MarkEnd(errors.New("payment declined"))
+		} else {
+			stats.MarkEnd(nil)
+		}
+		monitor.Record(stats)
+		monitor.Wait()
+	}
+
+	record(true)
+	record(true)
+	record(true)
+	if len(alerts) != 1 || alerts[0].Pipeline != "order" || alerts[0].Error != "payment declined" {
+		t.Fatalf("Expected one alert, got %+v", alerts)
+	}
+
+	record(false)
+	record(true)
+	record(true)
+	if len(alerts) != 2 {
+		t.Errorf("Expected alert after recovery, got %d", len(alerts))
+	}
+}
+
+// TestErrorRateCondition 测试错误率条件
+func TestErrorRateCondition(t *testing.T) {
+	cond := ErrorRate(0.5, 4)
+	w := &Window{Pipeline: "order"}
+
+	for _, failed := range []bool{true, false, true} {
+		w.observe(failed)
+	}
+	if _, active := cond.Evaluate(w); active {
+		t.Error("Expected inactive before window is full")
+	}
+
+	w.observe(false)
+	if _, active := cond.Evaluate(w); !active {
+		t.Error("Expected active at 50% error rate")
+	}
+}
+
+// TestSlackAlerter 测试 Slack 告警请求体
+func TestSlackAlerter(t *testing.T) {
+	var body map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer server.Close()
+
+	err := NewSlackAlerter(server.URL).Alert(context.Background(), Alert{Pipeline: "order", Message: "3 consecutive failures"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(body["text"], "3 consecutive failures") {
+		t.Errorf("Expected alert text, got %v", body)
+	}
+}
@@ -0,0 +1,150 @@
+// Package notify 在管道执行失败或超出 SLA 时向外部发送通知。
+//
+// Webhook 实现 pipe.StatsSink，通过 Pipeline.WithStatsSink 挂载后，
+// 每次执行结束时检查结果，满足条件时异步向配置的 URL POST 一份 JSON 摘要，
+// 失败时按指数退避重试。
+//
+// Monitor 同样实现 pipe.StatsSink，按管道统计最近的执行结果，在满足告警条件
+// （连续失败、错误率超过阈值等）时通过 Alerter（Slack、HTTP、邮件）发送告警。
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// Outcome 触发通知的执行结果
+type Outcome string
+
+const (
+	OutcomeFailure   Outcome = "failure"    // 执行失败
+	OutcomeSLABreach Outcome = "sla_breach" // 执行成功但耗时超出 SLA
+)
+
+// Summary 通知内容
+type Summary struct {
+	Pipeline    string            `json:"pipeline"`
+	ExecutionID string            `json:"execution_id"`
+	Outcome     Outcome           `json:"outcome"`
+	DurationMs  float64           `json:"duration_ms"`
+	Error       string            `json:"error,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	At          time.Time         `json:"at"`
+}
+
+// NewSummary 根据执行统计生成通知内容，无需通知时返回 false
+// sla 为 0 表示不检查耗时
+func NewSummary(stats *pipe.ExecutionStats, sla time.Duration) (Summary, bool) {
+	summary := Summary{
+		Pipeline:    stats.PipelineName,
+		ExecutionID: stats.ExecutionID,
+		DurationMs:  float64(stats.TotalDuration) / float64(time.Millisecond),
+		Labels:      stats.Labels,
+		At:          stats.EndTime,
+	}
+
+	switch {
+	case !stats.Success:
+		summary.Outcome = OutcomeFailure
+		if stats.Error != nil {
+			summary.Error = stats.Error.Error()
+		}
+	case sla > 0 && stats.TotalDuration > sla:
+		summary.Outcome = OutcomeSLABreach
+	default:
+		return Summary{}, false
+	}
+	return summary, true
+}
+
+// Webhook 向一组 URL 发送执行结果通知
+type Webhook struct {
+	urls       []string
+	client     *http.Client
+	sla        time.Duration
+	maxRetries int
+	backoff    time.Duration
+	onError    func(url string, err error)
+
+	wg sync.WaitGroup
+}
+
+// NewWebhook 创建 Webhook 通知（默认重试 3 次，初始退避 500ms，请求超时 5 秒）
+func NewWebhook(urls ...string) *Webhook {
+	return &Webhook{
+		urls:       urls,
+		client:     &http.Client{Timeout: 5 * time.Second},
+		maxRetries: 3,
+		backoff:    500 * time.Millisecond,
+	}
+}
+
+// WithSLA 设置 SLA，执行耗时超过 d 时也发送通知
+func (w *Webhook) WithSLA(d time.Duration) *Webhook {
+	w.sla = d
+	return w
+}
+
+// WithRetry 设置最大重试次数和初始退避时间（每次重试翻倍）
+func (w *Webhook) WithRetry(maxRetries int, backoff time.Duration) *Webhook {
+	w.maxRetries = maxRetries
+	w.backoff = backoff
+	return w
+}
+
+// WithClient 设置 HTTP 客户端
+func (w *Webhook) WithClient(client *http.Client) *Webhook {
+	w.client = client
+	return w
+}
+
+// OnError 设置重试耗尽后的错误回调
+func (w *Webhook) OnError(fn func(url string, err error)) *Webhook {
+	w.onError = fn
+	return w
+}
+
+// Record 实现 pipe.StatsSink，满足条件时异步发送通知
+func (w *Webhook) Record(stats *pipe.ExecutionStats) {
+	summary, ok := NewSummary(stats, w.sla)
+	if !ok {
+		return
+	}
+	w.Send(summary)
+}
+
+// Send 异步向所有 URL 发送通知
+func (w *Webhook) Send(summary Summary) {
+	body, err := json.Marshal(summary)
+	if err != nil {
+		w.reportError("", err)
+		return
+	}
+
+	for _, url := range w.urls {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			if err := post(context.Background(), w.client, url, body, w.maxRetries, w.backoff); err != nil {
+				w.reportError(url, err)
+			}
+		}()
+	}
+}
+
+// Wait 等待已发出的通知完成（用于优雅退出）
+func (w *Webhook) Wait() {
+	w.wg.Wait()
+}
+
+// reportError 调用错误回调
+func (w *Webhook) reportError(url string, err error) {
+	if w.onError != nil {
+		w.onError(url, err)
+	}
+}
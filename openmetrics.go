@@ -0,0 +1,213 @@
+package pipeline
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// OpenMetricsContentType OpenMetrics 文本格式的 Content-Type
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// OpenMetricsSink 以 OpenMetrics 文本格式暴露累计统计，可直接作为 HTTP Handler 供采集
+type OpenMetricsSink struct {
+	set aggregateSet
+}
+
+// NewOpenMetricsSink 创建 OpenMetrics 统计接收端
+func NewOpenMetricsSink() *OpenMetricsSink {
+	return &OpenMetricsSink{}
+}
+
+// Record 实现 StatsSink
+func (s *OpenMetricsSink) Record(stats *ExecutionStats) {
+	s.set.record(stats)
+}
+
+// ServeHTTP 输出 OpenMetrics 文本
+func (s *OpenMetricsSink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", OpenMetricsContentType)
+	_ = WriteOpenMetrics(w, s.set.list()...)
+}
+
+// WriteOpenMetrics 将累计统计以 OpenMetrics 文本格式写入 w
+func WriteOpenMetrics(w io.Writer, aggs ...*AggregateStats) error {
+	bw := bufio.NewWriter(w)
+
+	writeFamily(bw, "pipeline_executions", "counter", "Pipeline executions.")
+	for _, agg := range aggs {
+		writeSample(bw, "pipeline_executions_total", agg.Executions(), withLabels(agg)...)
+	}
+	writeFamily(bw, "pipeline_failures", "counter", "Failed pipeline executions.")
+	for _, agg := range aggs {
+		failures := agg.FailuresByKind()
+		kinds := make([]FailureKind, 0, len(failures))
+		for kind := range failures {
+			kinds = append(kinds, kind)
+		}
+		sort.Slice(kinds, func(i, j int) bool {
+			if kinds[i].Class != kinds[j].Class {
+				return kinds[i].Class < kinds[j].Class
+			}
+			return kinds[i].Severity < kinds[j].Severity
+		})
+		for _, kind := range kinds {
+			writeSample(bw, "pipeline_failures_total", failures[kind],
+				withLabels(agg, "class", kind.Class.Label(), "severity", string(kind.Severity))...)
+		}
+	}
+	writeFamily(bw, "pipeline_zombies", "counter", "Hook goroutines abandoned after timeout or cancellation.")
+	for _, agg := range aggs {
+		writeSample(bw, "pipeline_zombies_total", agg.Zombies(), withLabels(agg)...)
+	}
+	writeFamily(bw, "pipeline_duration_seconds", "counter", "Total pipeline execution time.")
+	for _, agg := range aggs {
+		writeSample(bw, "pipeline_duration_seconds_total", agg.TotalDuration().Seconds(), withLabels(agg)...)
+	}
+
+	hookFamilies := []struct {
+		name, typ, help, suffix string
+		value                   func(h HookAggregate) any
+	}{
+		{"pipeline_hook_calls", "counter", "Hook executions.", "_total", func(h HookAggregate) any { return h.Calls }},
+		{"pipeline_hook_errors", "counter", "Failed hook executions.", "_total", func(h HookAggregate) any { return h.Errors }},
+		{"pipeline_hook_skipped", "counter", "Skipped hook executions.", "_total", func(h HookAggregate) any { return h.Skipped }},
+		{"pipeline_hook_fallbacks", "counter", "Hook executions served by fallback.", "_total", func(h HookAggregate) any { return h.Fallbacks }},
+		{"pipeline_hook_panics", "counter", "Hook executions that panicked.", "_total", func(h HookAggregate) any { return h.Panics }},
+		{"pipeline_hook_deprecated_calls", "counter", "Executions of deprecated hooks.", "_total", func(h HookAggregate) any { return h.Deprecated }},
+		{"pipeline_hook_duration_seconds", "counter", "Total hook execution time.", "_total", func(h HookAggregate) any { return h.TotalDuration.Seconds() }},
+		{"pipeline_hook_handler_seconds", "counter", "Total time spent in hook bodies.", "_total", func(h HookAggregate) any { return h.TotalHandler.Seconds() }},
+		{"pipeline_hook_middleware_seconds", "counter", "Total time spent in middleware around hooks.", "_total", func(h HookAggregate) any { return h.MiddlewareDuration().Seconds() }},
+		{"pipeline_hook_duration_max_seconds", "gauge", "Maximum hook execution time.", "", func(h HookAggregate) any { return h.MaxDuration.Seconds() }},
+	}
+	writeFamily(bw, "pipeline_hook_errors_by_class", "counter", "Failed hook executions by error class.")
+	for _, agg := range aggs {
+		for _, h := range agg.Hooks() {
+			classes := make([]ErrorClass, 0, len(h.ErrorClasses))
+			for class := range h.ErrorClasses {
+				classes = append(classes, class)
+			}
+			sort.Slice(classes, func(i, j int) bool { return classes[i] < classes[j] })
+			for _, class := range classes {
+				writeSample(bw, "pipeline_hook_errors_by_class_total", h.ErrorClasses[class],
+					withLabels(agg, "hook", h.Name, "class", class.Label())...)
+			}
+		}
+	}
+	variantFamilies := []struct {
+		name, help string
+		value      func(v VariantAggregate) any
+	}{
+		{"pipeline_hook_variant_calls", "Experiment variant executions.", func(v VariantAggregate) any { return v.Calls }},
+		{"pipeline_hook_variant_errors", "Failed experiment variant executions.", func(v VariantAggregate) any { return v.Errors }},
+		{"pipeline_hook_variant_duration_seconds", "Total experiment variant execution time.", func(v VariantAggregate) any { return v.TotalDuration.Seconds() }},
+	}
+	for _, f := range variantFamilies {
+		writeFamily(bw, f.name, "counter", f.help)
+		for _, agg := range aggs {
+			for _, h := range agg.Hooks() {
+				for _, variant := range slices.Sorted(maps.Keys(h.Variants)) {
+					writeSample(bw, f.name+"_total", f.value(h.Variants[variant]),
+						withLabels(agg, "hook", h.Name, "variant", variant)...)
+				}
+			}
+		}
+	}
+	writeFamily(bw, "pipeline_hook_profile_samples", "counter", "Profiled hook executions.")
+	for _, agg := range aggs {
+		for _, h := range agg.Hooks() {
+			if h.Profile.Samples > 0 {
+				writeSample(bw, "pipeline_hook_profile_samples_total", h.Profile.Samples, withLabels(agg, "hook", h.Name)...)
+			}
+		}
+	}
+	writeFamily(bw, "pipeline_hook_profile_handler_seconds", "counter", "Time spent in hook bodies of profiled executions.")
+	for _, agg := range aggs {
+		for _, h := range agg.Hooks() {
+			if h.Profile.Samples > 0 {
+				writeSample(bw, "pipeline_hook_profile_handler_seconds_total", h.Profile.Handler.Seconds(), withLabels(agg, "hook", h.Name)...)
+			}
+		}
+	}
+	writeFamily(bw, "pipeline_hook_profile_layer_seconds", "counter", "Self time of middleware layers in profiled executions.")
+	for _, agg := range aggs {
+		for _, h := range agg.Hooks() {
+			for _, layer := range slices.Sorted(maps.Keys(h.Profile.Layers)) {
+				writeSample(bw, "pipeline_hook_profile_layer_seconds_total", h.Profile.Layers[layer].Seconds(),
+					withLabels(agg, "hook", h.Name, "layer", layer)...)
+			}
+		}
+	}
+	for _, f := range hookFamilies {
+		writeFamily(bw, f.name, f.typ, f.help)
+		for _, agg := range aggs {
+			for _, h := range agg.Hooks() {
+				writeSample(bw, f.name+f.suffix, f.value(h), withLabels(agg, "hook", h.Name)...)
+			}
+		}
+	}
+
+	bw.WriteString("# EOF\n")
+	return bw.Flush()
+}
+
+// withLabels 返回样本标签：pipeline、管道标签（WithLabels，按键排序）与 extra
+// 管道标签名中的非法字符替换为下划线，与内置标签同名的管道标签被忽略
+func withLabels(agg *AggregateStats, extra ...string) []string {
+	reserved := map[string]bool{"pipeline": true, "hook": true, "class": true, "severity": true, "variant": true, "layer": true}
+
+	labels := []string{"pipeline", agg.PipelineName}
+	pipelineLabels := agg.Labels()
+	for _, key := range sortedLabelKeys(pipelineLabels) {
+		name := labelName(key)
+		if reserved[name] {
+			continue
+		}
+		reserved[name] = true
+		labels = append(labels, name, pipelineLabels[key])
+	}
+	return append(labels, extra...)
+}
+
+// labelName 将标签键转换为合法的 OpenMetrics 标签名（[a-zA-Z_][a-zA-Z0-9_]*）
+func labelName(key string) string {
+	b := []byte(key)
+	for i, c := range b {
+		valid := c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || (i > 0 && '0' <= c && c <= '9')
+		if !valid {
+			b[i] = '_'
+		}
+	}
+	if len(b) == 0 {
+		return "_"
+	}
+	return string(b)
+}
+
+// writeFamily 输出指标族的 TYPE 与 HELP
+func writeFamily(w *bufio.Writer, name, typ, help string) {
+	fmt.Fprintf(w, "# TYPE %s %s\n# HELP %s %s\n", name, typ, name, help)
+}
+
+// writeSample 输出单个样本，labels 为键值交替的列表
+func writeSample(w *bufio.Writer, name string, value any, labels ...string) {
+	w.WriteString(name)
+	w.WriteString("{")
+	for i := 0; i+1 < len(labels); i += 2 {
+		if i > 0 {
+			w.WriteString(",")
+		}
+		fmt.Fprintf(w, `%s="%s"`, labels[i], escapeLabel(labels[i+1]))
+	}
+	fmt.Fprintf(w, "} %v\n", value)
+}
+
+// escapeLabel 转义标签值中的反斜杠、双引号和换行
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
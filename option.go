@@ -0,0 +1,44 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+)
+
+// OptionResolver 按次解析 Option（如按租户计算限额、功能开关）
+// 返回 nil 时使用管道的静态 Option
+type OptionResolver[Option any, Payload any] interface {
+	Resolve(ctx context.Context, payload *Payload) (*Option, error)
+}
+
+// OptionResolverFunc 函数形式的 OptionResolver
+type OptionResolverFunc[Option any, Payload any] func(ctx context.Context, payload *Payload) (*Option, error)
+
+// Resolve 实现 OptionResolver
+func (f OptionResolverFunc[Option, Payload]) Resolve(ctx context.Context, payload *Payload) (*Option, error) {
+	return f(ctx, payload)
+}
+
+// WithOptionResolver 设置每次执行时的 Option 解析器
+func (p *Pipeline[C, Option, Payload, Result]) WithOptionResolver(
+	resolver OptionResolver[Option, Payload],
+) *Pipeline[C, Option, Payload, Result] {
+	p.optionResolver = resolver
+	return p
+}
+
+// resolveOption 获取本次执行使用的 Option
+func (p *Pipeline[C, Option, Payload, Result]) resolveOption(ctx C, payload *Payload) (*Option, error) {
+	if p.optionResolver == nil {
+		return p.option, nil
+	}
+
+	option, err := p.optionResolver.Resolve(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline '%s' resolve option: %w", p.Name, err)
+	}
+	if option == nil {
+		return p.option, nil
+	}
+	return option, nil
+}
@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestOptionResolver 测试按次解析 Option
+func TestOptionResolver(t *testing.T) {
+	var seen []bool
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		WithOptionResolver(OptionResolverFunc[TestOption, TestPayload](
+			func(ctx context.Context, payload *TestPayload) (*TestOption, error) {
+				if payload.UserID == 0 {
+					return nil, nil
+				}
+				return &TestOption{EnableCache: payload.UserID%2 == 0}, nil
+			},
+		)).
+		AddHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			seen = append(seen, pipeCtx.Option.EnableCache)
+			return nil
+		})
+
+	for _, id := range []int{2, 3, 0} {
+		if _, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: id}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if len(seen) != 3 || !seen[0] || seen[1] || seen[2] {
+		t.Errorf("Expected per-execution options [true false false], got %v", seen)
+	}
+}
@@ -0,0 +1,53 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore 基于内存的发件箱存储（用于测试和单机场景）
+type MemoryStore struct {
+	mu        sync.Mutex
+	events    []Event
+	published map[string]bool
+}
+
+// NewMemoryStore 创建内存存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{published: make(map[string]bool)}
+}
+
+// Append 追加事件
+func (m *MemoryStore) Append(ctx context.Context, events []Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, events...)
+	return nil
+}
+
+// Pending 读取未发布的事件
+func (m *MemoryStore) Pending(ctx context.Context, limit int) ([]Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var pending []Event
+	for _, event := range m.events {
+		if len(pending) >= limit {
+			break
+		}
+		if !m.published[event.ID] {
+			pending = append(pending, event)
+		}
+	}
+	return pending, nil
+}
+
+// MarkPublished 标记事件已发布
+func (m *MemoryStore) MarkPublished(ctx context.Context, ids ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		m.published[id] = true
+	}
+	return nil
+}
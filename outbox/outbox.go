@@ -0,0 +1,133 @@
+// Package outbox 为管道提供事务性发件箱（Transactional Outbox）。
+//
+// Hook 在执行过程中通过 Emit 产生领域事件，Middleware 在 Hook 成功后将事件写入
+// 发件箱存储；配合 TxRunner 时，Hook 的业务写入与事件写入位于同一事务中。
+// Relay 负责从存储中读取未发布的事件并投递给 Publisher，实现至少一次的事件发布。
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// bufferKey 当前 Hook 发件箱缓冲区的状态槽位
+var bufferKey = pipe.NewStateKey[*buffer]("outbox")
+
+// ErrNoOutbox 当前 Hook 未安装发件箱中间件
+var ErrNoOutbox = errors.New("outbox middleware not installed")
+
+// Event 领域事件
+type Event struct {
+	ID        string            // 事件 ID（为空时自动生成）
+	Topic     string            // 主题
+	Key       string            // 分区/排序 key
+	Payload   []byte            // 事件内容
+	Headers   map[string]string // 附加头信息
+	CreatedAt time.Time         // 创建时间
+}
+
+// Store 发件箱存储
+type Store interface {
+	// Append 写入事件；在 TxRunner 中调用时应使用 ctx 关联的事务
+	Append(ctx context.Context, events []Event) error
+	// Pending 按写入顺序读取未发布的事件
+	Pending(ctx context.Context, limit int) ([]Event, error)
+	// MarkPublished 标记事件已发布
+	MarkPublished(ctx context.Context, ids ...string) error
+}
+
+// TxRunner 在事务中执行 fn：fn 返回错误时回滚，否则提交
+// 实现方负责开启事务并将其绑定到 ctx，使 Hook 和 Store.Append 使用同一事务
+type TxRunner[C pipe.Context] func(ctx C, fn func() error) error
+
+// buffer 单个 Hook 执行期间产生的事件
+type buffer struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// Emit 在当前 Hook 中产生事件
+func Emit[Option any, Payload any, Result any](
+	pipeCtx *pipe.PipeContext[Option, Payload, Result],
+	event Event,
+) error {
+	buf, ok := pipe.LoadState(pipeCtx, bufferKey)
+	if !ok {
+		return ErrNoOutbox
+	}
+
+	if event.ID == "" {
+		event.ID = newID()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	buf.mu.Lock()
+	buf.events = append(buf.events, event)
+	buf.mu.Unlock()
+	return nil
+}
+
+// EmitJSON 以 JSON 编码 v 作为事件内容产生事件
+func EmitJSON[Option any, Payload any, Result any](
+	pipeCtx *pipe.PipeContext[Option, Payload, Result],
+	topic, key string,
+	v any,
+) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return Emit(pipeCtx, Event{Topic: topic, Key: key, Payload: payload})
+}
+
+// Middleware 发件箱中间件
+// Hook 成功后将其产生的事件写入 store；tx 不为 nil 时 Hook 与写入在同一事务中执行，
+// Hook 失败时事件被丢弃
+func Middleware[C pipe.Context, Option any, Payload any, Result any](
+	store Store,
+	tx TxRunner[C],
+) pipe.Middleware[C, Option, Payload, Result] {
+	return func(next pipe.HookHandler[C, Option, Payload, Result]) pipe.HookHandler[C, Option, Payload, Result] {
+		return func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) error {
+			run := func() error {
+				buf := &buffer{}
+				pipe.StoreState(pipeCtx, bufferKey, buf)
+				defer pipe.DeleteState(pipeCtx, bufferKey)
+
+				if err := next(ctx, pipeCtx); err != nil {
+					return err
+				}
+
+				buf.mu.Lock()
+				events := buf.events
+				buf.mu.Unlock()
+
+				if len(events) == 0 {
+					return nil
+				}
+				return store.Append(ctx, events)
+			}
+
+			if tx == nil {
+				return run()
+			}
+			return tx(ctx, run)
+		}
+	}
+}
+
+// newID 生成随机事件 ID
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,88 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+type order struct {
+	ID   string
+	Fail bool
+}
+
+// TestOutboxMiddlewareAndRelay 测试事件写入发件箱并由中继发布
+func TestOutboxMiddlewareAndRelay(t *testing.T) {
+	ctx := pipe.WrapContext(context.Background())
+	store := NewMemoryStore()
+
+	var commits, rollbacks int
+	tx := func(ctx pipe.Context, fn func() error) error {
+		if err := fn(); err != nil {
+			rollbacks++
+			return err
+		}
+		commits++
+		return nil
+	}
+
+	pipeline := pipe.NewSimplePipeline[order, struct{}]("orders").
+		Use(Middleware[pipe.Context, pipe.NoOption, order, struct{}](store, tx)).
+		AddNamedHook("create", func(ctx pipe.Context, pipeCtx *pipe.SimplePipeContext[order, struct{}]) error {
+			if err := EmitJSON(pipeCtx, "order.created", pipeCtx.Payload.ID, pipeCtx.Payload); err != nil {
+				return err
+			}
+			if keys := pipeCtx.Keys(); len(keys) != 0 {
+				t.Errorf("Expected outbox buffer outside shared data, got keys %v", keys)
+			}
+			if pipeCtx.Payload.Fail {
+				return errors.New("insert failed")
+			}
+			return nil
+		})
+
+	if _, err := pipeline.Execute(ctx, &order{ID: "o1"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := pipeline.Execute(ctx, &order{ID: "o2", Fail: true}); err == nil {
+		t.Fatal("Expected error for failing hook")
+	}
+
+	if commits != 1 || rollbacks != 1 {
+		t.Errorf("Expected 1 commit and 1 rollback, got %d and %d", commits, rollbacks)
+	}
+
+	var published []Event
+	relay := NewRelay(store, PublisherFunc(func(ctx context.Context, event Event) error {
+		published = append(published, event)
+		return nil
+	}))
+
+	n, err := relay.RelayOnce(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("Expected 1 published event, got %d, %v", n, err)
+	}
+
+	if published[0].Topic != "order.created" || published[0].Key != "o1" {
+		t.Errorf("Unexpected event: %+v", published[0])
+	}
+
+	if n, _ := relay.RelayOnce(ctx); n != 0 {
+		t.Errorf("Expected no pending events, got %d", n)
+	}
+}
+
+// TestEmitWithoutMiddleware 测试未安装中间件时 Emit 返回错误
+func TestEmitWithoutMiddleware(t *testing.T) {
+	pipeline := pipe.NewSimplePipeline[order, struct{}]("orders").
+		AddHook(func(ctx pipe.Context, pipeCtx *pipe.SimplePipeContext[order, struct{}]) error {
+			return Emit(pipeCtx, Event{Topic: "x"})
+		})
+
+	_, err := pipeline.Execute(pipe.WrapContext(context.Background()), &order{})
+	if !errors.Is(err, ErrNoOutbox) {
+		t.Errorf("Expected ErrNoOutbox, got %v", err)
+	}
+}
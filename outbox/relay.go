@@ -0,0 +1,106 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+// Publisher 事件发布器（消息队列等）
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// PublisherFunc 函数形式的 Publisher
+type PublisherFunc func(ctx context.Context, event Event) error
+
+// Publish 调用函数本身
+func (f PublisherFunc) Publish(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// Relay 发件箱中继：轮询未发布事件并投递
+type Relay struct {
+	store     Store
+	publisher Publisher
+	batchSize int
+	interval  time.Duration
+	onError   func(err error)
+}
+
+// NewRelay 创建中继（默认每批 100 条，轮询间隔 1 秒）
+func NewRelay(store Store, publisher Publisher) *Relay {
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		batchSize: 100,
+		interval:  time.Second,
+	}
+}
+
+// WithBatchSize 设置每批读取的事件数
+func (r *Relay) WithBatchSize(n int) *Relay {
+	r.batchSize = n
+	return r
+}
+
+// WithInterval 设置轮询间隔
+func (r *Relay) WithInterval(d time.Duration) *Relay {
+	r.interval = d
+	return r
+}
+
+// OnError 设置 Run 过程中的错误回调
+func (r *Relay) OnError(fn func(err error)) *Relay {
+	r.onError = fn
+	return r
+}
+
+// RelayOnce 投递一批事件，返回成功发布的数量
+// 遇到发布失败时停止本批次，保证同一存储中的事件按顺序发布
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	events, err := r.store.Pending(ctx, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := make([]string, 0, len(events))
+	var publishErr error
+	for _, event := range events {
+		if publishErr = r.publisher.Publish(ctx, event); publishErr != nil {
+			break
+		}
+		published = append(published, event.ID)
+	}
+
+	if len(published) > 0 {
+		if err := r.store.MarkPublished(ctx, published...); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(published), publishErr
+}
+
+// Run 持续轮询并投递事件，直到 ctx 被取消
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := r.RelayOnce(ctx)
+			if err != nil && r.onError != nil {
+				r.onError(err)
+			}
+			if err != nil || n < r.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
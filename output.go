@@ -0,0 +1,36 @@
+package pipeline
+
+import "fmt"
+
+// SetOutput 以当前 Hook 的名称发布类型化输出，与自由格式的共享数据分开保存，
+// 之后的 Hook 通过 HookOutput 按名称和类型读取，使 Hook 之间的数据约定显式且类型安全。
+// 同一 Hook 再次发布时覆盖；重试快照（Snapshot/Restore）同样回滚输出
+func SetOutput[T any, Option any, Payload any, Result any](pipeCtx *PipeContext[Option, Payload, Result], value T) {
+	name, _ := pipeCtx.CurrentHook()
+
+	pipeCtx.state.mu.Lock()
+	defer pipeCtx.state.mu.Unlock()
+	if pipeCtx.state.outputs == nil {
+		pipeCtx.state.outputs = make(map[string]any)
+	}
+	pipeCtx.state.outputs[name] = value
+}
+
+// HookOutput 读取名为 hook 的 Hook 发布的输出
+// 未发布时返回 ErrHookOutputNotFound，类型不符时返回 ErrHookOutputType
+func HookOutput[T any, Option any, Payload any, Result any](pipeCtx *PipeContext[Option, Payload, Result], hook string) (T, error) {
+	var zero T
+
+	pipeCtx.state.mu.RLock()
+	value, ok := pipeCtx.state.outputs[hook]
+	pipeCtx.state.mu.RUnlock()
+	if !ok {
+		return zero, fmt.Errorf("%w: %s", ErrHookOutputNotFound, hook)
+	}
+
+	typed, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s is %T, not %T", ErrHookOutputType, hook, value, zero)
+	}
+	return typed, nil
+}
@@ -0,0 +1,68 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type scoreOutput struct {
+	Value  int
+	Reason string
+}
+
+// TestHookOutput 测试类型化的 Hook 输出
+func TestHookOutput(t *testing.T) {
+	var (
+		score    scoreOutput
+		missing  error
+		mismatch error
+	)
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		AddNamedHook("score", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			SetOutput(pipeCtx, scoreOutput{Value: 1})
+			SetOutput(pipeCtx, scoreOutput{Value: 42, Reason: "vip"}) // 覆盖
+			return nil
+		}).
+		AddNamedHook("decide", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			var err error
+			if score, err = HookOutput[scoreOutput](pipeCtx, "score"); err != nil {
+				return err
+			}
+			_, missing = HookOutput[scoreOutput](pipeCtx, "enrich")
+			_, mismatch = HookOutput[int](pipeCtx, "score")
+			if _, ok := pipeCtx.Get("score"); ok {
+				t.Error("Expected outputs to be separate from shared data")
+			}
+			return nil
+		})
+
+	if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if score != (scoreOutput{Value: 42, Reason: "vip"}) {
+		t.Errorf("Expected published score, got %+v", score)
+	}
+	if !errors.Is(missing, ErrHookOutputNotFound) {
+		t.Errorf("Expected ErrHookOutputNotFound, got %v", missing)
+	}
+	if !errors.Is(mismatch, ErrHookOutputType) {
+		t.Errorf("Expected ErrHookOutputType, got %v", mismatch)
+	}
+}
+
+// TestHookOutputRestore 测试快照回滚 Hook 输出
+func TestHookOutputRestore(t *testing.T) {
+	pipeCtx := NewPipeContext[NoOption, TestPayload, TestResult]("test", nil, &TestPayload{}, nil)
+	pipeCtx.setCurrentHook("score", 0, "")
+	SetOutput(pipeCtx, 1)
+
+	snap := pipeCtx.Snapshot()
+	SetOutput(pipeCtx, 2)
+	pipeCtx.Restore(snap)
+
+	if v, err := HookOutput[int](pipeCtx, "score"); err != nil || v != 1 {
+		t.Errorf("Expected restored output 1, got %v, %v", v, err)
+	}
+}
@@ -0,0 +1,295 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ResultMerger 并行分支结果合并器
+// base 为分支开始前的 Result 快照，branches 按分支声明顺序排列
+type ResultMerger[Result any] interface {
+	Merge(base *Result, branches []*Result) (*Result, error)
+}
+
+// ResultMergerFunc 函数形式的结果合并器
+type ResultMergerFunc[Result any] func(base *Result, branches []*Result) (*Result, error)
+
+// Merge 实现 ResultMerger
+func (f ResultMergerFunc[Result]) Merge(base *Result, branches []*Result) (*Result, error) {
+	return f(base, branches)
+}
+
+// LastWriteWins 最后一个分支的 Result 整体覆盖（默认合并策略）
+type LastWriteWins[Result any] struct{}
+
+// Merge 实现 ResultMerger
+func (LastWriteWins[Result]) Merge(base *Result, branches []*Result) (*Result, error) {
+	if len(branches) == 0 {
+		return base, nil
+	}
+	return branches[len(branches)-1], nil
+}
+
+// FieldMerger 按字段合并：分支修改过的顶层导出字段写回结果
+// 多个分支修改同一字段时后声明的分支生效；Debug 模式下写入不同值视为冲突并返回 MergeConflictError
+// Result 不是结构体时按整体比较
+type FieldMerger[Result any] struct {
+	Debug bool // 调试模式：检测字段写冲突
+}
+
+// Merge 实现 ResultMerger
+func (m FieldMerger[Result]) Merge(base *Result, branches []*Result) (*Result, error) {
+	merged := DeepCopy(base)
+	if merged == nil {
+		merged = new(Result)
+	}
+
+	baseVal := reflect.ValueOf(base).Elem()
+	mergedVal := reflect.ValueOf(merged).Elem()
+
+	if baseVal.Kind() != reflect.Struct {
+		return merged, m.mergeField("", baseVal, mergedVal, branches, func(v reflect.Value) reflect.Value { return v })
+	}
+
+	for i := 0; i < baseVal.NumField(); i++ {
+		field := baseVal.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		index := i
+		err := m.mergeField(field.Name, baseVal.Field(i), mergedVal.Field(i), branches, func(v reflect.Value) reflect.Value {
+			return v.Field(index)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// mergeField 合并单个字段
+func (m FieldMerger[Result]) mergeField(
+	name string,
+	base, dst reflect.Value,
+	branches []*Result,
+	pick func(reflect.Value) reflect.Value,
+) error {
+	var (
+		writers []int
+		written reflect.Value
+	)
+
+	for i, branch := range branches {
+		if branch == nil {
+			continue
+		}
+		val := pick(reflect.ValueOf(branch).Elem())
+		if reflect.DeepEqual(val.Interface(), base.Interface()) {
+			continue
+		}
+
+		if m.Debug && len(writers) > 0 && !reflect.DeepEqual(val.Interface(), written.Interface()) {
+			return &MergeConflictError{Field: name, Branches: append(writers, i)}
+		}
+		writers = append(writers, i)
+		written = val
+		dst.Set(val)
+	}
+	return nil
+}
+
+// ParallelBuilder 并行分支构建器
+type ParallelBuilder[C Context, Option any, Payload any, Result any] struct {
+	branches [][]*Hook[C, Option, Payload, Result]
+	merger   ResultMerger[Result]
+}
+
+// Parallel 创建并行分支，每个 Handler 作为一个独立分支
+func Parallel[C Context, Option any, Payload any, Result any](
+	handlers ...HookHandler[C, Option, Payload, Result],
+) *ParallelBuilder[C, Option, Payload, Result] {
+	b := &ParallelBuilder[C, Option, Payload, Result]{merger: LastWriteWins[Result]{}}
+	for _, handler := range handlers {
+		b.Branch(handler)
+	}
+	return b
+}
+
+// Branch 添加一个分支，分支内的 Hook 依次执行
+func (b *ParallelBuilder[C, Option, Payload, Result]) Branch(
+	handlers ...HookHandler[C, Option, Payload, Result],
+) *ParallelBuilder[C, Option, Payload, Result] {
+	hooks := make([]*Hook[C, Option, Payload, Result], 0, len(handlers))
+	for _, handler := range handlers {
+		hooks = append(hooks, &Hook[C, Option, Payload, Result]{Handler: handler})
+	}
+	return b.BranchHooks(hooks...)
+}
+
+// BranchHooks 添加由 Hook 组成的分支，分支内依次执行；Hook 名称用于错误和描述中的定位
+func (b *ParallelBuilder[C, Option, Payload, Result]) BranchHooks(
+	hooks ...*Hook[C, Option, Payload, Result],
+) *ParallelBuilder[C, Option, Payload, Result] {
+	b.branches = append(b.branches, hooks)
+	return b
+}
+
+// Merger 设置结果合并策略（默认 LastWriteWins）
+func (b *ParallelBuilder[C, Option, Payload, Result]) Merger(m ResultMerger[Result]) *ParallelBuilder[C, Option, Payload, Result] {
+	b.merger = m
+	return b
+}
+
+// Build 构建并行 Hook
+// 每个分支在独立的 Result 深拷贝上执行，共享数据和中断标记；全部成功后由合并器写回 Result
+// 分支中的 panic 转换为该分支的 PipeError（Err 为带堆栈的 PanicError）。
+// 分支共享一个可取消的上下文（见 ContextWithCancel）：任一分支失败或调用 Abort 时取消其余分支及其中的子管道，
+// 因此而以 context.Canceled 结束的分支不计为失败，记入 ExecutionStats.Cancellations。
+// C 无法派生可取消的上下文时，正在执行的子 Hook 收不到取消，其余分支在下一个子 Hook 开始前停止
+func (b *ParallelBuilder[C, Option, Payload, Result]) Build(name string) *Hook[C, Option, Payload, Result] {
+	groups := make([]hookGroup[C, Option, Payload, Result], 0, len(b.branches))
+	for i, hooks := range b.branches {
+		group := hookGroup[C, Option, Payload, Result]{owner: name, label: fmt.Sprintf("branch %d", i), hooks: hooks}
+		group.recoverPanics = true // 分支在独立 goroutine 中执行，调用方的 Recovery 中间件无法捕获
+		groups = append(groups, group)
+	}
+	merger := b.merger
+
+	return &Hook[C, Option, Payload, Result]{
+		Name: name,
+		Handler: func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+			base := DeepCopy(pipeCtx.Result)
+			results := make([]*Result, len(groups))
+			errs := make([]*PipeError, len(groups))
+
+			branchCtx, cancel, derived := ContextWithCancel(ctx)
+			defer cancel()
+
+			// 无法派生时改用分支取消信号，由 runIndexed 在子 Hook 之间检查
+			var branch context.Context
+			if !derived {
+				parent := pipeCtx.branch
+				if parent == nil {
+					parent = context.Background()
+				}
+				var cancelBranch context.CancelFunc
+				branch, cancelBranch = context.WithCancel(parent)
+				defer cancelBranch()
+				cancel = cancelBranch
+			}
+
+			var (
+				stopOnce sync.Once
+				trigger  = -1 // 触发取消的分支索引（中断时为 -1）
+				cause    error
+				stopped  bool
+			)
+			stop := func(i int, err error) {
+				stopOnce.Do(func() {
+					trigger, cause, stopped = i, err, true
+					cancel()
+				})
+			}
+
+			// 中断时取消仍在执行的分支
+			done := make(chan struct{})
+			watched := make(chan struct{})
+			go func() {
+				defer close(watched)
+				select {
+				case <-pipeCtx.abortSignal():
+					stop(-1, ErrAborted)
+				case <-done:
+				}
+			}()
+
+			var wg sync.WaitGroup
+			scopes := make([]*dataScope, len(groups))
+			for i, group := range groups {
+				results[i] = DeepCopy(base)
+				forked := pipeCtx.fork(results[i])
+				if branch != nil {
+					forked.branch = branch
+				}
+				scopes[i] = forked.beginScope(fmt.Sprintf("%s/%s", name, group.label))
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if j, err := group.runIndexed(branchCtx, forked); err != nil {
+						errs[i] = branchError(pipeCtx, group, i, j, err)
+						stop(i, errs[i])
+					}
+				}()
+			}
+			wg.Wait()
+			close(done)
+			<-watched
+
+			// 严格模式：并行组结束后，分支写入的数据才可被其他 Hook 读取
+			for _, scope := range scopes {
+				scope.finish()
+			}
+
+			// 因其他分支失败或中断而取消的分支不计为失败
+			if stopped && ctx.Err() == nil {
+				for i, err := range errs {
+					if err == nil || i == trigger || !errors.Is(err, context.Canceled) {
+						continue
+					}
+					errs[i] = nil
+					pipeCtx.stats.AddCancellation(CancelStat{
+						Name:  fmt.Sprintf("%s/%s", name, groups[i].label),
+						Cause: cause,
+						At:    time.Now(),
+					})
+				}
+			}
+
+			if err := newPipeErrors(errs); err != nil {
+				return err
+			}
+			merged, err := merger.Merge(base, results)
+			if err != nil {
+				return err
+			}
+			*pipeCtx.Result = *merged
+			return nil
+		},
+		kind:   "parallel",
+		groups: groups,
+	}
+}
+
+// branchError 为并行分支中失败的子 Hook 创建 PipeError
+// HookName 为 "分支标签/子 Hook 名称"，HookIndex 为分支索引
+func branchError[C Context, Option any, Payload any, Result any](
+	pipeCtx *PipeContext[Option, Payload, Result],
+	group hookGroup[C, Option, Payload, Result],
+	branch, index int,
+	err error,
+) *PipeError {
+	hook := group.hooks[index]
+	name := hook.Name
+	if name == "" {
+		name = FuncName(hook.Handler)
+	}
+
+	pipeErr := newPipeError(pipeCtx.Name, fmt.Sprintf("%s/%s", group.label, name), branch, err)
+	pipeErr.Class = pipeCtx.ClassifyError(err)
+	pipeErr.Code = pipeCtx.errorCode(err, hook.ErrorCode, pipeErr.Class)
+	pipeErr.Retryable = pipeCtx.ShouldRetry(err)
+	return pipeErr
+}
+
+// AddParallel 添加并行分支
+func (p *Pipeline[C, Option, Payload, Result]) AddParallel(
+	name string,
+	parallel *ParallelBuilder[C, Option, Payload, Result],
+) *Pipeline[C, Option, Payload, Result] {
+	return p.AddHookWithOptions(parallel.Build(name))
+}
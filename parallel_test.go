@@ -0,0 +1,231 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestParallelFieldMerger 测试并行分支按字段合并结果
+func TestParallelFieldMerger(t *testing.T) {
+	writeOutput := func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		pipeCtx.Result.Output = append(pipeCtx.Result.Output, "a")
+		return nil
+	}
+	writeMetadata := func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		pipeCtx.Result.Metadata = map[string]any{"b": true}
+		pipeCtx.Set("b", true)
+		return nil
+	}
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddParallel("fanout", Parallel(writeOutput, writeMetadata).
+			Merger(FieldMerger[TestResult]{Debug: true}))
+
+	result, err := pipeline.Execute(newMockContext(), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.Output) != 1 || result.Metadata["b"] != true {
+		t.Errorf("Expected both branch writes merged, got %+v", result)
+	}
+}
+
+// TestParallelMergeConflict 测试调试模式下的写冲突检测
+func TestParallelMergeConflict(t *testing.T) {
+	write := func(value string) HookHandler[sylph.Context, TestOption, TestPayload, TestResult] {
+		return func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			pipeCtx.Result.Output = []string{value}
+			return nil
+		}
+	}
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddParallel("fanout", Parallel(write("a"), write("b")).
+			Merger(FieldMerger[TestResult]{Debug: true}))
+
+	_, err := pipeline.Execute(newMockContext(), &TestPayload{})
+	if !errors.Is(err, ErrMergeConflict) {
+		t.Fatalf("Expected ErrMergeConflict, got %v", err)
+	}
+
+	var conflict *MergeConflictError
+	if !errors.As(err, &conflict) || conflict.Field != "Output" {
+		t.Errorf("Expected conflict on Output, got %v", err)
+	}
+}
+
+// TestParallelPipeErrors 测试并行分支失败按 Hook 聚合为 PipeErrors
+func TestParallelPipeErrors(t *testing.T) {
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	fail := func(err error) HookHandler[Context, NoOption, TestPayload, TestResult] {
+		return func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			return err
+		}
+	}
+	ok := func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+		return nil
+	}
+
+	// 可派生的上下文：兄弟分支通过上下文取消，不检查上下文的子 Hook 仍会执行
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		AddParallel("fanout", Parallel(fail(errA)).Branch(ok).Branch(ok, fail(errB)))
+
+	_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("Expected both branch errors, got %v", err)
+	}
+
+	var errs PipeErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("Expected 2 PipeErrors, got %v", err)
+	}
+	if errs[0].HookIndex != 0 || errs[1].HookIndex != 2 {
+		t.Errorf("Expected branch indexes 0 and 2, got %d and %d", errs[0].HookIndex, errs[1].HookIndex)
+	}
+	if !strings.HasPrefix(errs[1].HookName, "branch 2/") {
+		t.Errorf("Expected hook name attributed to branch 2, got %s", errs[1].HookName)
+	}
+}
+
+// TestParallelBranchPanic 测试分支 panic 转换为该分支的 PipeError
+func TestParallelBranchPanic(t *testing.T) {
+	ok := func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		return nil
+	}
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddParallel("fanout", Parallel[sylph.Context, TestOption, TestPayload, TestResult]().
+			Branch(ok).
+			Branch(ok, func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+				panic("branch exploded")
+			}))
+
+	_, err := pipeline.Execute(newMockContext(), &TestPayload{})
+
+	var pipeErrs PipeErrors
+	if !errors.As(err, &pipeErrs) || len(pipeErrs) != 1 || pipeErrs[0].HookIndex != 1 {
+		t.Fatalf("Expected a single PipeError for branch 1, got %v", err)
+	}
+	if !strings.HasPrefix(pipeErrs[0].HookName, "branch 1/") {
+		t.Errorf("Expected branch attribution, got %s", pipeErrs[0].HookName)
+	}
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "branch exploded" || len(panicErr.Stack) == 0 {
+		t.Errorf("Expected PanicError with stack, got %v", err)
+	}
+}
+
+// TestParallelCancelSiblings 测试分支失败时取消兄弟分支，被取消的分支不计为失败
+func TestParallelCancelSiblings(t *testing.T) {
+	errFailed := errors.New("failed")
+	fail := func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+		return errFailed
+	}
+	wait := func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	var stats *ExecutionStats
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		AddParallel("fanout", Parallel(fail, wait)).
+		WithStatsSink(StatsSinkFunc(func(s *ExecutionStats) { stats = s }))
+
+	_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	var errs PipeErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || !errors.Is(err, errFailed) {
+		t.Fatalf("Expected only the failing branch error, got %v", err)
+	}
+	if len(stats.Cancellations) != 1 || stats.Cancellations[0].Name != "fanout/branch 1" {
+		t.Fatalf("Expected branch 1 recorded as cancelled, got %+v", stats.Cancellations)
+	}
+	if !errors.Is(stats.Cancellations[0].Cause, errFailed) {
+		t.Errorf("Expected cancellation cause to be the failing branch, got %v", stats.Cancellations[0].Cause)
+	}
+}
+
+// TestParallelCancelSiblingsWithoutDeriver 测试上下文无法派生时，兄弟分支在下一个子 Hook 前停止
+func TestParallelCancelSiblingsWithoutDeriver(t *testing.T) {
+	errFailed := errors.New("failed")
+	started := make(chan struct{})
+	var reached atomic.Bool
+	fail := func(ctx underivedContext, pipeCtx *PipeContext[NoOption, TestPayload, TestResult]) error {
+		<-started
+		return errFailed
+	}
+	slow := func(ctx underivedContext, pipeCtx *PipeContext[NoOption, TestPayload, TestResult]) error {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}
+	next := func(ctx underivedContext, pipeCtx *PipeContext[NoOption, TestPayload, TestResult]) error {
+		reached.Store(true)
+		return nil
+	}
+
+	var stats *ExecutionStats
+	pipeline := NewPipeline[underivedContext, NoOption, TestPayload, TestResult]("test").
+		AddParallel("fanout", Parallel(fail).Branch(slow, next)).
+		WithStatsSink(StatsSinkFunc(func(s *ExecutionStats) { stats = s }))
+
+	_, err := pipeline.Execute(underivedContext{WrapContext(context.Background())}, &TestPayload{})
+	var errs PipeErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || !errors.Is(err, errFailed) {
+		t.Fatalf("Expected only the failing branch error, got %v", err)
+	}
+	if reached.Load() {
+		t.Error("Expected sibling branch to stop before its next hook")
+	}
+	if len(stats.Cancellations) != 1 || stats.Cancellations[0].Name != "fanout/branch 1" {
+		t.Errorf("Expected branch 1 recorded as cancelled, got %+v", stats.Cancellations)
+	}
+}
+
+// TestParallelAbortCancelsSubPipeline 测试分支中断时取消兄弟分支中的子管道
+func TestParallelAbortCancelsSubPipeline(t *testing.T) {
+	var subStats *ExecutionStats
+	started := make(chan struct{})
+	sub := NewSimplePipeline[TestPayload, TestResult]("sub").
+		AddNamedHook("wait", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		}).
+		AddNamedHook("after", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			t.Error("Expected hooks after cancellation not to run")
+			return nil
+		}).
+		WithStatsSink(StatsSinkFunc(func(s *ExecutionStats) { subStats = s }))
+
+	abort := func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+		<-started
+		pipeCtx.AbortWithReason("done early")
+		return nil
+	}
+	nested := SubPipeline[Context, NoOption, TestPayload, TestResult, TestPayload, TestResult](sub,
+		func(pipeCtx *SimplePipeContext[TestPayload, TestResult]) *TestPayload { return pipeCtx.Payload }, nil)
+
+	var stats *ExecutionStats
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		AddParallel("fanout", Parallel(abort, nested)).
+		WithStatsSink(StatsSinkFunc(func(s *ExecutionStats) { stats = s }))
+
+	if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil {
+		t.Fatalf("Expected aborted execution to succeed, got %v", err)
+	}
+	if len(stats.Cancellations) != 1 || !errors.Is(stats.Cancellations[0].Cause, ErrAborted) {
+		t.Errorf("Expected sub-pipeline branch cancelled by abort, got %+v", stats.Cancellations)
+	}
+	if !subStats.Cancelled || !subStats.HookStats[0].Cancelled {
+		t.Errorf("Expected sub-pipeline recorded as cancelled, got %+v", subStats)
+	}
+}
@@ -0,0 +1,57 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// WithImmutablePayload 启用只读 Payload 模式
+// 每个 Hook（包括每次重试）拿到的都是原始 Payload 的深拷贝，Hook 的修改不会影响后续 Hook；
+// 使用 pipedebug 构建标签编译时，Hook 修改 Payload 会以 ErrPayloadMutated 失败
+func (p *Pipeline[C, Option, Payload, Result]) WithImmutablePayload() *Pipeline[C, Option, Payload, Result] {
+	p.immutablePayload = true
+	return p
+}
+
+// immutablePayload 包装 Handler：执行前替换为 Payload 深拷贝，调试构建下检测修改
+func immutablePayload[C Context, Option any, Payload any, Result any](
+	handler HookHandler[C, Option, Payload, Result],
+	original *Payload,
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+		pipeCtx.Payload = DeepCopy(original)
+		defer func() { pipeCtx.Payload = original }()
+
+		err := handler(ctx, pipeCtx)
+		if err == nil && debugPayloadMutation && !reflect.DeepEqual(pipeCtx.Payload, original) {
+			name, _ := pipeCtx.CurrentHook()
+			return fmt.Errorf("%w: hook '%s'", ErrPayloadMutated, name)
+		}
+		return err
+	}
+}
+
+// OnValidatePayload 注册 Payload 校验（前置条件），在 BeforeExecute 之后、第一个 Hook 之前执行
+// 校验在 Hook 的错误处理之外进行，SkipOnError 和 WithFallback 不会吞掉校验失败；
+// 任一校验失败时不执行任何 Hook，管道返回 *ValidationError（非 ValidationError 的错误会被包装）
+func (p *Pipeline[C, Option, Payload, Result]) OnValidatePayload(
+	fn func(ctx C, payload *Payload) error,
+) *Pipeline[C, Option, Payload, Result] {
+	p.payloadChecks = append(p.payloadChecks, fn)
+	return p
+}
+
+// validatePayload 依次执行 Payload 校验，返回第一个失败
+func (p *Pipeline[C, Option, Payload, Result]) validatePayload(ctx C, payload *Payload) error {
+	for _, check := range p.payloadChecks {
+		if err := check(ctx, payload); err != nil {
+			var verr *ValidationError
+			if errors.As(err, &verr) {
+				return err
+			}
+			return &ValidationError{Target: "payload", Err: err}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+//go:build pipedebug
+
+package pipeline
+
+// debugPayloadMutation 调试构建：检测 Hook 对只读 Payload 的修改
+var debugPayloadMutation = true
@@ -0,0 +1,6 @@
+//go:build !pipedebug
+
+package pipeline
+
+// debugPayloadMutation 非调试构建不做修改检测
+var debugPayloadMutation = false
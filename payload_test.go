@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestImmutablePayload 测试只读 Payload 模式
+func TestImmutablePayload(t *testing.T) {
+	debug := debugPayloadMutation
+	defer func() { debugPayloadMutation = debug }()
+	debugPayloadMutation = false
+
+	var seen []string
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		WithImmutablePayload().
+		AddHook(
+			func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+				pipeCtx.Payload.Data = "mutated"
+				return nil
+			},
+			func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+				seen = append(seen, pipeCtx.Payload.Data)
+				return nil
+			},
+		)
+
+	payload := &TestPayload{Data: "original"}
+	if _, err := pipeline.Execute(newMockContext(), payload); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if payload.Data != "original" {
+		t.Errorf("Expected payload untouched, got %s", payload.Data)
+	}
+	if len(seen) != 1 || seen[0] != "original" {
+		t.Errorf("Expected second hook to see original payload, got %v", seen)
+	}
+
+	debugPayloadMutation = true
+
+	_, err := pipeline.Execute(newMockContext(), payload)
+	if !errors.Is(err, ErrPayloadMutated) {
+		t.Errorf("Expected ErrPayloadMutated, got %v", err)
+	}
+}
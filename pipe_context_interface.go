@@ -2,6 +2,14 @@ package pipeline
 
 import "context"
 
+// Logger 定义管道使用的结构化日志接口
+type Logger interface {
+	Info(pkg, action string, data any)
+	Warn(pkg, action string, data any)
+	Error(pkg, action string, err error, data any)
+	Debug(pkg, action string, data any)
+}
+
 // Context 定义管道执行所需的最小上下文接口
 // 用户可以传入任何实现了该接口的类型，包括 sylph.Context
 type Context interface {
@@ -9,28 +17,38 @@ type Context interface {
 	context.Context
 
 	// 日志方法：支持结构化日志记录
-	Info(pkg, action string, data any)
-	Warn(pkg, action string, data any)
-	Error(pkg, action string, err error, data any)
-	Debug(pkg, action string, data any)
+	Logger
 }
 
+// nopLogger 空操作日志实现
+type nopLogger struct{}
+
+func (nopLogger) Info(pkg, action string, data any)             {}
+func (nopLogger) Warn(pkg, action string, data any)             {}
+func (nopLogger) Error(pkg, action string, err error, data any) {}
+func (nopLogger) Debug(pkg, action string, data any)            {}
+
 // stdContextAdapter 是标准 context.Context 的适配器
-// 为不需要日志功能的场景提供默认实现
+// 日志调用转发给内部的 Logger（默认为空操作）
 type stdContextAdapter struct {
 	context.Context
+	Logger
 }
 
-func (s *stdContextAdapter) Info(pkg, action string, data any)             {}
-func (s *stdContextAdapter) Warn(pkg, action string, data any)             {}
-func (s *stdContextAdapter) Error(pkg, action string, err error, data any) {}
-func (s *stdContextAdapter) Debug(pkg, action string, data any)            {}
-
 // WrapContext 将标准 context.Context 包装为 pipe.Context
 // 日志方法默认为空操作（no-op）
 func WrapContext(ctx context.Context) Context {
+	return WrapContextWithLogger(ctx, nil)
+}
+
+// WrapContextWithLogger 将标准 context.Context 与 Logger 组合为 pipe.Context
+// logger 为 nil 时日志方法为空操作
+func WrapContextWithLogger(ctx context.Context, logger Logger) Context {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return &stdContextAdapter{Context: ctx}
+	if logger == nil {
+		logger = nopLogger{}
+	}
+	return &stdContextAdapter{Context: ctx, Logger: logger}
 }
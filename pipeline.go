@@ -68,6 +68,12 @@
 package pipeline
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -79,16 +85,80 @@ type Pipeline[C Context, Option any, Payload any, Result any] struct {
 	Name   string  // 管道名称
 	option *Option // 选项数据（指针类型，与 OptionHandler 一致）
 
-	hooks       []*Hook[C, Option, Payload, Result]      // Hook 列表
-	middlewares []Middleware[C, Option, Payload, Result] // 中间件列表
+	optionResolver OptionResolver[Option, Payload] // 按次解析 Option（可选）
+	deadlinePolicy DeadlinePolicy                  // 剩余时间不足时的处理策略
+	concurrency    int                             // 异步执行的最大并发数
+	queueSize      int                             // 异步执行的最大排队数
+	cancelGrace    time.Duration                   // 取消后等待 Hook 返回的宽限时间（0 表示不监控）
+	classifier     ErrorClassifier                 // 错误分类器（默认 DefaultClassifier）
+
+	reducer ResultReducer[Result] // Result 归并函数（可选）
+
+	payloadCodec Codec[Payload] // Payload 编解码器（可选）
+	resultCodec  Codec[Result]  // Result 编解码器（可选）
+
+	description string            // 管道描述
+	labels      map[string]string // 管道标签（team、domain 等）
+
+	hooks             []*Hook[C, Option, Payload, Result]           // Hook 列表
+	middlewareEntries []namedMiddleware[C, Option, Payload, Result] // 中间件注册列表（按注册顺序）
+	middlewareChain   []namedMiddleware[C, Option, Payload, Result] // 按优先级排序的中间件（生效顺序）
+	middlewares       []Middleware[C, Option, Payload, Result]      // 完整的中间件链（没有条件中间件时直接使用）
+
+	conditionalMiddlewares bool // 存在 UseFor/ForHooks 注册的条件中间件
+
+	stagePolicies map[string]*StagePolicy[C, Option, Payload, Result] // 命名阶段的执行策略
 
 	// 生命周期钩子
 	beforeExecute []func(ctx C, pipeCtx *PipeContext[Option, Payload, Result])
 	afterExecute  []func(ctx C, pipeCtx *PipeContext[Option, Payload, Result], err error)
 	onError       []func(ctx C, hookName string, err error)
+	payloadChecks []func(ctx C, payload *Payload) error
+	resultChecks  []func(ctx C, result *Result) error
+	onRetry       []func(ctx C, event RetryEvent)
+	onTimeout     []func(ctx C, event TimeoutEvent)
+	onZombie      []func(ctx C, event ZombieEvent)
+	onShadow      []func(ctx C, report ShadowReport[Result])
+
+	shadowDiff []DiffOption // 影子执行对比 Result 的选项
+	statsSinks []StatsSink  // 执行统计接收端
+	eventStore EventStore   // 执行事件存储（可选）
+	dataEvents bool         // 事件存储是否记录共享数据变更
+
+	profileRate float64 // 剖析采样比例（见 WithProfiling）
+	noHookStats bool    // 不记录 Hook 级统计（见 WithoutStats）
+
+	container     *Container       // 依赖注入容器（按需创建）
+	containerOnce sync.Once        // 保护 container 的延迟创建
+	logger        Logger           // ExecuteStd 使用的日志实现（可选）
+	clock         Clock            // pipeCtx.Now() 的时间源（可选）
+	seed          *uint64          // pipeCtx.Rand() 的固定种子（可选）
+	standby       []standbyBinding // 每次执行前取出的预热资源
+
+	immutablePayload bool // 每个 Hook 使用 Payload 的深拷贝
+	panicIsolation   bool // 每个 Hook 在独立 goroutine 中执行并恢复 panic
+	panicRecovery    bool // 在当前 goroutine 中恢复 Hook（含中间件）的 panic
+	panicRethrow     bool // 因 panic 失败时在执行结束后重新 panic
+	strictData       bool // 共享数据严格模式（调试用）
+	concurrentData   bool // 使用分片的共享数据存储
+	enforceSunset    bool // Validate 检查弃用 Hook 的下线日期
+
+	providedKeys []string        // Hook 执行前已存在的共享数据键（Validate 使用）
+	redactedKeys map[string]bool // 调试输出时脱敏的共享数据键
+	errorCapture *errorCapture   // 失败时捕获到 PipeError 的上下文（可选）
+
+	lifecycle pipelineLifecycle[C] // 跨执行的初始化与释放
+
+	hookRegistry *HookRegistry[C, Option, Payload, Result] // 按名称引用 Hook 的注册表（默认全局）
+	coverage     *CoverageCollector                        // 测试覆盖收集器（可选）
+
+	hookNamer HookNamer    // 未命名 Hook 的名称生成规则
+	hookNames sync.Map     // 自动生成的 Hook 名称缓存
+	durations sync.Map     // Hook 历史耗时（*Hook -> *durationHistory）
+	zombies   atomic.Int64 // 仍在后台运行的僵尸 Hook goroutine 数量
 }
 
-// NewPipeline 创建新的管道
+// NewPipeline 创建新的管道（应用 SetDefaults 设置的默认配置）
 func NewPipeline[C Context, Option any, Payload any, Result any](
 	name string,
 	opts ...OptionHandler[Option],
@@ -98,7 +168,7 @@ func NewPipeline[C Context, Option any, Payload any, Result any](
 		opt(option)
 	}
 
-	return &Pipeline[C, Option, Payload, Result]{
+	p := &Pipeline[C, Option, Payload, Result]{
 		Name:          name,
 		option:        option,
 		hooks:         make([]*Hook[C, Option, Payload, Result], 0),
@@ -106,7 +176,10 @@ func NewPipeline[C Context, Option any, Payload any, Result any](
 		beforeExecute: make([]func(C, *PipeContext[Option, Payload, Result]), 0),
 		afterExecute:  make([]func(C, *PipeContext[Option, Payload, Result], error), 0),
 		onError:       make([]func(C, string, error), 0),
+		hookNamer:     FuncName,
 	}
+	p.applyDefaults()
+	return p
 }
 
 // AddHook 添加 Hook（简化版，直接使用 Handler）
@@ -143,11 +216,14 @@ func (p *Pipeline[C, Option, Payload, Result]) AddHookWithOptions(
 	return p
 }
 
-// Use 使用中间件
+// Use 使用中间件（未命名，优先级为 0）
 func (p *Pipeline[C, Option, Payload, Result]) Use(
 	middlewares ...Middleware[C, Option, Payload, Result],
 ) *Pipeline[C, Option, Payload, Result] {
-	p.middlewares = append(p.middlewares, middlewares...)
+	for _, middleware := range middlewares {
+		p.middlewareEntries = append(p.middlewareEntries, namedMiddleware[C, Option, Payload, Result]{middleware: middleware})
+	}
+	p.rebuildMiddlewares()
 	return p
 }
 
@@ -175,84 +251,269 @@ func (p *Pipeline[C, Option, Payload, Result]) OnError(
 	return p
 }
 
+// WithLogger 设置管道日志
+// 通过 ExecuteStd 执行时，Hook 和中间件中的日志调用会转发给该 Logger
+func (p *Pipeline[C, Option, Payload, Result]) WithLogger(logger Logger) *Pipeline[C, Option, Payload, Result] {
+	p.logger = logger
+	return p
+}
+
+// ExecuteStd 使用标准 context.Context 执行管道
+// ctx 会与 WithLogger 设置的 Logger 组合为 pipe.Context；
+// 要求 C 为 pipe.Context（或其满足的接口），否则 ctx 本身需实现 C
+func (p *Pipeline[C, Option, Payload, Result]) ExecuteStd(
+	ctx context.Context,
+	payload *Payload,
+) (*Result, error) {
+	if p.logger == nil {
+		if c, ok := any(ctx).(C); ok {
+			return p.Execute(c, payload)
+		}
+	}
+
+	if c, ok := any(WrapContextWithLogger(ctx, p.logger)).(C); ok {
+		return p.Execute(c, payload)
+	}
+
+	if c, ok := any(ctx).(C); ok {
+		return p.Execute(c, payload)
+	}
+
+	return nil, fmt.Errorf("%w: pipeline '%s' expects %v",
+		ErrIncompatibleContext, p.Name, reflect.TypeOf((*C)(nil)).Elem())
+}
+
 // Execute 执行管道
 func (p *Pipeline[C, Option, Payload, Result]) Execute(
 	ctx C,
 	payload *Payload,
 ) (*Result, error) {
+	return p.execute(ctx, payload, false, nil)
+}
+
+// execute 执行管道，accepted 表示关闭前已接受的异步执行，stream 为流式执行的更新通道（可为 nil）
+func (p *Pipeline[C, Option, Payload, Result]) execute(
+	ctx C,
+	payload *Payload,
+	accepted bool,
+	stream *resultStream[Result],
+) (*Result, error) {
+	// 首次执行时运行初始化钩子
+	if err := p.init(ctx, accepted); err != nil {
+		return nil, err
+	}
+
+	// 解析本次执行的 Option
+	option, err := p.resolveOption(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// 取出预热资源，清理函数执行完后归还
+	var data dataMap
+	checkin, err := p.checkoutStandby(ctx, &data)
+	if err != nil {
+		return nil, err
+	}
+	defer checkin()
+
 	// 初始化 Result
 	var result Result
 
 	// 创建执行统计
 	stats := NewExecutionStats(p.Name)
+	stats.Labels = p.Labels()
+	if !p.noHookStats {
+		stats.HookStats = make([]HookStat, 0, len(p.hooks))
+	}
+	stats.Seed = p.executionSeed(ctx)
+	stats.Sampled = p.sampled()
 	stats.MarkStart()
 
+	// 事件记录器（未配置事件存储时为 nil）
+	journal := p.newJournal(ctx, stats)
+	journal.record(ExecutionEvent{
+		Type: EventExecutionStarted,
+		Data: encodeEvent(journal, p.payloadCodec, payload),
+		Seed: stats.Seed,
+		At:   stats.StartTime,
+	})
+
 	// 初始化 PipeContext
 	pipeCtx := &PipeContext[Option, Payload, Result]{
 		Name:    p.Name,
-		Option:  p.option, // 指针传递，避免大结构体拷贝
+		Option:  option, // 指针传递，避免大结构体拷贝
 		Payload: payload,
-		Result:  &result,              // 指针传递，允许 Hook 修改
-		data:    make(map[string]any), // 初始化中间状态
+		Result:  &result, // 指针传递，允许 Hook 修改
+		state: &sharedState{ // 初始化中间状态
+			data:       data,
+			events:     p.bindEvents(ctx),
+			classifier: p.classifier,
+			journal:    journal,
+			coverage:   p.coverage,
+			redacted:   p.redactedKeys,
+			clock:      p.executionClock(ctx),
+			seed:       stats.Seed,
+		},
 		stats:   stats,
+		reducer: p.reducer,
+		stream:  stream,
+	}
+
+	// 并发共享数据存储接管已写入的预热资源
+	if p.concurrentData {
+		pipeCtx.state.shards = newShardedData(&pipeCtx.state.data)
+		pipeCtx.state.data = dataMap{}
 	}
 
+	// 严格模式下，清理完成后的写入视为错误
+	if p.strictData {
+		pipeCtx.state.strict = newStrictData(p.Name)
+		pipeCtx.scope = &dataScope{name: p.Name}
+		defer pipeCtx.state.strict.complete()
+	}
+
+	// 管道结束后（包括 panic）执行 Hook 注册的清理函数
+	defer p.cleanup(ctx, pipeCtx)
+
 	// 执行 BeforeExecute 钩子
 	for _, fn := range p.beforeExecute {
 		fn(ctx, pipeCtx)
 	}
 
-	var finalErr error
+	// 校验 Payload，失败时不执行任何 Hook
+	finalErr := p.validatePayload(ctx, payload)
+
+	// 登记覆盖拓扑（未配置覆盖收集器时为空操作）
+	p.registerCoverage()
 
-	// 执行所有 Hook
-	for i, hook := range p.hooks {
+	// 执行所有 Hook（满足条件时走快速路径）
+	hooks := p.hooks
+	if finalErr == nil && p.fastPath() {
+		finalErr = p.runPlainHooks(ctx, pipeCtx)
+		hooks = nil
+	}
+	var hookStat HookStat // 复用同一个变量：runHook 返回后不会再有写入，记录时按值复制
+	for i, hook := range hooks {
 		// 检查是否中断
-		if pipeCtx.IsAborted() {
+		if finalErr != nil || pipeCtx.IsAborted() {
 			break
 		}
+		if hook.isSlot() {
+			continue
+		}
+
+		name := p.hookName(hook)
 
 		// 记录 Hook 开始时间
-		hookStat := HookStat{
-			Name:      hook.Name,
+		hookStat = HookStat{
+			Name:      name,
 			Index:     i,
+			Stage:     hook.stage,
 			StartTime: time.Now(),
 		}
 
-		// 应用中间件
-		handler := hook.Handler
-		if len(p.middlewares) > 0 {
-			handler = applyMiddlewares(handler, p.middlewares)
+		// Option 关闭了该 Hook，或剩余时间不足（按截止时间策略跳过或失败）
+		skip := hook.skipIf != nil && hook.skipIf(pipeCtx.Option)
+		var err error
+		if !skip {
+			skip, err = p.checkDeadline(ctx, hook, payload)
+		}
+		if skip {
+			hookStat.Skipped = true
+			hookStat.EndTime = hookStat.StartTime
+			p.addHookStat(stats, hookStat)
+			journal.hookFinished(hookStat)
+			continue
+		}
+
+		pipeCtx.setCurrentHook(name, i, hook.stage)
+		journal.record(ExecutionEvent{Type: EventHookStarted, Hook: name, Index: i, Stage: hook.stage, At: hookStat.StartTime})
+
+		// 上下文已取消（调用方取消、兄弟分支失败或中断）时不再启动后续 Hook
+		if err == nil {
+			err = ctx.Err()
 		}
 
 		// 执行 Hook
-		err := handler(ctx, pipeCtx)
+		if err == nil {
+			if stats.Sampled {
+				hookStat.Profile = &HookProfile{}
+			}
+			err = p.runHook(ctx, pipeCtx, hook, payload, &hookStat)
+			if !hookStat.Skipped {
+				p.coverage.hitHook(p.Name, name)
+				if hook.deprecated != nil {
+					hookStat.Deprecated = true
+					warnDeprecated(ctx, p.Name, name, hook.deprecated)
+				}
+			}
+		}
 
 		// 记录 Hook 结束时间
 		hookStat.EndTime = time.Now()
 		hookStat.Duration = hookStat.EndTime.Sub(hookStat.StartTime)
 		hookStat.Error = err
-		stats.AddHookStat(hookStat)
+		if err != nil {
+			hookStat.Class = pipeCtx.ClassifyError(err)
+			hookStat.Cancelled = ctx.Err() != nil
+		}
+		hookStat.Panicked = isPanic(err) || isPanic(hookStat.Cause)
+		hookStat.Variant = pipeCtx.CurrentVariant()
+		hookStat.Fields = pipeCtx.LogFields()
+		p.addHookStat(stats, hookStat)
+		journal.hookFinished(hookStat)
+		if err == nil && !hookStat.Skipped {
+			p.history(hook).observe(hookStat.Duration)
+		}
+		if !hookStat.Skipped {
+			hook.complete(ctx, pipeCtx, err)
+		}
 
 		// 处理错误
 		if err != nil {
 			// 调用错误处理钩子
 			for _, errFn := range p.onError {
-				errFn(ctx, hook.Name, err)
+				errFn(ctx, name, withFields(err, hookStat.Fields))
 			}
 
-			// 如果设置了 SkipOnError，则跳过错误继续执行
-			if hook.SkipOnError {
+			// 如果设置了 SkipOnError（或错误处理函数决定继续），则跳过错误继续执行
+			if p.skips(hook, err) {
 				continue
 			}
 
 			// 否则中断执行并返回错误
-			finalErr = newPipeError(p.Name, hook.Name, i, err)
+			finalErr = p.hookError(pipeCtx, hook, name, i, err, hookStat.Class, hookStat.Fields)
 			break
 		}
 	}
 
+	// 校验结果（中断的执行不校验）
+	if finalErr == nil && !pipeCtx.IsAborted() {
+		finalErr = p.validateResult(ctx, pipeCtx.Result)
+	}
+
 	// 标记执行结束
 	stats.MarkEnd(finalErr)
+	if finalErr != nil {
+		stats.ErrorClass = pipeCtx.ClassifyError(finalErr)
+		stats.Severity = SeverityOf(finalErr, stats.ErrorClass)
+		stats.Cancelled = ctx.Err() != nil
+	}
+
+	for _, sink := range p.statsSinks {
+		sink.Record(stats)
+	}
+	finished := ExecutionEvent{
+		Type:     EventExecutionFinished,
+		Duration: stats.TotalDuration,
+		Err:      errString(finalErr),
+		At:       stats.EndTime,
+	}
+	if finalErr == nil {
+		finished.Data = encodeEvent(journal, p.resultCodec, pipeCtx.Result)
+	}
+	journal.record(finished)
 
 	// 执行 AfterExecute 钩子
 	for _, fn := range p.afterExecute {
@@ -260,8 +521,158 @@ func (p *Pipeline[C, Option, Payload, Result]) Execute(
 	}
 
 	if finalErr != nil {
+		var panicErr *PanicError
+		if p.panicRethrow && errors.As(finalErr, &panicErr) {
+			panic(panicErr)
+		}
 		return nil, finalErr
 	}
 
 	return pipeCtx.Result, nil
 }
+
+// hookError 构造中断执行的 Hook 错误
+func (p *Pipeline[C, Option, Payload, Result]) hookError(
+	pipeCtx *PipeContext[Option, Payload, Result],
+	hook *Hook[C, Option, Payload, Result],
+	name string,
+	index int,
+	err error,
+	class ErrorClass,
+	fields map[string]any,
+) *PipeError {
+	pipeErr := newPipeError(p.Name, name, index, err)
+	pipeErr.Fields = fields
+	pipeErr.Class = class
+	pipeErr.Severity = SeverityOf(err, pipeErr.Class)
+	pipeErr.Code = pipeCtx.errorCode(err, hook.ErrorCode, pipeErr.Class)
+	pipeErr.Retryable = pipeCtx.ShouldRetry(err)
+	p.errorCapture.capture(pipeCtx.state, *pipeCtx.Result, pipeErr)
+	return pipeErr
+}
+
+// addHookStat 记录 Hook 统计（WithoutStats 时忽略）
+func (p *Pipeline[C, Option, Payload, Result]) addHookStat(stats *ExecutionStats, stat HookStat) {
+	if !p.noHookStats {
+		stats.AddHookStat(stat)
+	}
+}
+
+// runHook 应用中间件并执行单个 Hook
+// Once Hook 在管道实例内最多执行一次，之后记录为跳过
+func (p *Pipeline[C, Option, Payload, Result]) runHook(
+	ctx C,
+	pipeCtx *PipeContext[Option, Payload, Result],
+	hook *Hook[C, Option, Payload, Result],
+	payload *Payload,
+	hookStat *HookStat,
+) error {
+	timeout := p.hookTimeout(hook)
+	middlewares := p.hookMiddlewares(hook)
+
+	// 没有任何包装时直接调用，Hook 本体耗时即调用耗时
+	if hookStat.Profile == nil && len(middlewares) == 0 && !p.wrapsHook(hook, timeout) {
+		start := time.Now()
+		err := p.callHook(ctx, pipeCtx, hook, hook.Handler, hookStat)
+		if !hookStat.Skipped {
+			hookStat.Handler = time.Since(start)
+		}
+		return err
+	}
+
+	// 在最内层记录 Hook 本体耗时；采样执行还在每层包装外放置探针
+	handlerTime := new(atomic.Int64)
+	handler := timed(handlerTime, hook.Handler)
+	defer func() { hookStat.Handler = time.Duration(handlerTime.Load()) }()
+	var prof *profiler
+	if hookStat.Profile != nil {
+		prof = &profiler{names: []string{""}, totals: []*atomic.Int64{handlerTime}}
+		defer func() { hookStat.Profile = prof.profile() }()
+	}
+
+	// 应用中间件
+	if p.panicIsolation {
+		handler = isolatePanics(handler)
+	}
+	if timeout > 0 {
+		handler = TimeoutHandler(handler, timeout, 0)
+	}
+	if p.immutablePayload {
+		handler = immutablePayload(handler, payload)
+	}
+	if prof != nil {
+		if p.panicIsolation || timeout > 0 || p.immutablePayload {
+			handler = probe(prof, pipelineLayer, handler)
+		}
+		handler = profiledMiddlewares(prof, handler, p.hookMiddlewareChain(hook))
+	} else if len(middlewares) > 0 {
+		handler = applyMiddlewares(handler, middlewares)
+	}
+	if p.panicRecovery {
+		handler = recoverPanics(handler)
+	}
+
+	// 取消监控在降级处理之内：被放弃的 goroutine 不会再写入 hookStat
+	if p.cancelGrace > 0 {
+		handler = cancelGuard(handler, p.cancelGrace)
+	}
+	if hook.fallback != nil {
+		handler = withFallback(handler, hook.fallback, hookStat)
+	}
+	if hook.errorHandle != nil {
+		handler = handleErrors(handler, hook.errorHandle)
+	}
+	if hook.shadow != nil {
+		handler = p.withShadow(handler, hook.shadow)
+	}
+
+	return p.callHook(ctx, pipeCtx, hook, handler, hookStat)
+}
+
+// wrapsHook 除中间件外，是否还有需要包装 Hook 的配置
+func (p *Pipeline[C, Option, Payload, Result]) wrapsHook(hook *Hook[C, Option, Payload, Result], timeout time.Duration) bool {
+	return timeout > 0 || p.panicIsolation || p.immutablePayload || p.panicRecovery || p.cancelGrace > 0 ||
+		hook.fallback != nil || hook.errorHandle != nil || hook.shadow != nil
+}
+
+// callHook 调用包装后的 handler，Once Hook 已执行过时记录为跳过
+func (p *Pipeline[C, Option, Payload, Result]) callHook(
+	ctx C,
+	pipeCtx *PipeContext[Option, Payload, Result],
+	hook *Hook[C, Option, Payload, Result],
+	handler HookHandler[C, Option, Payload, Result],
+	hookStat *HookStat,
+) error {
+	if hook.once == nil {
+		return handler(ctx, pipeCtx)
+	}
+
+	var err error
+	hookStat.Skipped = true
+	hook.once.Do(func() {
+		hookStat.Skipped = false
+		err = handler(ctx, pipeCtx)
+	})
+	return err
+}
+
+// withFallback 主处理失败时执行降级处理，两者都失败时返回合并后的错误
+func withFallback[C Context, Option any, Payload any, Result any](
+	primary HookHandler[C, Option, Payload, Result],
+	fallback HookHandler[C, Option, Payload, Result],
+	hookStat *HookStat,
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+		err := primary(ctx, pipeCtx)
+		if err == nil {
+			return nil
+		}
+
+		hookStat.Fallback = true
+		hookStat.Cause = err
+		if fbErr := fallback(ctx, pipeCtx); fbErr != nil {
+			return errors.Join(err, fbErr)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,272 @@
+package pipelinetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// DefaultFuzzTimeout 单次模糊测试执行的默认超时
+const DefaultFuzzTimeout = 5 * time.Second
+
+// maxShrinkChecks 缩小失败输入时最多执行管道的次数
+const maxShrinkChecks = 1000
+
+// Invariant 对单次执行结果的断言，返回非 nil 表示违反
+type Invariant[Payload any, Result any] func(payload *Payload, result *Result, err error) error
+
+// FuzzConfig 模糊测试配置
+type FuzzConfig[C pipe.Context, Payload any, Result any] struct {
+	Seeds       []*Payload                          // 种子 Payload（以 JSON 编码加入语料）
+	Corpus      [][]byte                            // 原始种子语料（配合自定义 Decode 使用）
+	Decode      func(data []byte) (*Payload, error) // 语料解码（默认 JSON，解码失败的输入被跳过）
+	Context     func(ctx context.Context) C         // 构造执行上下文（默认使用 ExecuteStd）
+	Timeout     time.Duration                       // 单次执行超时（默认 DefaultFuzzTimeout）
+	Invariants  []Invariant[Payload, Result]        // Result 不变式
+	FailOnError bool                                // 管道返回的任何错误都视为失败（默认只有 panic 失败）
+}
+
+// Fuzz 将管道接入 go test -fuzz：解码语料为 Payload 并执行管道，检查
+// 不发生 panic（包括并行分支中被转换为 PanicError 的 panic）、不遗留僵尸 Hook goroutine、
+// 满足全部不变式。失败时将 Payload 结构化缩小（见 Shrink）后一并报告
+//
+//	func FuzzOrder(f *testing.F) {
+//	    pipelinetest.Fuzz(f, newPipeline(), pipelinetest.FuzzConfig[pipe.Context, Order, Summary]{
+//	        Seeds: []*Order{{ID: "o-1"}},
+//	    })
+//	}
+func Fuzz[C pipe.Context, Option any, Payload any, Result any](
+	f *testing.F,
+	pipeline *pipe.Pipeline[C, Option, Payload, Result],
+	cfg FuzzConfig[C, Payload, Result],
+) {
+	f.Helper()
+
+	for _, seed := range cfg.Seeds {
+		data, err := json.Marshal(seed)
+		if err != nil {
+			f.Fatalf("encode seed: %v", err)
+		}
+		f.Add(data)
+	}
+	for _, data := range cfg.Corpus {
+		f.Add(data)
+	}
+
+	decode := cfg.Decode
+	if decode == nil {
+		decode = func(data []byte) (*Payload, error) {
+			payload := new(Payload)
+			if err := json.Unmarshal(data, payload); err != nil {
+				return nil, err
+			}
+			return payload, nil
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		payload, err := decode(data)
+		if err != nil || payload == nil {
+			t.Skip("undecodable input")
+		}
+
+		check := func(p *Payload) error { return fuzzCheck(pipeline, cfg, p) }
+		if err := check(payload); err != nil {
+			shrunk := Shrink(payload, func(p *Payload) bool { return check(p) != nil })
+			t.Fatalf("pipeline %s: %v\npayload: %s\nshrunk payload: %s (%v)",
+				pipeline.Name, err, encode(payload), encode(shrunk), check(shrunk))
+		}
+	})
+}
+
+// fuzzCheck 在 Payload 的深拷贝上执行一次管道并检查不变式
+func fuzzCheck[C pipe.Context, Option any, Payload any, Result any](
+	pipeline *pipe.Pipeline[C, Option, Payload, Result],
+	cfg FuzzConfig[C, Payload, Result],
+	payload *Payload,
+) (err error) {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultFuzzTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	zombies := pipeline.Zombies()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %w", pipe.NewPanicError(r))
+		}
+	}()
+
+	input := pipe.DeepCopy(payload)
+	var (
+		result  *Result
+		execErr error
+	)
+	if cfg.Context != nil {
+		result, execErr = pipeline.Execute(cfg.Context(ctx), input)
+	} else {
+		result, execErr = pipeline.ExecuteStd(ctx, input)
+	}
+
+	var panicErr *pipe.PanicError
+	switch {
+	case errors.As(execErr, &panicErr):
+		return fmt.Errorf("panic in hook: %w", execErr)
+	case execErr != nil && cfg.FailOnError:
+		return fmt.Errorf("pipeline error: %w", execErr)
+	case pipeline.Zombies() > zombies:
+		return fmt.Errorf("%d zombie hook goroutine(s) left running", pipeline.Zombies()-zombies)
+	}
+
+	for _, invariant := range cfg.Invariants {
+		if err := invariant(payload, result, execErr); err != nil {
+			return fmt.Errorf("invariant violated: %w", err)
+		}
+	}
+	return nil
+}
+
+// Shrink 在 fails 保持为 true 的前提下结构化地缩小 payload：
+// 逐个将导出字段置零、删除切片元素和 map 键、截短字符串，直到无法继续缩小
+// fails 接收的 Payload 可能被修改，应在副本上执行管道
+func Shrink[Payload any](payload *Payload, fails func(p *Payload) bool) *Payload {
+	current := pipe.DeepCopy(payload)
+	checks := 0
+	check := func() bool {
+		if checks >= maxShrinkChecks {
+			return false
+		}
+		checks++
+		return fails(pipe.DeepCopy(current))
+	}
+
+	for shrinkValue(reflect.ValueOf(current).Elem(), check) {
+		// 持续缩小，直到一轮中没有任何进展
+	}
+	return current
+}
+
+// shrinkValue 尝试缩小 v，check 返回 true 表示缩小后仍然失败；有任何缩小时返回 true
+func shrinkValue(v reflect.Value, check func() bool) bool {
+	if !v.CanSet() {
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		progress := false
+		for i := range v.NumField() {
+			if shrinkValue(v.Field(i), check) {
+				progress = true
+			}
+		}
+		return progress
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return false
+		}
+		return trySet(v, reflect.Zero(v.Type()), check) || shrinkValue(v.Elem(), check)
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return false
+		}
+		if trySet(v, reflect.Zero(v.Type()), check) {
+			return true
+		}
+		progress := false
+		for i := v.Len() - 1; i >= 0; i-- {
+			removed := reflect.MakeSlice(v.Type(), 0, v.Len()-1)
+			removed = reflect.AppendSlice(removed, v.Slice(0, i))
+			removed = reflect.AppendSlice(removed, v.Slice(i+1, v.Len()))
+			if trySet(v, removed, check) {
+				progress = true
+			}
+		}
+		for i := range v.Len() {
+			if shrinkValue(v.Index(i), check) {
+				progress = true
+			}
+		}
+		return progress
+
+	case reflect.Map:
+		if v.IsNil() {
+			return false
+		}
+		if trySet(v, reflect.Zero(v.Type()), check) {
+			return true
+		}
+		progress := false
+		for _, key := range v.MapKeys() {
+			old := v.MapIndex(key)
+			v.SetMapIndex(key, reflect.Value{})
+			if check() {
+				progress = true
+				continue
+			}
+			v.SetMapIndex(key, old)
+		}
+		return progress
+
+	case reflect.String:
+		s := v.String()
+		if s == "" {
+			return false
+		}
+		if trySet(v, reflect.Zero(v.Type()), check) {
+			return true
+		}
+		progress := false
+		for len(s) > 1 {
+			half := reflect.New(v.Type()).Elem()
+			half.SetString(s[:len(s)/2])
+			if !trySet(v, half, check) {
+				break
+			}
+			s = v.String()
+			progress = true
+		}
+		return progress
+
+	default:
+		if v.IsZero() {
+			return false
+		}
+		return trySet(v, reflect.Zero(v.Type()), check)
+	}
+}
+
+// trySet 将 v 设为 value，缩小后不再失败时恢复原值
+func trySet(v, value reflect.Value, check func() bool) bool {
+	if v.IsZero() && value.IsZero() {
+		return false
+	}
+
+	old := reflect.New(v.Type()).Elem()
+	old.Set(v)
+	v.Set(value)
+	if check() {
+		return true
+	}
+	v.Set(old)
+	return false
+}
+
+// encode 以 JSON 输出 Payload（失败时退回 %+v）
+func encode(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	return string(data)
+}
@@ -0,0 +1,128 @@
+package pipelinetest
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+type fuzzPayload struct {
+	Name  string
+	Items []int
+	Meta  *fuzzMeta
+	Tags  map[string]string
+}
+
+type fuzzMeta struct {
+	Note string
+}
+
+// TestShrink 测试失败 Payload 的结构化缩小
+func TestShrink(t *testing.T) {
+	payload := &fuzzPayload{
+		Name:  "a long name",
+		Items: []int{1, 2, 13, 4},
+		Meta:  &fuzzMeta{Note: "note"},
+		Tags:  map[string]string{"a": "1", "b": "2"},
+	}
+
+	shrunk := Shrink(payload, func(p *fuzzPayload) bool {
+		return slices.Contains(p.Items, 13)
+	})
+
+	expected := &fuzzPayload{Items: []int{13}}
+	if !reflect.DeepEqual(shrunk, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, shrunk)
+	}
+	if payload.Name != "a long name" || len(payload.Items) != 4 {
+		t.Errorf("Shrink must not modify the original payload, got %+v", payload)
+	}
+
+	shrunk = Shrink(payload, func(p *fuzzPayload) bool {
+		return strings.HasPrefix(p.Name, "a")
+	})
+	if shrunk.Name != "a" || shrunk.Items != nil || shrunk.Meta != nil {
+		t.Errorf("Expected name shrunk to \"a\", got %+v", shrunk)
+	}
+}
+
+func newFuzzPipeline(hook pipe.HookHandler[pipe.Context, pipe.NoOption, fuzzPayload, order]) *pipe.SimplePipeline[fuzzPayload, order] {
+	return pipe.NewSimplePipeline[fuzzPayload, order]("fuzz").AddNamedHook("hook", hook)
+}
+
+// TestFuzzCheck 测试 panic、错误和不变式检查
+func TestFuzzCheck(t *testing.T) {
+	type config = FuzzConfig[pipe.Context, fuzzPayload, order]
+
+	panicking := newFuzzPipeline(func(ctx pipe.Context, pipeCtx *pipe.SimplePipeContext[fuzzPayload, order]) error {
+		_ = pipeCtx.Payload.Meta.Note // Meta 为 nil 时 panic
+		return nil
+	})
+	if err := fuzzCheck(panicking, config{}, &fuzzPayload{Meta: &fuzzMeta{}}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := fuzzCheck(panicking, config{}, &fuzzPayload{}); err == nil || !strings.Contains(err.Error(), "panic") {
+		t.Errorf("Expected panic error, got %v", err)
+	}
+
+	branchPanic := pipe.NewSimplePipeline[fuzzPayload, order]("fuzz").
+		AddHookWithOptions(pipe.Parallel[pipe.Context, pipe.NoOption, fuzzPayload, order]().
+			Branch(func(ctx pipe.Context, pipeCtx *pipe.SimplePipeContext[fuzzPayload, order]) error {
+				panic("boom")
+			}).
+			Build("fanout"))
+	if err := fuzzCheck(branchPanic, config{}, &fuzzPayload{}); err == nil || !strings.Contains(err.Error(), "panic in hook") {
+		t.Errorf("Expected panic in hook, got %v", err)
+	}
+
+	failing := newFuzzPipeline(func(ctx pipe.Context, pipeCtx *pipe.SimplePipeContext[fuzzPayload, order]) error {
+		return errors.New("invalid")
+	})
+	if err := fuzzCheck(failing, config{}, &fuzzPayload{}); err != nil {
+		t.Errorf("Pipeline errors should pass by default, got %v", err)
+	}
+	if err := fuzzCheck(failing, config{FailOnError: true}, &fuzzPayload{}); err == nil {
+		t.Error("Expected error with FailOnError")
+	}
+
+	counting := newFuzzPipeline(func(ctx pipe.Context, pipeCtx *pipe.SimplePipeContext[fuzzPayload, order]) error {
+		pipeCtx.Result.Items = append(pipeCtx.Result.Items, pipeCtx.Payload.Name)
+		return nil
+	})
+	invariant := func(payload *fuzzPayload, result *order, err error) error {
+		if len(result.Items) != 1 || result.Items[0] != payload.Name {
+			return errors.New("name not copied")
+		}
+		if payload.Name == "bad" {
+			return errors.New("bad name")
+		}
+		return nil
+	}
+	cfg := config{Invariants: []Invariant[fuzzPayload, order]{invariant}}
+	if err := fuzzCheck(counting, cfg, &fuzzPayload{Name: "ok"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := fuzzCheck(counting, cfg, &fuzzPayload{Name: "bad"}); err == nil || !strings.Contains(err.Error(), "invariant violated") {
+		t.Errorf("Expected invariant violation, got %v", err)
+	}
+}
+
+// FuzzOrderPipeline 以种子语料运行模糊测试（go test 时只执行种子）
+func FuzzOrderPipeline(f *testing.F) {
+	Fuzz(f, newOrderPipeline(), FuzzConfig[pipe.Context, order, summary]{
+		Seeds:  []*order{{ID: "o-1", Items: []string{"x"}}, {}},
+		Corpus: [][]byte{[]byte(`{"ID":"o-2","Items":["a","b"]}`), []byte("not json")},
+		Invariants: []Invariant[order, summary]{
+			func(payload *order, result *summary, err error) error {
+				if err == nil && result.Total != len(payload.Items) {
+					return errors.New("total mismatch")
+				}
+				return nil
+			},
+		},
+	})
+}
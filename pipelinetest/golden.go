@@ -0,0 +1,198 @@
+// Package pipelinetest 提供管道的测试辅助工具。
+//
+// Golden 执行管道并将 Result（可选附带共享数据）确定性地序列化为 JSON，与 golden 文件比较，
+// 不一致时输出逐字段的差异（WithDiff 可忽略字段或设置容差）；以 -update 运行测试时改为写入 golden 文件：
+//
+//	go test ./... -update
+//
+// Replay 用当前管道重放事件存储中记录的生产执行，Result 与记录不一致时测试失败。
+package pipelinetest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+func init() {
+	// 其他包已定义同名 flag 时复用
+	if flag.Lookup("update") == nil {
+		flag.Bool("update", false, "update golden files")
+	}
+}
+
+// updating 是否以 -update 运行（更新 golden 文件）
+func updating() bool {
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+	b, _ := getter.Get().(bool)
+	return b
+}
+
+// GoldenOption golden 测试选项
+type GoldenOption func(*goldenConfig)
+
+// goldenConfig golden 测试配置
+type goldenConfig struct {
+	dataKeys []string          // 写入 golden 文件的共享数据键
+	diffOpts []pipe.DiffOption // 与 golden 文件对比时的选项
+}
+
+// WithData 将指定的共享数据一并写入 golden 文件（未设置的键记为 null）
+func WithData(keys ...string) GoldenOption {
+	return func(c *goldenConfig) {
+		c.dataKeys = append(c.dataKeys, keys...)
+	}
+}
+
+// WithDiff 按解码后的 JSON 结构与 golden 文件对比，并应用对比选项（如忽略时间戳、浮点容差）
+// 路径使用 JSON 字段名，如 pipe.IgnoreFields("result.created_at")
+func WithDiff(opts ...pipe.DiffOption) GoldenOption {
+	return func(c *goldenConfig) {
+		c.diffOpts = append(c.diffOpts, opts...)
+	}
+}
+
+// Golden 以 context.Background() 执行管道，将结果与 golden 文件比较
+// 管道的上下文类型不是 pipe.Context 时使用 GoldenContext
+func Golden[C pipe.Context, Option any, Payload any, Result any](
+	t testing.TB,
+	pipeline *pipe.Pipeline[C, Option, Payload, Result],
+	payload *Payload,
+	path string,
+	opts ...GoldenOption,
+) {
+	t.Helper()
+	golden(t, pipeline, payload, path, opts, func() (*Result, error) {
+		return pipeline.ExecuteStd(context.Background(), payload)
+	})
+}
+
+// GoldenContext 以指定上下文执行管道，将结果与 golden 文件比较
+func GoldenContext[C pipe.Context, Option any, Payload any, Result any](
+	t testing.TB,
+	pipeline *pipe.Pipeline[C, Option, Payload, Result],
+	ctx C,
+	payload *Payload,
+	path string,
+	opts ...GoldenOption,
+) {
+	t.Helper()
+	golden(t, pipeline, payload, path, opts, func() (*Result, error) {
+		return pipeline.Execute(ctx, payload)
+	})
+}
+
+// golden 执行、序列化并比较或更新 golden 文件
+func golden[C pipe.Context, Option any, Payload any, Result any](
+	t testing.TB,
+	pipeline *pipe.Pipeline[C, Option, Payload, Result],
+	payload *Payload,
+	path string,
+	opts []GoldenOption,
+	execute func() (*Result, error),
+) {
+	t.Helper()
+
+	var cfg goldenConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var data map[string]any
+	if len(cfg.dataKeys) > 0 {
+		done := captureData(pipeline, payload, cfg.dataKeys, &data)
+		defer done()
+	}
+
+	result, err := execute()
+	if err != nil {
+		t.Fatalf("pipeline %s: %v", pipeline.Name, err)
+	}
+
+	var value any = result
+	if len(cfg.dataKeys) > 0 {
+		value = struct {
+			Result *Result        `json:"result"`
+			Data   map[string]any `json:"data"`
+		}{result, data}
+	}
+
+	got, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal result: %v", err)
+	}
+	got = append(got, '\n')
+
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+	if bytes.Equal(bytes.TrimSpace(got), bytes.TrimSpace(want)) {
+		return
+	}
+
+	var wantValue, gotValue any
+	if json.Unmarshal(want, &wantValue) != nil || json.Unmarshal(got, &gotValue) != nil {
+		t.Errorf("result does not match %s (run with -update to accept)\n--- want\n%s\n--- got\n%s", path, want, got)
+		return
+	}
+	diff := pipe.DiffResults(&wantValue, &gotValue, cfg.diffOpts...)
+	if !diff.Equal() {
+		t.Errorf("result does not match %s (run with -update to accept)\n%s\n--- want\n%s\n--- got\n%s", path, diff, want, got)
+	}
+}
+
+// captures 进行中的共享数据采集（Payload 指针 -> 采集函数）
+var captures sync.Map
+
+// hooked 已注册采集回调的管道
+var hooked sync.Map
+
+// captureData 在本次执行结束时读取指定的共享数据，返回的函数用于注销采集
+// 以 Payload 指针识别执行，采集回调每个管道只注册一次
+func captureData[C pipe.Context, Option any, Payload any, Result any](
+	pipeline *pipe.Pipeline[C, Option, Payload, Result],
+	payload *Payload,
+	keys []string,
+	data *map[string]any,
+) func() {
+	if _, loaded := hooked.LoadOrStore(pipeline, true); !loaded {
+		pipeline.OnAfterExecute(func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result], err error) {
+			if fn, ok := captures.Load(pipeCtx.Payload); ok {
+				fn.(func(*pipe.PipeContext[Option, Payload, Result]))(pipeCtx)
+			}
+		})
+	}
+
+	captures.Store(payload, func(pipeCtx *pipe.PipeContext[Option, Payload, Result]) {
+		*data = make(map[string]any, len(keys))
+		for _, key := range keys {
+			(*data)[key], _ = pipeCtx.Get(key)
+		}
+	})
+	return func() { captures.Delete(payload) }
+}
@@ -0,0 +1,147 @@
+package pipelinetest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+type order struct {
+	ID    string
+	Items []string
+}
+
+type summary struct {
+	ID    string   `json:"id"`
+	Items []string `json:"items"`
+	Total int      `json:"total"`
+}
+
+func newOrderPipeline() *pipe.SimplePipeline[order, summary] {
+	return pipe.NewSimplePipeline[order, summary]("order").
+		AddNamedHook("summarize", func(ctx pipe.Context, pipeCtx *pipe.SimplePipeContext[order, summary]) error {
+			pipeCtx.Result.ID = pipeCtx.Payload.ID
+			pipeCtx.Result.Items = pipeCtx.Payload.Items
+			pipeCtx.Result.Total = len(pipeCtx.Payload.Items)
+			pipeCtx.Set("tags", map[string]int{"b": 2, "a": 1})
+			return nil
+		})
+}
+
+// recorder 记录失败而不终止外层测试
+type recorder struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failed = true
+	r.msg = format
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+// run 在独立 goroutine 中执行，使 Fatalf 可以终止
+func run(t *testing.T, fn func(tb testing.TB)) *recorder {
+	r := &recorder{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(r)
+	}()
+	<-done
+	return r
+}
+
+func setUpdate(t *testing.T, v string) {
+	t.Helper()
+	if err := flag.Set("update", v); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = flag.Set("update", "false") })
+}
+
+// TestGolden 测试 golden 文件的写入与比较
+func TestGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "case1.json")
+	payload := &order{ID: "o-1", Items: []string{"x", "y"}}
+
+	if r := run(t, func(tb testing.TB) { Golden(tb, newOrderPipeline(), payload, path) }); !r.failed {
+		t.Fatal("expected failure for missing golden file")
+	}
+
+	setUpdate(t, "true")
+	Golden(t, newOrderPipeline(), payload, path)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden file not written: %v", err)
+	}
+	if !strings.Contains(string(content), `"total": 2`) {
+		t.Errorf("unexpected golden content:\n%s", content)
+	}
+
+	setUpdate(t, "false")
+	Golden(t, newOrderPipeline(), payload, path)
+
+	changed := &order{ID: "o-1", Items: []string{"x"}}
+	if r := run(t, func(tb testing.TB) { Golden(tb, newOrderPipeline(), changed, path) }); !r.failed {
+		t.Error("expected mismatch to fail")
+	}
+}
+
+// TestGoldenWithData 测试共享数据写入 golden 文件（键有序，输出稳定）
+func TestGoldenWithData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "case2.json")
+	pipeline := newOrderPipeline()
+
+	setUpdate(t, "true")
+	Golden(t, pipeline, &order{ID: "o-2"}, path, WithData("tags", "missing"))
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"result": {`, `"missing": null`, "\"a\": 1,\n      \"b\": 2"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("golden file missing %q:\n%s", want, content)
+		}
+	}
+
+	setUpdate(t, "false")
+	for range 3 {
+		Golden(t, pipeline, &order{ID: "o-2"}, path, WithData("tags", "missing"))
+	}
+}
+
+// TestGoldenWithDiff 测试按结构对比并忽略指定字段
+func TestGoldenWithDiff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "case3.json")
+
+	setUpdate(t, "true")
+	Golden(t, newOrderPipeline(), &order{ID: "o-3", Items: []string{"x"}}, path)
+	setUpdate(t, "false")
+
+	renamed := &order{ID: "o-4", Items: []string{"x"}}
+	if r := run(t, func(tb testing.TB) { Golden(tb, newOrderPipeline(), renamed, path) }); !r.failed {
+		t.Error("expected mismatch without ignored fields")
+	}
+	Golden(t, newOrderPipeline(), renamed, path, WithDiff(pipe.IgnoreFields("id")))
+
+	changed := &order{ID: "o-4", Items: []string{"y"}}
+	if r := run(t, func(tb testing.TB) {
+		Golden(tb, newOrderPipeline(), changed, path, WithDiff(pipe.IgnoreFields("id")))
+	}); !r.failed {
+		t.Error("expected mismatch on items")
+	}
+}
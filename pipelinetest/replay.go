@@ -0,0 +1,106 @@
+package pipelinetest
+
+import (
+	"context"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// Replay 从事件存储加载记录的执行，用当前管道重新执行并逐个比较 Result，返回重放的执行数量。
+// 适合在 CI 中作为重构 Hook 时的回归保护：
+//   - 记录时需配置 WithEventStore 和 WithCodecs，开始事件携带 Payload，成功的结束事件携带 Result；
+//   - 重放使用记录的随机种子和开始时间（见 pipe.ContextWithSeed、pipe.ContextWithClock）；
+//   - 原本成功的执行，重放失败或 Result 的差异超出 opts（忽略字段、容差）时测试失败；
+//   - 原本失败的执行，重放成功时测试失败；尚未结束的执行被跳过。
+//
+// query 选择要重放的执行（Pipeline 为空时使用管道名称，Types 被忽略）
+func Replay[C pipe.Context, Option any, Payload any, Result any](
+	t testing.TB,
+	pipeline *pipe.Pipeline[C, Option, Payload, Result],
+	store pipe.EventStore,
+	query pipe.EventQuery,
+	opts ...pipe.DiffOption,
+) int {
+	t.Helper()
+
+	payloadCodec, resultCodec := pipeline.PayloadCodec(), pipeline.ResultCodec()
+	if payloadCodec == nil || resultCodec == nil {
+		t.Fatalf("pipeline %s: replay requires WithCodecs", pipeline.Name)
+	}
+
+	if query.Pipeline == "" {
+		query.Pipeline = pipeline.Name
+	}
+	query.Types = []pipe.ExecutionEventType{pipe.EventExecutionStarted, pipe.EventExecutionFinished}
+	events, err := store.Query(context.Background(), query)
+	if err != nil {
+		t.Fatalf("query recorded executions: %v", err)
+	}
+
+	replayed := 0
+	for _, rec := range recordedExecutions(events) {
+		if rec.finished == nil {
+			continue
+		}
+		replayed++
+
+		payload, err := payloadCodec.Decode(rec.started.Data)
+		if err != nil {
+			t.Errorf("execution %s: decode payload: %v", rec.id, err)
+			continue
+		}
+
+		ctx := pipe.ContextWithSeed(context.Background(), rec.started.Seed)
+		ctx = pipe.ContextWithClock(ctx, pipe.FixedClock(rec.started.At))
+		result, err := pipeline.ExecuteStd(ctx, payload)
+
+		if rec.finished.Err != "" {
+			if err == nil {
+				t.Errorf("execution %s: recorded failure %q, replay succeeded", rec.id, rec.finished.Err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("execution %s: recorded success, replay failed: %v", rec.id, err)
+			continue
+		}
+
+		want, err := resultCodec.Decode(rec.finished.Data)
+		if err != nil {
+			t.Errorf("execution %s: decode result: %v", rec.id, err)
+			continue
+		}
+		if diff := pipe.DiffResults(want, result, opts...); !diff.Equal() {
+			t.Errorf("execution %s: result diverged from recording\n%s", rec.id, diff)
+		}
+	}
+	return replayed
+}
+
+// recordedExecution 单次执行的开始与结束事件
+type recordedExecution struct {
+	id       string
+	started  *pipe.ExecutionEvent
+	finished *pipe.ExecutionEvent
+}
+
+// recordedExecutions 按开始顺序整理带 Payload 的执行
+func recordedExecutions(events []pipe.ExecutionEvent) []*recordedExecution {
+	var order []*recordedExecution
+	byID := make(map[string]*recordedExecution)
+	for i := range events {
+		event := &events[i]
+		switch {
+		case event.Type == pipe.EventExecutionStarted && event.Data != nil:
+			rec := &recordedExecution{id: event.ExecutionID, started: event}
+			byID[event.ExecutionID] = rec
+			order = append(order, rec)
+		case event.Type == pipe.EventExecutionFinished:
+			if rec, ok := byID[event.ExecutionID]; ok {
+				rec.finished = event
+			}
+		}
+	}
+	return order
+}
@@ -0,0 +1,60 @@
+package pipelinetest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// newPricingPipeline 按 total 计算总数的管道，total 返回错误时执行失败
+func newPricingPipeline(total func(items []string) (int, error)) *pipe.SimplePipeline[order, summary] {
+	return pipe.NewSimplePipeline[order, summary]("pricing").
+		WithCodecs(pipe.NewJSONCodec[order](1), pipe.NewJSONCodec[summary](1)).
+		AddNamedHook("price", func(ctx pipe.Context, pipeCtx *pipe.SimplePipeContext[order, summary]) (err error) {
+			pipeCtx.Result.ID = pipeCtx.Payload.ID
+			pipeCtx.Result.Total, err = total(pipeCtx.Payload.Items)
+			return err
+		})
+}
+
+// count 商品数量，没有商品时失败
+func count(items []string) (int, error) {
+	if len(items) == 0 {
+		return 0, errors.New("empty order")
+	}
+	return len(items), nil
+}
+
+// TestReplay 测试用当前管道重放记录的执行
+func TestReplay(t *testing.T) {
+	store := pipe.NewMemoryEventStore()
+	recording := newPricingPipeline(count).WithEventStore(store)
+	for _, payload := range []*order{{ID: "o-1", Items: []string{"a"}}, {ID: "o-2", Items: []string{"a", "b"}}, {ID: "o-3"}} {
+		_, _ = recording.ExecuteStd(t.Context(), payload)
+	}
+
+	if n := Replay(t, newPricingPipeline(count), store, pipe.EventQuery{}); n != 3 {
+		t.Errorf("Expected 3 replayed executions, got %d", n)
+	}
+
+	doubled := newPricingPipeline(func(items []string) (int, error) {
+		n, err := count(items)
+		return 2 * n, err
+	})
+	r := run(t, func(tb testing.TB) { Replay(tb, doubled, store, pipe.EventQuery{}) })
+	if !r.failed || !strings.Contains(r.msg, "diverged") {
+		t.Errorf("Expected divergence failure, got %q", r.msg)
+	}
+	r = run(t, func(tb testing.TB) { Replay(tb, doubled, store, pipe.EventQuery{}, pipe.IgnoreFields("Total")) })
+	if r.failed {
+		t.Errorf("Expected ignored field to pass, got %q", r.msg)
+	}
+
+	lenient := newPricingPipeline(func(items []string) (int, error) { return len(items), nil })
+	r = run(t, func(tb testing.TB) { Replay(tb, lenient, store, pipe.EventQuery{}) })
+	if !r.failed || !strings.Contains(r.msg, "recorded failure") {
+		t.Errorf("Expected recorded failure mismatch, got %q", r.msg)
+	}
+}
@@ -0,0 +1,101 @@
+package pipeline
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// pipelineLayer 管道内置包装（超时、panic 隔离、只读 Payload）在剖析中的层名称
+const pipelineLayer = "pipeline"
+
+// HookProfile 采样执行中单个 Hook 的细粒度耗时（见 WithProfiling）
+type HookProfile struct {
+	Handler time.Duration // Hook 本体耗时（不含中间件和管道的包装）
+	Layers  []LayerTime   // 由外到内各层包装自身的耗时（不含内层）：中间件按名称，管道内置包装为 "pipeline"
+}
+
+// LayerTime 单层包装自身的耗时
+type LayerTime struct {
+	Name     string        // 中间件名称（未命名为 "<unnamed>"，阶段中间件为 "stage 阶段名"）
+	Duration time.Duration // 该层自身的累计耗时（重试等多次调用内层时累加）
+}
+
+// Overhead 各层包装自身耗时之和
+func (p *HookProfile) Overhead() time.Duration {
+	var total time.Duration
+	for _, layer := range p.Layers {
+		total += layer.Duration
+	}
+	return total
+}
+
+// WithProfiling 对比例为 rate（0~1）的执行做细粒度剖析：记录每个 Hook 在各层中间件与 Hook 本体中的耗时，
+// 写入 HookStat.Profile 并随执行统计交给 StatsSink（AggregateStats 按层累计）。
+// 未采样的执行没有额外开销；rate 超出范围时 panic
+func (p *Pipeline[C, Option, Payload, Result]) WithProfiling(rate float64) *Pipeline[C, Option, Payload, Result] {
+	if rate < 0 || rate > 1 {
+		panic(fmt.Sprintf("pipeline: profiling rate %v out of range [0, 1]", rate))
+	}
+	p.profileRate = rate
+	return p
+}
+
+// sampled 本次执行是否剖析
+func (p *Pipeline[C, Option, Payload, Result]) sampled() bool {
+	return p.profileRate > 0 && (p.profileRate >= 1 || rand.Float64() < p.profileRate)
+}
+
+// profiler 单个 Hook 的剖析探针：各层（含内层）的累计耗时，由内到外
+type profiler struct {
+	names  []string
+	totals []*atomic.Int64 // 超时放弃的 goroutine 可能在统计完成后仍然写入
+}
+
+// probe 记录该层（含内层）的累计耗时
+func probe[C Context, Option any, Payload any, Result any](
+	prof *profiler,
+	name string,
+	handler HookHandler[C, Option, Payload, Result],
+) HookHandler[C, Option, Payload, Result] {
+	total := new(atomic.Int64)
+	prof.names = append(prof.names, name)
+	prof.totals = append(prof.totals, total)
+	return timed(total, handler)
+}
+
+// timed 将每次调用 handler 的耗时累加到 total
+func timed[C Context, Option any, Payload any, Result any](
+	total *atomic.Int64,
+	handler HookHandler[C, Option, Payload, Result],
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+		start := time.Now()
+		defer func() { total.Add(int64(time.Since(start))) }()
+		return handler(ctx, pipeCtx)
+	}
+}
+
+// profile 按各层累计耗时之差计算每层自身的耗时
+func (prof *profiler) profile() *HookProfile {
+	profile := &HookProfile{Handler: time.Duration(prof.totals[0].Load())}
+	for i := len(prof.totals) - 1; i > 0; i-- {
+		self := time.Duration(prof.totals[i].Load() - prof.totals[i-1].Load())
+		profile.Layers = append(profile.Layers, LayerTime{Name: prof.names[i], Duration: max(self, 0)})
+	}
+	return profile
+}
+
+// profiledMiddlewares 逐层应用中间件，每层外包一个探针
+func profiledMiddlewares[C Context, Option any, Payload any, Result any](
+	prof *profiler,
+	handler HookHandler[C, Option, Payload, Result],
+	chain []namedMiddleware[C, Option, Payload, Result],
+) HookHandler[C, Option, Payload, Result] {
+	for _, entry := range slices.Backward(chain) {
+		handler = probe(prof, entry.displayName(), entry.middleware(handler))
+	}
+	return handler
+}
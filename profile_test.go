@@ -0,0 +1,106 @@
+package pipeline
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// sleepMiddleware 在调用内层之前休眠的测试中间件
+func sleepMiddleware(d time.Duration) Middleware[sylph.Context, TestOption, TestPayload, TestResult] {
+	return func(next HookHandler[sylph.Context, TestOption, TestPayload, TestResult]) HookHandler[sylph.Context, TestOption, TestPayload, TestResult] {
+		return func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			time.Sleep(d)
+			return next(ctx, pipeCtx)
+		}
+	}
+}
+
+// TestProfiling 测试采样执行按层记录中间件与 Hook 本体的耗时
+func TestProfiling(t *testing.T) {
+	agg := NewAggregateStats("profiled")
+	var stats *ExecutionStats
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("profiled").
+		UseNamed("slow", sleepMiddleware(20*time.Millisecond)).
+		UseNamed("fast", sleepMiddleware(0)).
+		AddNamedHook("work", func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}).
+		WithPanicIsolation().
+		WithProfiling(1)
+	pipeline.WithStatsSink(agg)
+	pipeline.OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+		stats = pipeCtx.Stats()
+	})
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !stats.Sampled {
+		t.Fatal("Expected execution to be sampled")
+	}
+	profile := stats.HookStats[0].Profile
+	if profile == nil {
+		t.Fatal("Expected hook profile")
+	}
+	if profile.Handler < 10*time.Millisecond || profile.Handler >= 20*time.Millisecond {
+		t.Errorf("Expected handler time around 10ms, got %v", profile.Handler)
+	}
+	if len(profile.Layers) != 3 || profile.Layers[0].Name != "slow" || profile.Layers[1].Name != "fast" || profile.Layers[2].Name != "pipeline" {
+		t.Fatalf("Expected layers [slow fast pipeline], got %+v", profile.Layers)
+	}
+	if profile.Layers[0].Duration < 20*time.Millisecond || profile.Layers[1].Duration >= 10*time.Millisecond {
+		t.Errorf("Expected slow layer >= 20ms and fast layer < 10ms, got %+v", profile.Layers)
+	}
+	if profile.Overhead() != profile.Layers[0].Duration+profile.Layers[1].Duration+profile.Layers[2].Duration {
+		t.Errorf("Expected overhead to sum layers, got %v", profile.Overhead())
+	}
+
+	hook, _ := agg.Hook("work")
+	if hook.Profile.Samples != 1 || hook.Profile.Layers["slow"] != profile.Layers[0].Duration {
+		t.Errorf("Expected profile aggregated, got %+v", hook.Profile)
+	}
+	var buf bytes.Buffer
+	if err := WriteOpenMetrics(&buf, agg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		`pipeline_hook_profile_samples_total{pipeline="profiled",hook="work"} 1`,
+		`pipeline_hook_profile_layer_seconds_total{pipeline="profiled",hook="work",layer="slow"}`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %q in metrics, got:\n%s", expected, buf.String())
+		}
+	}
+}
+
+// TestProfilingUnsampled 测试未采样的执行不记录剖析，以及采样比例的构建期检查
+func TestProfilingUnsampled(t *testing.T) {
+	var stats *ExecutionStats
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		UseNamed("slow", sleepMiddleware(0)).
+		AddNamedHook("work", appendHook("work")).
+		WithPanicIsolation()
+	pipeline.OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+		stats = pipeCtx.Stats()
+	})
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.Sampled || stats.HookStats[0].Profile != nil {
+		t.Errorf("Expected no profile without WithProfiling, got %+v", stats.HookStats[0].Profile)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for rate out of range")
+		}
+	}()
+	pipeline.WithProfiling(1.5)
+}
@@ -0,0 +1,197 @@
+// Package progress 将单次执行的进度事件实时推送给前端（SSE / WebSocket）。
+//
+// Broker 实现 pipe.EventStore，通过 Pipeline.WithEventStore 挂载后，事件在写入底层存储的同时
+// 推送给按执行 ID 订阅的连接。订阅时先回放底层存储中已有的事件，再推送后续事件，
+// 收到 execution_finished 后结束，前端据此展示长耗时管道的逐步进度。
+package progress
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// subscribeBuffer 订阅通道的缓冲大小
+const subscribeBuffer = 16
+
+// Event 推送给前端的进度事件（不包含共享数据的值和编码后的 Payload/Result）
+type Event struct {
+	ExecutionID string    `json:"execution_id"`
+	Pipeline    string    `json:"pipeline"`
+	Seq         int64     `json:"seq"`
+	Type        string    `json:"type"`
+	Hook        string    `json:"hook,omitempty"`
+	Stage       string    `json:"stage,omitempty"`
+	Index       int       `json:"index"`
+	Key         string    `json:"key,omitempty"`
+	DurationMs  float64   `json:"duration_ms,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	At          time.Time `json:"at"`
+}
+
+// NewEvent 将执行事件转换为进度事件
+func NewEvent(event pipe.ExecutionEvent) Event {
+	return Event{
+		ExecutionID: event.ExecutionID,
+		Pipeline:    event.Pipeline,
+		Seq:         event.Seq,
+		Type:        string(event.Type),
+		Hook:        event.Hook,
+		Stage:       event.Stage,
+		Index:       event.Index,
+		Key:         event.Key,
+		DurationMs:  float64(event.Duration) / float64(time.Millisecond),
+		Error:       event.Err,
+		At:          event.At,
+	}
+}
+
+// Broker 按执行 ID 分发事件的事件存储
+type Broker struct {
+	store pipe.EventStore
+
+	mu   sync.Mutex
+	subs map[string]map[*subscriber]struct{}
+}
+
+// NewBroker 创建事件分发器，事件同时写入 store（为 nil 时只推送不保存，订阅时无法回放已发生的事件）
+func NewBroker(store pipe.EventStore) *Broker {
+	return &Broker{
+		store: store,
+		subs:  make(map[string]map[*subscriber]struct{}),
+	}
+}
+
+// Append 写入底层存储并推送给该执行的订阅者；推送不阻塞执行
+func (b *Broker) Append(ctx context.Context, event pipe.ExecutionEvent) error {
+	var err error
+	if b.store != nil {
+		err = b.store.Append(ctx, event)
+	}
+
+	b.mu.Lock()
+	for sub := range b.subs[event.ExecutionID] {
+		sub.push(event)
+	}
+	b.mu.Unlock()
+	return err
+}
+
+// Query 查询底层存储
+func (b *Broker) Query(ctx context.Context, query pipe.EventQuery) ([]pipe.ExecutionEvent, error) {
+	if b.store == nil {
+		return nil, nil
+	}
+	return b.store.Query(ctx, query)
+}
+
+// Subscribe 订阅执行的事件：先回放已有事件，再推送后续事件，
+// 推送 execution_finished 或 ctx 取消后关闭通道
+func (b *Broker) Subscribe(ctx context.Context, executionID string) (<-chan pipe.ExecutionEvent, error) {
+	// 先登记再回放，回放期间写入的事件不会丢失
+	sub := &subscriber{notify: make(chan struct{}, 1)}
+	b.mu.Lock()
+	if b.subs[executionID] == nil {
+		b.subs[executionID] = make(map[*subscriber]struct{})
+	}
+	b.subs[executionID][sub] = struct{}{}
+	b.mu.Unlock()
+
+	history, err := b.Query(ctx, pipe.EventQuery{ExecutionID: executionID})
+	if err != nil {
+		b.unsubscribe(executionID, sub)
+		return nil, err
+	}
+
+	events := make(chan pipe.ExecutionEvent, subscribeBuffer)
+	go func() {
+		defer close(events)
+		defer b.unsubscribe(executionID, sub)
+
+		send := func(event pipe.ExecutionEvent) bool {
+			select {
+			case events <- event:
+				return event.Type != pipe.EventExecutionFinished
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		replayed := make(map[int64]bool, len(history))
+		for _, event := range history {
+			replayed[event.Seq] = true
+			if !send(event) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-sub.notify:
+			case <-ctx.Done():
+				return
+			}
+			for _, event := range sub.take() {
+				if replayed[event.Seq] {
+					continue
+				}
+				if !send(event) {
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// finished 返回执行结束事件的序号，执行未结束时 ok 为 false
+func (b *Broker) finished(ctx context.Context, executionID string) (seq int64, ok bool) {
+	events, err := b.Query(ctx, pipe.EventQuery{
+		ExecutionID: executionID,
+		Types:       []pipe.ExecutionEventType{pipe.EventExecutionFinished},
+	})
+	if err != nil || len(events) == 0 {
+		return 0, false
+	}
+	return events[0].Seq, true
+}
+
+// unsubscribe 取消订阅
+func (b *Broker) unsubscribe(executionID string, sub *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subs[executionID], sub)
+	if len(b.subs[executionID]) == 0 {
+		delete(b.subs, executionID)
+	}
+}
+
+// subscriber 单个订阅的待推送事件
+type subscriber struct {
+	mu      sync.Mutex
+	pending []pipe.ExecutionEvent
+	notify  chan struct{}
+}
+
+// push 追加待推送事件并唤醒订阅协程
+func (s *subscriber) push(event pipe.ExecutionEvent) {
+	s.mu.Lock()
+	s.pending = append(s.pending, event)
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// take 取出所有待推送事件
+func (s *subscriber) take() []pipe.ExecutionEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := s.pending
+	s.pending = nil
+	return events
+}
@@ -0,0 +1,98 @@
+package progress
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"golang.org/x/net/websocket"
+)
+
+// ExecutionIDFunc 从请求中解析执行 ID（如 r.PathValue("id")）
+type ExecutionIDFunc func(r *http.Request) string
+
+// SSE 返回以 Server-Sent Events 推送执行进度的 http.Handler：
+// 每个事件的 event 为事件类型，id 为事件序号，data 为 JSON 格式的 Event。
+// 推送 execution_finished 后结束响应；EventSource 重连时按 Last-Event-ID 跳过已推送的事件，
+// 执行已结束且全部推送过时返回 204，浏览器不再重连
+func (b *Broker) SSE(executionID ExecutionIDFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := executionID(r)
+		if id == "" {
+			http.Error(w, "missing execution id", http.StatusBadRequest)
+			return
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		after, _ := strconv.ParseInt(r.Header.Get("Last-Event-ID"), 10, 64)
+		if seq, ok := b.finished(r.Context(), id); ok && after >= seq {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		events, err := b.Subscribe(r.Context(), id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for event := range events {
+			if event.Seq <= after {
+				continue
+			}
+			data, err := json.Marshal(NewEvent(event))
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	})
+}
+
+// WebSocket 返回以 WebSocket 推送执行进度的 http.Handler：每条文本消息为 JSON 格式的 Event，
+// 推送 execution_finished 或客户端断开后关闭连接
+func (b *Broker) WebSocket(executionID ExecutionIDFunc) http.Handler {
+	return websocket.Handler(func(conn *websocket.Conn) {
+		defer conn.Close()
+
+		id := executionID(conn.Request())
+		if id == "" {
+			return
+		}
+
+		// 连接被接管后请求的 ctx 不再感知断开，通过读取检测客户端关闭
+		ctx, cancel := context.WithCancel(conn.Request().Context())
+		defer cancel()
+		go func() {
+			defer cancel()
+			var discard []byte
+			for websocket.Message.Receive(conn, &discard) == nil {
+			}
+		}()
+
+		events, err := b.Subscribe(ctx, id)
+		if err != nil {
+			return
+		}
+		for event := range events {
+			if err := websocket.JSON.Send(conn, NewEvent(event)); err != nil {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package progress
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pipe "github.com/sylphbyte/pipeline"
+	"golang.org/x/net/websocket"
+)
+
+type order struct{ ID string }
+
+// runPipeline 执行一次两个 Hook 的管道，返回执行 ID
+func runPipeline(t *testing.T, broker *Broker) string {
+	t.Helper()
+
+	var executionID string
+	p := pipe.NewSimplePipeline[order, string]("order").WithEventStore(broker)
+	p.OnBeforeExecute(func(ctx pipe.Context, pipeCtx *pipe.SimplePipeContext[order, string]) {
+		executionID = pipeCtx.Stats().ExecutionID
+	})
+	p.AddNamedHook("validate", func(ctx pipe.Context, pipeCtx *pipe.SimplePipeContext[order, string]) error {
+		return nil
+	})
+	p.AddNamedHook("charge", func(ctx pipe.Context, pipeCtx *pipe.SimplePipeContext[order, string]) error {
+		return nil
+	})
+
+	if _, err := p.ExecuteStd(context.Background(), &order{ID: "o-1"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	return executionID
+}
+
+// newServer 启动以路径参数作为执行 ID 的测试服务
+func newServer(broker *Broker) *httptest.Server {
+	id := func(r *http.Request) string { return r.PathValue("id") }
+	mux := http.NewServeMux()
+	mux.Handle("GET /executions/{id}/events", broker.SSE(id))
+	mux.Handle("GET /executions/{id}/ws", broker.WebSocket(id))
+	return httptest.NewServer(mux)
+}
+
+// TestSubscribeReplayAndLive 测试订阅先回放已有事件再推送后续事件
+func TestSubscribeReplayAndLive(t *testing.T) {
+	broker := NewBroker(pipe.NewMemoryEventStore())
+	ctx := context.Background()
+	appendEvent := func(seq int64, eventType pipe.ExecutionEventType, hook string) {
+		_ = broker.Append(ctx, pipe.ExecutionEvent{ExecutionID: "e-1", Seq: seq, Type: eventType, Hook: hook})
+	}
+
+	appendEvent(1, pipe.EventExecutionStarted, "")
+	appendEvent(2, pipe.EventHookStarted, "validate")
+
+	events, err := broker.Subscribe(ctx, "e-1")
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	appendEvent(3, pipe.EventHookFinished, "validate")
+	_ = broker.Append(ctx, pipe.ExecutionEvent{ExecutionID: "e-2", Seq: 1, Type: pipe.EventExecutionStarted})
+	appendEvent(4, pipe.EventExecutionFinished, "")
+
+	var seqs []int64
+	timeout := time.After(time.Second)
+	for done := false; !done; {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				done = true
+				break
+			}
+			if event.ExecutionID != "e-1" {
+				t.Errorf("Unexpected execution: %s", event.ExecutionID)
+			}
+			seqs = append(seqs, event.Seq)
+		case <-timeout:
+			t.Fatal("Subscription did not close after execution_finished")
+		}
+	}
+
+	if len(seqs) != 4 || seqs[0] != 1 || seqs[3] != 4 {
+		t.Errorf("Expected seqs 1..4, got %v", seqs)
+	}
+}
+
+// TestSSE 测试 SSE 推送执行进度及按 Last-Event-ID 重连
+func TestSSE(t *testing.T) {
+	broker := NewBroker(pipe.NewMemoryEventStore())
+	server := newServer(broker)
+	defer server.Close()
+
+	id := runPipeline(t, broker)
+	url := server.URL + "/executions/" + id + "/events"
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected text/event-stream, got %s", ct)
+	}
+
+	var types []string
+	var lastID string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if v, ok := strings.CutPrefix(line, "event: "); ok {
+			types = append(types, v)
+		}
+		if v, ok := strings.CutPrefix(line, "id: "); ok {
+			lastID = v
+		}
+	}
+	resp.Body.Close()
+
+	if len(types) != 6 || types[0] != "execution_started" || types[5] != "execution_finished" {
+		t.Errorf("Unexpected events: %v", types)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Last-Event-ID", lastID)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Reconnect failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent || len(body) != 0 {
+		t.Errorf("Expected 204 after finished execution, got %d %q", resp.StatusCode, body)
+	}
+}
+
+// TestWebSocket 测试 WebSocket 推送执行进度
+func TestWebSocket(t *testing.T) {
+	broker := NewBroker(pipe.NewMemoryEventStore())
+	server := newServer(broker)
+	defer server.Close()
+
+	id := runPipeline(t, broker)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/executions/" + id + "/ws"
+
+	conn, err := websocket.Dial(wsURL, "", server.URL)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	var hooks []string
+	for {
+		var event Event
+		if err := websocket.JSON.Receive(conn, &event); err != nil {
+			break
+		}
+		if event.ExecutionID != id {
+			t.Errorf("Unexpected execution: %s", event.ExecutionID)
+		}
+		if event.Type == string(pipe.EventHookFinished) {
+			hooks = append(hooks, event.Hook)
+		}
+	}
+
+	if len(hooks) != 2 || hooks[0] != "validate" || hooks[1] != "charge" {
+		t.Errorf("Unexpected finished hooks: %v", hooks)
+	}
+}
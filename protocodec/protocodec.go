@@ -0,0 +1,57 @@
+// Package protocodec 提供基于 Protocol Buffers 的 pipe.Codec 实现。
+//
+// 编码结果为 google.protobuf.Any，类型 URL 记录消息全名，解码时类型不一致返回 pipe.ErrSchemaMismatch。
+// 字段的增删兼容性由 proto 本身的规则保证，不需要像 JSONCodec 那样注册迁移函数。
+//
+//	pipeline.WithCodecs(protocodec.New[orderpb.CreateOrder](), protocodec.New[orderpb.Order]())
+package protocodec
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// Message *T 实现 proto.Message 的约束
+type Message[T any] interface {
+	*T
+	proto.Message
+}
+
+// Codec 以 proto 消息编解码 Payload/Result（T 为生成的消息结构体）
+type Codec[T any, PT Message[T]] struct{}
+
+// New 创建 proto 编解码器
+func New[T any, PT Message[T]]() *Codec[T, PT] {
+	return &Codec[T, PT]{}
+}
+
+// Encode 实现 pipe.Codec
+func (c *Codec[T, PT]) Encode(v *T) ([]byte, error) {
+	msg, err := anypb.New(PT(v))
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+// Decode 实现 pipe.Codec
+func (c *Codec[T, PT]) Decode(data []byte) (*T, error) {
+	var msg anypb.Any
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("%w: %w", pipe.ErrSchemaMismatch, err)
+	}
+
+	out := PT(new(T))
+	if !msg.MessageIs(out) {
+		return nil, fmt.Errorf("%w: expected %s, got %s",
+			pipe.ErrSchemaMismatch, out.ProtoReflect().Descriptor().FullName(), msg.GetTypeUrl())
+	}
+	if err := msg.UnmarshalTo(out); err != nil {
+		return nil, fmt.Errorf("%w: %w", pipe.ErrSchemaMismatch, err)
+	}
+	return (*T)(out), nil
+}
@@ -0,0 +1,32 @@
+package protocodec
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// TestCodecRoundTrip 测试 proto 编解码与类型校验
+func TestCodecRoundTrip(t *testing.T) {
+	codec := New[wrapperspb.StringValue]()
+
+	data, err := codec.Encode(wrapperspb.String("order-1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	decoded, err := codec.Decode(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.GetValue() != "order-1" {
+		t.Errorf("Expected order-1, got %q", decoded.GetValue())
+	}
+
+	if _, err := New[wrapperspb.Int64Value]().Decode(data); !errors.Is(err, pipe.ErrSchemaMismatch) {
+		t.Errorf("Expected ErrSchemaMismatch, got %v", err)
+	}
+}
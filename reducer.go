@@ -0,0 +1,24 @@
+package pipeline
+
+// ResultReducer 将 Hook 的贡献归并到 Result
+type ResultReducer[Result any] func(result *Result, contribution any)
+
+// WithResultReducer 设置 Result 归并函数：Hook 通过 pipeCtx.Contribute 提交贡献，由归并函数统一组装 Result，
+// Hook 无需了解 Result 的结构。并行分支的贡献归并到分支自己的 Result，之后按分支的 Merger 合并
+func (p *Pipeline[C, Option, Payload, Result]) WithResultReducer(reducer ResultReducer[Result]) *Pipeline[C, Option, Payload, Result] {
+	p.reducer = reducer
+	return p
+}
+
+// Contribute 提交对 Result 的贡献，由 WithResultReducer 设置的归并函数处理（可并发调用）
+// 未设置归并函数时返回 ErrNoResultReducer
+func (p *PipeContext[Option, Payload, Result]) Contribute(contribution any) error {
+	if p.reducer == nil {
+		return ErrNoResultReducer
+	}
+
+	p.reduceMu.Lock()
+	defer p.reduceMu.Unlock()
+	p.reducer(p.Result, contribution)
+	return nil
+}
@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+)
+
+type priceContribution struct {
+	Item  string
+	Price int
+}
+
+// TestResultReducer 测试 Hook 贡献经归并函数组装 Result
+func TestResultReducer(t *testing.T) {
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		WithResultReducer(func(result *TestResult, contribution any) {
+			switch c := contribution.(type) {
+			case string:
+				result.Output = append(result.Output, c)
+			case priceContribution:
+				if result.Metadata == nil {
+					result.Metadata = make(map[string]any)
+				}
+				total, _ := result.Metadata["total"].(int)
+				result.Metadata["total"] = total + c.Price
+			}
+		}).
+		AddNamedHook("label", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			return pipeCtx.Contribute("priced")
+		}).
+		AddNamedHook("price", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			var wg sync.WaitGroup
+			for i := 1; i <= 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					pipeCtx.Contribute(priceContribution{Price: i})
+				}()
+			}
+			wg.Wait()
+			return nil
+		})
+
+	result, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Output) != 1 || result.Output[0] != "priced" {
+		t.Errorf("Expected [priced], got %v", result.Output)
+	}
+	if result.Metadata["total"] != 55 {
+		t.Errorf("Expected total 55, got %v", result.Metadata["total"])
+	}
+}
+
+// TestResultReducerParallel 测试并行分支的贡献随分支 Result 合并
+func TestResultReducerParallel(t *testing.T) {
+	branch := func(item string) func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+		return func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			return pipeCtx.Contribute(item)
+		}
+	}
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		WithResultReducer(func(result *TestResult, contribution any) {
+			result.Output = append(result.Output, contribution.(string))
+		}).
+		AddHookWithOptions(Parallel[Context, NoOption, TestPayload, TestResult]().
+			Branch(branch("a")).
+			Branch(branch("b")).
+			Merger(ResultMergerFunc[TestResult](func(base *TestResult, branches []*TestResult) (*TestResult, error) {
+				for _, branch := range branches {
+					base.Output = append(base.Output, branch.Output...)
+				}
+				return base, nil
+			})).
+			Build("enrich"))
+
+	result, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sort.Strings(result.Output)
+	if len(result.Output) != 2 || result.Output[0] != "a" || result.Output[1] != "b" {
+		t.Errorf("Expected [a b], got %v", result.Output)
+	}
+}
+
+// TestContributeWithoutReducer 测试未设置归并函数时 Contribute 返回错误
+func TestContributeWithoutReducer(t *testing.T) {
+	pipeCtx := NewPipeContext[NoOption, TestPayload, TestResult]("test", nil, &TestPayload{}, nil)
+	if err := pipeCtx.Contribute("x"); !errors.Is(err, ErrNoResultReducer) {
+		t.Errorf("Expected ErrNoResultReducer, got %v", err)
+	}
+}
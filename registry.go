@@ -0,0 +1,142 @@
+package pipeline
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// HookFactory 按名称创建 Hook 的工厂函数，每次引用时调用一次，返回新的 Hook
+type HookFactory[C Context, Option any, Payload any, Result any] func() *Hook[C, Option, Payload, Result]
+
+// HookRegistry 按名称注册的 Hook 工厂，插件模块注册 Hook，管道（及 DSL）按名称引用，二者互不导入
+type HookRegistry[C Context, Option any, Payload any, Result any] struct {
+	mu        sync.RWMutex
+	factories map[string]HookFactory[C, Option, Payload, Result]
+}
+
+// NewHookRegistry 创建独立的 Hook 注册表
+func NewHookRegistry[C Context, Option any, Payload any, Result any]() *HookRegistry[C, Option, Payload, Result] {
+	return &HookRegistry[C, Option, Payload, Result]{
+		factories: make(map[string]HookFactory[C, Option, Payload, Result]),
+	}
+}
+
+// hookRegistries 各类型参数组合的全局注册表（*HookRegistry 类型 -> 注册表）
+var hookRegistries sync.Map
+
+// DefaultHookRegistry 获取类型参数对应的全局注册表
+func DefaultHookRegistry[C Context, Option any, Payload any, Result any]() *HookRegistry[C, Option, Payload, Result] {
+	key := reflect.TypeOf((*HookRegistry[C, Option, Payload, Result])(nil))
+	if r, ok := hookRegistries.Load(key); ok {
+		return r.(*HookRegistry[C, Option, Payload, Result])
+	}
+	r, _ := hookRegistries.LoadOrStore(key, NewHookRegistry[C, Option, Payload, Result]())
+	return r.(*HookRegistry[C, Option, Payload, Result])
+}
+
+// RegisterHookFactory 向全局注册表注册 Hook 工厂（通常在插件包的 init 中调用）
+// 名称重复时 panic
+func RegisterHookFactory[C Context, Option any, Payload any, Result any](
+	name string,
+	factory HookFactory[C, Option, Payload, Result],
+) {
+	DefaultHookRegistry[C, Option, Payload, Result]().Register(name, factory)
+}
+
+// Register 注册 Hook 工厂，名称重复时 panic
+func (r *HookRegistry[C, Option, Payload, Result]) Register(
+	name string,
+	factory HookFactory[C, Option, Payload, Result],
+) *HookRegistry[C, Option, Payload, Result] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.factories[name]; exists {
+		panic(fmt.Sprintf("pipeline: hook factory '%s' already registered", name))
+	}
+	r.factories[name] = factory
+	return r
+}
+
+// Hook 按名称创建 Hook，未设置名称的 Hook 使用注册名称；未注册时返回 ErrHookNotRegistered
+func (r *HookRegistry[C, Option, Payload, Result]) Hook(name string) (*Hook[C, Option, Payload, Result], error) {
+	r.mu.RLock()
+	factory, ok := r.factories[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrHookNotRegistered, name)
+	}
+
+	hook := factory()
+	if hook.Name == "" {
+		hook.Name = name
+	}
+	return hook, nil
+}
+
+// Names 按字典序返回所有已注册的名称
+func (r *HookRegistry[C, Option, Payload, Result]) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// WithHookRegistry 设置管道引用 Hook 时使用的注册表（默认 DefaultHookRegistry）
+func (p *Pipeline[C, Option, Payload, Result]) WithHookRegistry(
+	registry *HookRegistry[C, Option, Payload, Result],
+) *Pipeline[C, Option, Payload, Result] {
+	p.hookRegistry = registry
+	return p
+}
+
+// HookRegistry 获取管道使用的 Hook 注册表
+func (p *Pipeline[C, Option, Payload, Result]) HookRegistry() *HookRegistry[C, Option, Payload, Result] {
+	if p.hookRegistry != nil {
+		return p.hookRegistry
+	}
+	return DefaultHookRegistry[C, Option, Payload, Result]()
+}
+
+// AddRegisteredHook 按名称添加已注册的 Hook，未注册时 panic
+func (p *Pipeline[C, Option, Payload, Result]) AddRegisteredHook(names ...string) *Pipeline[C, Option, Payload, Result] {
+	for _, name := range names {
+		p.AddHookWithOptions(p.mustRegisteredHook(name))
+	}
+	return p
+}
+
+// InsertHookBefore 在名为 target 的 Hook 之前插入 Hook，target 不存在时 panic
+func (p *Pipeline[C, Option, Payload, Result]) InsertHookBefore(
+	target string,
+	hook *Hook[C, Option, Payload, Result],
+) *Pipeline[C, Option, Payload, Result] {
+	for i, h := range p.hooks {
+		if p.hookName(h) == target {
+			p.hooks = slices.Insert(p.hooks, i, hook)
+			return p
+		}
+	}
+	panic(fmt.Sprintf("pipeline: %v: %s", ErrHookNotFound, target))
+}
+
+// InsertRegisteredHookBefore 在名为 target 的 Hook 之前插入已注册的 Hook
+func (p *Pipeline[C, Option, Payload, Result]) InsertRegisteredHookBefore(target, name string) *Pipeline[C, Option, Payload, Result] {
+	return p.InsertHookBefore(target, p.mustRegisteredHook(name))
+}
+
+// mustRegisteredHook 从管道的注册表创建 Hook，未注册时 panic（构建期编程错误）
+func (p *Pipeline[C, Option, Payload, Result]) mustRegisteredHook(name string) *Hook[C, Option, Payload, Result] {
+	hook, err := p.HookRegistry().Hook(name)
+	if err != nil {
+		panic(fmt.Sprintf("pipeline: %v", err))
+	}
+	return hook
+}
@@ -0,0 +1,75 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// recordHook 创建记录执行顺序的 Hook 工厂
+func recordHook(order *[]string, name string) HookFactory[Context, NoOption, TestPayload, TestResult] {
+	return func() *Hook[Context, NoOption, TestPayload, TestResult] {
+		return NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			*order = append(*order, name)
+			return nil
+		}).Build()
+	}
+}
+
+// TestHookRegistry 测试按名称引用已注册的 Hook 并在指定 Hook 前插入
+func TestHookRegistry(t *testing.T) {
+	var order []string
+	registry := NewHookRegistry[Context, NoOption, TestPayload, TestResult]().
+		Register("validate", recordHook(&order, "validate")).
+		Register("audit", recordHook(&order, "audit")).
+		Register("persist", recordHook(&order, "persist"))
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		WithHookRegistry(registry).
+		AddRegisteredHook("validate", "persist").
+		InsertRegisteredHookBefore("persist", "audit")
+
+	if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"validate", "audit", "persist"}; !slices.Equal(order, want) {
+		t.Errorf("Expected %v, got %v", want, order)
+	}
+
+	if _, err := registry.Hook("missing"); !errors.Is(err, ErrHookNotRegistered) {
+		t.Errorf("Expected ErrHookNotRegistered, got %v", err)
+	}
+	if names := registry.Names(); !slices.Equal(names, []string{"audit", "persist", "validate"}) {
+		t.Errorf("Unexpected names %v", names)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected duplicate registration to panic")
+		}
+	}()
+	registry.Register("audit", recordHook(&order, "audit"))
+}
+
+// globalRegistrations 全局注册表测试的注册次数
+var globalRegistrations atomic.Int64
+
+// TestDefaultHookRegistry 测试全局注册表按类型参数隔离
+func TestDefaultHookRegistry(t *testing.T) {
+	// 全局注册表在进程内共享，每次运行（-count）使用不同的名称
+	var order []string
+	name := fmt.Sprintf("registry-test-global-%d", globalRegistrations.Add(1))
+	RegisterHookFactory(name, recordHook(&order, "global"))
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").AddRegisteredHook(name)
+	if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil || len(order) != 1 {
+		t.Fatalf("Expected global hook to run, got %v, %v", order, err)
+	}
+
+	if _, err := DefaultHookRegistry[Context, NoOption, TestPayload, NoOption]().Hook(name); !errors.Is(err, ErrHookNotRegistered) {
+		t.Errorf("Expected registries to be separated by type, got %v", err)
+	}
+}
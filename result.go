@@ -0,0 +1,20 @@
+package pipeline
+
+// OnValidateResult 注册结果校验（后置条件），在最后一个 Hook 之后、返回之前执行
+// 任一校验失败时管道返回 *ResultInvalidError
+func (p *Pipeline[C, Option, Payload, Result]) OnValidateResult(
+	fn func(ctx C, result *Result) error,
+) *Pipeline[C, Option, Payload, Result] {
+	p.resultChecks = append(p.resultChecks, fn)
+	return p
+}
+
+// validateResult 依次执行结果校验，返回第一个失败
+func (p *Pipeline[C, Option, Payload, Result]) validateResult(ctx C, result *Result) error {
+	for _, check := range p.resultChecks {
+		if err := check(ctx, result); err != nil {
+			return &ResultInvalidError{PipelineName: p.Name, Err: err}
+		}
+	}
+	return nil
+}
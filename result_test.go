@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestOnValidateResult 测试结果后置校验
+func TestOnValidateResult(t *testing.T) {
+	errEmpty := errors.New("empty output")
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		OnValidateResult(func(ctx sylph.Context, result *TestResult) error {
+			if len(result.Output) == 0 {
+				return errEmpty
+			}
+			return nil
+		}).
+		AddHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			if pipeCtx.Payload.Data != "" {
+				pipeCtx.Result.Output = append(pipeCtx.Result.Output, pipeCtx.Payload.Data)
+			}
+			return nil
+		})
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{Data: "x"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err := pipeline.Execute(newMockContext(), &TestPayload{})
+	var invalid *ResultInvalidError
+	if !errors.As(err, &invalid) || !errors.Is(err, errEmpty) {
+		t.Errorf("Expected ResultInvalidError wrapping errEmpty, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package pipeline
+
+import "errors"
+
+// retryMark 携带重试语义标注的错误
+type retryMark struct {
+	retryable bool
+	err       error
+}
+
+func (e *retryMark) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryMark) Unwrap() error {
+	return e.err
+}
+
+// Retryable 标注错误值得重试（覆盖错误分类的判断），Hook 借此向重试中间件、错误处理和死信路由传达重试语义
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryMark{retryable: true, err: err}
+}
+
+// Permanent 标注错误不应重试（覆盖错误分类的判断）
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryMark{retryable: false, err: err}
+}
+
+// RetryableOf 返回错误的重试标注，marked 为 false 表示未经 Retryable/Permanent 标注（取最外层的标注）
+func RetryableOf(err error) (retryable bool, marked bool) {
+	var mark *retryMark
+	if errors.As(err, &mark) {
+		return mark.retryable, true
+	}
+	return false, false
+}
+
+// ShouldRetry 错误是否值得重试：优先使用 Retryable/Permanent 标注，否则取错误分类的 Retryable
+func (p *PipeContext[Option, Payload, Result]) ShouldRetry(err error) bool {
+	if retryable, marked := RetryableOf(err); marked {
+		return retryable
+	}
+	return p.ClassifyError(err).Retryable()
+}
@@ -0,0 +1,58 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestRetryableMarkers 测试重试标注对 PipeError 与错误处理决定的影响
+func TestRetryableMarkers(t *testing.T) {
+	errRemote := errors.New("remote")
+
+	if _, marked := RetryableOf(errRemote); marked {
+		t.Error("Expected unmarked error")
+	}
+	if retryable, marked := RetryableOf(Permanent(Retryable(errRemote))); !marked || retryable {
+		t.Error("Expected the outermost marker to win")
+	}
+	if Retryable(nil) != nil || Permanent(nil) != nil {
+		t.Error("Expected nil errors to stay nil")
+	}
+
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+		attempts  int
+	}{
+		{"unmarked", errRemote, true, 3},
+		{"permanent", Permanent(errRemote), false, 1},
+		{"retryable validation", Retryable(Classify(errRemote, ClassValidation)), true, 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+				AddHookWithOptions(NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+					attempts++
+					return tc.err
+				}).WithName("call").OnErrorHandle(func(ctx Context, err error) ErrorDecision {
+					if attempts < 3 {
+						return DecideRetry()
+					}
+					return DecideAbort()
+				}).Build())
+
+			_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+			var pipeErr *PipeError
+			if !errors.As(err, &pipeErr) || pipeErr.Retryable != tc.retryable {
+				t.Errorf("Expected PipeError.Retryable=%v, got %v", tc.retryable, err)
+			}
+			if attempts != tc.attempts {
+				t.Errorf("Expected %d attempts, got %d", tc.attempts, attempts)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+// Package saga 提供跨多个管道的 Saga 编排。
+//
+// Saga 由一组有序步骤组成，每个步骤包含正向动作和补偿动作。任一步骤失败时，
+// 已完成的步骤按相反顺序执行补偿。Saga 状态在每一步之后持久化到 Store，
+// 进程崩溃后可通过 Resume / RecoverAll 继续执行未完成的正向流程或补偿流程。
+//
+// 恢复时正在执行的步骤会被重新执行，因此动作与补偿都应当是幂等的。
+package saga
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+var (
+	// ErrCompensated 步骤失败，已完成补偿
+	ErrCompensated = errors.New("saga compensated")
+	// ErrCompensationFailed 补偿失败，需要人工介入
+	ErrCompensationFailed = errors.New("saga compensation failed")
+	// ErrNotFound Saga 实例不存在
+	ErrNotFound = errors.New("saga not found")
+	// ErrAlreadyExists Saga 实例已存在
+	ErrAlreadyExists = errors.New("saga already exists")
+	// ErrBusy Saga 实例正在被另一次 Execute/Resume 处理
+	ErrBusy = errors.New("saga instance busy")
+)
+
+// Status Saga 状态
+type Status string
+
+const (
+	StatusRunning      Status = "running"      // 正向执行中
+	StatusCompensating Status = "compensating" // 补偿中
+	StatusCompleted    Status = "completed"    // 全部步骤成功
+	StatusCompensated  Status = "compensated"  // 失败且补偿完成
+	StatusFailed       Status = "failed"       // 补偿失败
+)
+
+// Done 是否为终态
+func (s Status) Done() bool {
+	return s == StatusCompleted || s == StatusCompensated || s == StatusFailed
+}
+
+// Action 步骤动作（正向或补偿），可直接修改 Data
+type Action[C pipe.Context, Data any] func(ctx C, data *Data) error
+
+// Step Saga 步骤
+type Step[C pipe.Context, Data any] struct {
+	Name       string
+	Action     Action[C, Data]
+	Compensate Action[C, Data] // 可为 nil（无需补偿）
+}
+
+// State Saga 实例状态
+type State[Data any] struct {
+	ID         string    // 实例 ID
+	Saga       string    // Saga 名称
+	Status     Status    // 当前状态
+	Completed  int       // 已完成（且未被补偿）的步骤数
+	FailedStep string    // 失败的步骤名称
+	Error      string    // 失败原因
+	Data       *Data     // 业务数据
+	UpdatedAt  time.Time // 更新时间
+}
+
+// Saga 编排器
+type Saga[C pipe.Context, Data any] struct {
+	Name  string
+	steps []Step[C, Data]
+	store Store[Data]
+
+	mu     sync.Mutex          // 只保护 active，步骤动作执行期间不持有
+	active map[string]struct{} // 正在处理的实例 ID
+}
+
+// New 创建 Saga（store 为 nil 时使用内存存储）
+func New[C pipe.Context, Data any](name string, store Store[Data]) *Saga[C, Data] {
+	if store == nil {
+		store = NewMemoryStore[Data]()
+	}
+	return &Saga[C, Data]{Name: name, store: store, active: make(map[string]struct{})}
+}
+
+// AddStep 添加步骤
+func (s *Saga[C, Data]) AddStep(name string, action, compensate Action[C, Data]) *Saga[C, Data] {
+	s.steps = append(s.steps, Step[C, Data]{Name: name, Action: action, Compensate: compensate})
+	return s
+}
+
+// PipelineAction 将管道（或任意 Executor）适配为步骤动作，管道的 Result 替换 Data
+func PipelineAction[C pipe.Context, Data any](exec pipe.Executor[C, Data, Data]) Action[C, Data] {
+	return func(ctx C, data *Data) error {
+		result, err := exec.Execute(ctx, data)
+		if err != nil {
+			return err
+		}
+		if result != nil {
+			*data = *result
+		}
+		return nil
+	}
+}
+
+// Execute 创建并执行 Saga 实例
+// 同一实例同时只允许一次 Execute/Resume，冲突时返回 ErrBusy；不同实例互不阻塞
+func (s *Saga[C, Data]) Execute(ctx C, id string, data *Data) (*State[Data], error) {
+	if err := s.acquire(id); err != nil {
+		return nil, err
+	}
+	defer s.release(id)
+
+	if _, err := s.store.Load(ctx, s.Name, id); err == nil {
+		return nil, fmt.Errorf("saga '%s' instance '%s': %w", s.Name, id, ErrAlreadyExists)
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	state := &State[Data]{ID: id, Saga: s.Name, Status: StatusRunning, Data: data}
+	if err := s.save(ctx, state); err != nil {
+		return nil, err
+	}
+
+	return state, s.run(ctx, state)
+}
+
+// Resume 继续执行未完成的 Saga 实例（崩溃恢复），从 Store 中最近一次保存的检查点开始
+func (s *Saga[C, Data]) Resume(ctx C, id string) (*State[Data], error) {
+	if err := s.acquire(id); err != nil {
+		return nil, err
+	}
+	defer s.release(id)
+
+	state, err := s.store.Load(ctx, s.Name, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Status.Done() {
+		return state, nil
+	}
+
+	return state, s.run(ctx, state)
+}
+
+// acquire 占用实例，已被占用时返回 ErrBusy
+func (s *Saga[C, Data]) acquire(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, busy := s.active[id]; busy {
+		return fmt.Errorf("saga '%s' instance '%s': %w", s.Name, id, ErrBusy)
+	}
+	s.active[id] = struct{}{}
+	return nil
+}
+
+// release 释放实例占用
+func (s *Saga[C, Data]) release(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.active, id)
+}
+
+// RecoverAll 恢复所有未完成的 Saga 实例，返回各实例的最终状态和遇到的错误
+func (s *Saga[C, Data]) RecoverAll(ctx C) ([]*State[Data], error) {
+	pending, err := s.store.ListPending(ctx, s.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*State[Data], 0, len(pending))
+	var errs []error
+	for _, p := range pending {
+		state, err := s.Resume(ctx, p.ID)
+		if state != nil {
+			states = append(states, state)
+		}
+		if err != nil && !errors.Is(err, ErrCompensated) {
+			errs = append(errs, err)
+		}
+	}
+
+	return states, errors.Join(errs...)
+}
+
+// run 根据当前状态继续正向执行或补偿
+func (s *Saga[C, Data]) run(ctx C, state *State[Data]) error {
+	if state.Status == StatusRunning {
+		for i := state.Completed; i < len(s.steps); i++ {
+			step := s.steps[i]
+			if err := step.Action(ctx, state.Data); err != nil {
+				state.Status = StatusCompensating
+				state.FailedStep = step.Name
+				state.Error = err.Error()
+				if saveErr := s.save(ctx, state); saveErr != nil {
+					return saveErr
+				}
+				break
+			}
+
+			state.Completed = i + 1
+			if err := s.save(ctx, state); err != nil {
+				return err
+			}
+		}
+
+		if state.Status == StatusRunning {
+			state.Status = StatusCompleted
+			return s.save(ctx, state)
+		}
+	}
+
+	return s.compensate(ctx, state)
+}
+
+// compensate 逆序补偿已完成的步骤
+func (s *Saga[C, Data]) compensate(ctx C, state *State[Data]) error {
+	for state.Completed > 0 {
+		step := s.steps[state.Completed-1]
+		if step.Compensate != nil {
+			if err := step.Compensate(ctx, state.Data); err != nil {
+				state.Status = StatusFailed
+				state.Error = fmt.Sprintf("%s; compensate '%s': %v", state.Error, step.Name, err)
+				if saveErr := s.save(ctx, state); saveErr != nil {
+					return saveErr
+				}
+				return fmt.Errorf("saga '%s' instance '%s' step '%s': %w: %w",
+					s.Name, state.ID, step.Name, ErrCompensationFailed, err)
+			}
+		}
+
+		state.Completed--
+		if err := s.save(ctx, state); err != nil {
+			return err
+		}
+	}
+
+	state.Status = StatusCompensated
+	if err := s.save(ctx, state); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("saga '%s' instance '%s' failed at step '%s': %w: %s",
+		s.Name, state.ID, state.FailedStep, ErrCompensated, state.Error)
+}
+
+// save 持久化状态
+func (s *Saga[C, Data]) save(ctx C, state *State[Data]) error {
+	state.UpdatedAt = time.Now()
+	return s.store.Save(ctx, state)
+}
@@ -0,0 +1,122 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+type booking struct {
+	Log []string
+}
+
+func record(entry string, err error) Action[pipe.Context, booking] {
+	return func(ctx pipe.Context, data *booking) error {
+		if err != nil {
+			return err
+		}
+		data.Log = append(data.Log, entry)
+		return nil
+	}
+}
+
+// TestSagaCompensation 测试失败后逆序补偿
+func TestSagaCompensation(t *testing.T) {
+	ctx := pipe.WrapContext(context.Background())
+	paymentErr := errors.New("card declined")
+
+	s := New[pipe.Context, booking]("trip", nil).
+		AddStep("hotel", record("hotel", nil), record("cancel-hotel", nil)).
+		AddStep("flight", record("flight", nil), record("cancel-flight", nil)).
+		AddStep("payment", record("", paymentErr), nil)
+
+	state, err := s.Execute(ctx, "t1", &booking{})
+	if !errors.Is(err, ErrCompensated) {
+		t.Fatalf("Expected ErrCompensated, got %v", err)
+	}
+
+	if state.Status != StatusCompensated || state.FailedStep != "payment" {
+		t.Errorf("Unexpected state: %+v", state)
+	}
+
+	if got := strings.Join(state.Data.Log, ","); got != "hotel,flight,cancel-flight,cancel-hotel" {
+		t.Errorf("Unexpected log: %s", got)
+	}
+}
+
+// TestSagaRecovery 测试崩溃后恢复未完成的 Saga
+func TestSagaRecovery(t *testing.T) {
+	ctx := pipe.WrapContext(context.Background())
+	store := NewMemoryStore[booking]()
+
+	// 模拟崩溃前已完成第一步
+	_ = store.Save(ctx, &State[booking]{
+		ID: "t2", Saga: "trip", Status: StatusRunning, Completed: 1,
+		Data: &booking{Log: []string{"hotel"}},
+	})
+
+	s := New[pipe.Context, booking]("trip", store).
+		AddStep("hotel", record("hotel", nil), record("cancel-hotel", nil)).
+		AddStep("flight", record("flight", nil), record("cancel-flight", nil))
+
+	states, err := s.RecoverAll(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(states) != 1 || states[0].Status != StatusCompleted {
+		t.Fatalf("Expected one completed saga, got %+v", states)
+	}
+
+	if got := strings.Join(states[0].Data.Log, ","); got != "hotel,flight" {
+		t.Errorf("Expected only remaining steps to run, got %s", got)
+	}
+}
+
+// TestSagaCheckpointIsolation 测试存储中的检查点不受后续步骤修改影响，且实例之间互不阻塞
+func TestSagaCheckpointIsolation(t *testing.T) {
+	ctx := pipe.WrapContext(context.Background())
+	store := NewMemoryStore[booking]()
+
+	entered := make(chan struct{})
+	unblock := make(chan struct{})
+	var checkpoint []string
+
+	s := New[pipe.Context, booking]("trip", store).
+		AddStep("hotel", record("hotel", nil), nil).
+		AddStep("flight", func(ctx pipe.Context, data *booking) error {
+			data.Log = append(data.Log, "flight")
+			if saved, err := store.Load(ctx, "trip", "slow"); err == nil && checkpoint == nil {
+				checkpoint = saved.Data.Log
+				close(entered)
+				<-unblock
+			}
+			return nil
+		}, nil)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.Execute(ctx, "slow", &booking{})
+		done <- err
+	}()
+	<-entered
+
+	if _, err := s.Resume(ctx, "slow"); !errors.Is(err, ErrBusy) {
+		t.Errorf("Expected ErrBusy, got %v", err)
+	}
+	if state, err := s.Execute(ctx, "fast", &booking{}); err != nil || state.Status != StatusCompleted {
+		t.Errorf("Expected other instance to complete, got %+v, %v", state, err)
+	}
+
+	close(unblock)
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := strings.Join(checkpoint, ","); got != "hotel" {
+		t.Errorf("Expected checkpoint to hold only saved steps, got %s", got)
+	}
+}
@@ -0,0 +1,77 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// Store Saga 状态持久化接口
+type Store[Data any] interface {
+	// Save 保存状态（覆盖同一 Saga 下同 ID 的实例）
+	Save(ctx context.Context, state *State[Data]) error
+	// Load 读取状态，不存在时返回 ErrNotFound
+	Load(ctx context.Context, saga, id string) (*State[Data], error)
+	// ListPending 列出未到达终态的实例
+	ListPending(ctx context.Context, saga string) ([]*State[Data], error)
+}
+
+// MemoryStore 基于内存的 Store（用于测试和单机场景）
+// 保存和读取时都深拷贝 Data，存储内容只在 Save 时变化，与持久化存储的语义一致
+type MemoryStore[Data any] struct {
+	mu     sync.RWMutex
+	states map[string]State[Data]
+}
+
+// NewMemoryStore 创建内存 Store
+func NewMemoryStore[Data any]() *MemoryStore[Data] {
+	return &MemoryStore[Data]{states: make(map[string]State[Data])}
+}
+
+// Save 保存状态快照
+func (m *MemoryStore[Data]) Save(ctx context.Context, state *State[Data]) error {
+	snapshot := copyState(state)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[state.Saga+"/"+state.ID] = *snapshot
+	return nil
+}
+
+// Load 读取状态快照
+func (m *MemoryStore[Data]) Load(ctx context.Context, saga, id string) (*State[Data], error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	state, ok := m.states[saga+"/"+id]
+	if !ok {
+		return nil, fmt.Errorf("saga '%s' instance '%s': %w", saga, id, ErrNotFound)
+	}
+	return copyState(&state), nil
+}
+
+// ListPending 列出未到达终态的实例（按 ID 排序）
+func (m *MemoryStore[Data]) ListPending(ctx context.Context, saga string) ([]*State[Data], error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var pending []*State[Data]
+	for _, state := range m.states {
+		if state.Saga == saga && !state.Status.Done() {
+			pending = append(pending, copyState(&state))
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+	return pending, nil
+}
+
+// copyState 复制状态并深拷贝 Data
+func copyState[Data any](state *State[Data]) *State[Data] {
+	cp := *state
+	cp.Data = pipe.DeepCopy(state.Data)
+	return &cp
+}
@@ -0,0 +1,123 @@
+package pipeline
+
+import (
+	"maps"
+	"time"
+)
+
+// ShadowReport 影子执行的对比报告
+type ShadowReport[Result any] struct {
+	Hook            string        // Hook 名称
+	Index           int           // Hook 索引
+	PrimaryErr      error         // 主处理（含中间件）的错误
+	ShadowErr       error         // 候选处理的错误
+	PrimaryDuration time.Duration // 主处理耗时
+	ShadowDuration  time.Duration // 候选处理耗时
+	PrimaryResult   *Result       // 主处理完成时 Result 的副本
+	ShadowResult    *Result       // 候选处理在独立副本上得到的 Result
+	Diff            Diff          // 两者都成功时 Result 的差异（按 WithShadowDiff 的选项对比）
+	Match           bool          // 两者是否都成功且 Result 没有差异，或都失败
+}
+
+// WithShadow 设置影子候选处理，用于在生产环境中安全验证重写的步骤：
+// 候选处理与主处理并行执行，使用 Option、Payload、Result 的深拷贝和共享数据的浅拷贝（值保持原有引用），
+// 不经过中间件，其 Result、共享数据写入和错误都不影响真实执行；两者完成后通过 OnShadow 上报对比结果。
+// 候选处理使用同一个 ctx，管道结束后 ctx 被取消时可能提前失败
+func (b *HookBuilder[C, Option, Payload, Result]) WithShadow(
+	candidate HookHandler[C, Option, Payload, Result],
+) *HookBuilder[C, Option, Payload, Result] {
+	b.hook.shadow = candidate
+	return b
+}
+
+// OnShadow 注册影子执行报告回调，在后台 goroutine 中调用（Close 会等待未完成的影子执行）
+func (p *Pipeline[C, Option, Payload, Result]) OnShadow(
+	fn func(ctx C, report ShadowReport[Result]),
+) *Pipeline[C, Option, Payload, Result] {
+	p.onShadow = append(p.onShadow, fn)
+	return p
+}
+
+// WithShadowDiff 设置影子执行对比 Result 时的选项（如忽略时间戳字段、浮点容差）
+func (p *Pipeline[C, Option, Payload, Result]) WithShadowDiff(opts ...DiffOption) *Pipeline[C, Option, Payload, Result] {
+	p.shadowDiff = opts
+	return p
+}
+
+// withShadow 在主处理的同时以上下文副本执行候选处理，主处理不等待候选处理完成
+func (p *Pipeline[C, Option, Payload, Result]) withShadow(
+	primary HookHandler[C, Option, Payload, Result],
+	candidate HookHandler[C, Option, Payload, Result],
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) (err error) {
+		shadowCtx := pipeCtx.shadowCopy()
+		primaryDone := make(chan ShadowReport[Result], 1)
+
+		p.lifecycle.inflight.Add(1)
+		go func() {
+			defer p.lifecycle.inflight.Done()
+
+			start := time.Now()
+			shadowErr := recoverPanics(candidate)(ctx, shadowCtx)
+			shadowDuration := time.Since(start)
+			p.cleanup(ctx, shadowCtx)
+
+			report := <-primaryDone
+			report.ShadowErr = shadowErr
+			report.ShadowDuration = shadowDuration
+			report.ShadowResult = shadowCtx.Result
+			if report.PrimaryErr == nil && shadowErr == nil {
+				report.Diff = DiffResults(report.PrimaryResult, report.ShadowResult, p.shadowDiff...)
+			}
+			report.Match = (report.PrimaryErr == nil) == (shadowErr == nil) && report.Diff.Equal()
+			for _, fn := range p.onShadow {
+				fn(ctx, report)
+			}
+		}()
+
+		// 主处理 panic 时同样通知候选方，避免其 goroutine 永久阻塞
+		start := time.Now()
+		defer func() {
+			name, index := pipeCtx.CurrentHook()
+			primaryDone <- ShadowReport[Result]{
+				Hook:            name,
+				Index:           index,
+				PrimaryErr:      err,
+				PrimaryDuration: time.Since(start),
+				PrimaryResult:   DeepCopy(pipeCtx.Result),
+			}
+		}()
+		return primary(ctx, pipeCtx)
+	}
+}
+
+// shadowCopy 创建与当前上下文隔离的副本：中断、清理、共享数据写入都只作用于副本
+func (p *PipeContext[Option, Payload, Result]) shadowCopy() *PipeContext[Option, Payload, Result] {
+	p.state.mu.RLock()
+	data := p.state.copyData()
+	outputs := maps.Clone(p.state.outputs)
+	slots := maps.Clone(p.state.slots)
+	p.state.mu.RUnlock()
+	cloneValues(&data)
+
+	name, index := p.CurrentHook()
+	return &PipeContext[Option, Payload, Result]{
+		Name:    p.Name,
+		Option:  DeepCopy(p.Option),
+		Payload: DeepCopy(p.Payload),
+		Result:  DeepCopy(p.Result),
+		state: &sharedState{
+			data:       data,
+			outputs:    outputs,
+			slots:      slots,
+			classifier: p.state.classifier,
+			redacted:   p.state.redacted,
+			clock:      p.state.clock,
+			seed:       p.state.seed,
+		},
+		stats:     NewExecutionStats(p.Name),
+		hookName:  name,
+		hookIndex: index,
+		hookStage: p.CurrentStage(),
+	}
+}
@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestShadow 测试影子处理并行执行、上报对比结果且不影响真实执行
+func TestShadow(t *testing.T) {
+	reports := make(chan ShadowReport[TestResult], 1)
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("pricing")
+	pipeline.OnShadow(func(ctx sylph.Context, report ShadowReport[TestResult]) {
+		reports <- report
+	})
+	pipeline.AddHookWithOptions(NewHook(appendHook("v1")).
+		WithName("price").
+		WithShadow(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			time.Sleep(50 * time.Millisecond)
+			pipeCtx.Payload.Data = "mutated"
+			pipeCtx.Set("rewritten", true)
+			pipeCtx.Result.Output = append(pipeCtx.Result.Output, "v2")
+			return nil
+		}).
+		Build())
+
+	var stats *ExecutionStats
+	var shared bool
+	pipeline.OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+		stats = pipeCtx.Stats()
+		_, shared = pipeCtx.Get("rewritten")
+	})
+
+	payload := &TestPayload{Data: "order"}
+	result, err := pipeline.Execute(newMockContext(), payload)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Output, []string{"v1"}) || payload.Data != "order" || shared {
+		t.Errorf("Shadow leaked into real execution: result %v, payload %q, data %v", result.Output, payload.Data, shared)
+	}
+	if stats.HookStats[0].Duration >= 50*time.Millisecond {
+		t.Errorf("Expected primary not to wait for shadow, took %v", stats.HookStats[0].Duration)
+	}
+
+	report := <-reports
+	if report.Hook != "price" || report.Match {
+		t.Errorf("Expected mismatch report for price, got %+v", report)
+	}
+	if len(report.Diff.Differences) != 1 || report.Diff.Differences[0].Path != "Output[0]" {
+		t.Errorf("Expected Output[0] difference, got:\n%s", report.Diff)
+	}
+	if !reflect.DeepEqual(report.PrimaryResult.Output, []string{"v1"}) || !reflect.DeepEqual(report.ShadowResult.Output, []string{"v2"}) {
+		t.Errorf("Unexpected results: primary %v, shadow %v", report.PrimaryResult.Output, report.ShadowResult.Output)
+	}
+	if report.ShadowDuration < 50*time.Millisecond {
+		t.Errorf("Expected shadow latency recorded, got %v", report.ShadowDuration)
+	}
+}
+
+// TestShadowFailure 测试影子处理失败和 panic 不影响真实执行，Close 等待影子执行完成
+func TestShadowFailure(t *testing.T) {
+	var reports []ShadowReport[TestResult]
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("pricing")
+	pipeline.OnShadow(func(ctx sylph.Context, report ShadowReport[TestResult]) {
+		reports = append(reports, report)
+	})
+	pipeline.AddHookWithOptions(NewHook(appendHook("v1")).
+		WithName("price").
+		WithShadow(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			panic("candidate bug")
+		}).
+		Build())
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := pipeline.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 report after Close, got %d", len(reports))
+	}
+	var panicErr *PanicError
+	if reports[0].Match || !errors.As(reports[0].ShadowErr, &panicErr) || reports[0].PrimaryErr != nil {
+		t.Errorf("Expected shadow panic reported as mismatch, got %+v", reports[0])
+	}
+}
@@ -0,0 +1,36 @@
+package pipeline
+
+// NoOption 不需要 Option 的管道使用的空选项类型
+type NoOption = struct{}
+
+// SimplePipeline 简化管道：使用 pipe.Context，无 Option
+type SimplePipeline[Payload any, Result any] = Pipeline[Context, NoOption, Payload, Result]
+
+// SimpleHookHandler 简化管道的 Hook 处理函数
+type SimpleHookHandler[Payload any, Result any] = HookHandler[Context, NoOption, Payload, Result]
+
+// SimplePipeContext 简化管道的上下文
+type SimplePipeContext[Payload any, Result any] = PipeContext[NoOption, Payload, Result]
+
+// TransformPipeline 转换管道：Payload 与 Result 为同一类型
+type TransformPipeline[T any] = Pipeline[Context, NoOption, T, T]
+
+// TransformHookHandler 转换管道的 Hook 处理函数
+type TransformHookHandler[T any] = HookHandler[Context, NoOption, T, T]
+
+// NewSimplePipeline 创建简化管道
+// 只需指定 Payload 和 Result 两个类型参数，执行时可传入 pipe.WrapContext(ctx)
+func NewSimplePipeline[Payload any, Result any](name string) *SimplePipeline[Payload, Result] {
+	return NewPipeline[Context, NoOption, Payload, Result](name)
+}
+
+// NewTransformPipeline 创建转换管道
+// 执行开始时 Result 被初始化为 Payload 的浅拷贝，Hook 依次对 Result 进行转换
+func NewTransformPipeline[T any](name string) *TransformPipeline[T] {
+	return NewPipeline[Context, NoOption, T, T](name).
+		OnBeforeExecute(func(ctx Context, pipeCtx *PipeContext[NoOption, T, T]) {
+			if pipeCtx.Payload != nil {
+				*pipeCtx.Result = *pipeCtx.Payload
+			}
+		})
+}
@@ -0,0 +1,96 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestSimplePipeline 测试简化管道
+func TestSimplePipeline(t *testing.T) {
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("simple").
+		AddHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			pipeCtx.Result.Output = append(pipeCtx.Result.Output, pipeCtx.Payload.Data)
+			return nil
+		})
+
+	result, err := pipeline.Execute(WrapContext(context.Background()), &TestPayload{Data: "simple"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.Output) != 1 || result.Output[0] != "simple" {
+		t.Errorf("Expected output [simple], got %v", result.Output)
+	}
+}
+
+// TestTransformPipeline 测试转换管道
+func TestTransformPipeline(t *testing.T) {
+	var upper TransformHookHandler[string] = func(ctx Context, pipeCtx *PipeContext[NoOption, string, string]) error {
+		*pipeCtx.Result = strings.ToUpper(*pipeCtx.Result)
+		return nil
+	}
+
+	pipeline := NewTransformPipeline[string]("transform").
+		AddHook(upper).
+		AddHook(func(ctx Context, pipeCtx *PipeContext[NoOption, string, string]) error {
+			*pipeCtx.Result += "!"
+			return nil
+		})
+
+	input := "hello"
+	result, err := pipeline.Execute(WrapContext(context.Background()), &input)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if *result != "HELLO!" {
+		t.Errorf("Expected 'HELLO!', got '%s'", *result)
+	}
+
+	if input != "hello" {
+		t.Errorf("Expected payload to stay unchanged, got '%s'", input)
+	}
+}
+
+type recordingLogger struct {
+	nopLogger
+	infos []string
+}
+
+func (l *recordingLogger) Info(pkg, action string, data any) {
+	l.infos = append(l.infos, pkg+"."+action)
+}
+
+// TestExecuteStd 测试直接使用标准 context.Context 执行
+func TestExecuteStd(t *testing.T) {
+	logger := &recordingLogger{}
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("std").
+		WithLogger(logger).
+		AddHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			ctx.Info("hook", "run", nil)
+			return nil
+		})
+
+	if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(logger.infos) != 1 || logger.infos[0] != "hook.run" {
+		t.Errorf("Expected log routed to pipeline logger, got %v", logger.infos)
+	}
+}
+
+// TestExecuteStdIncompatibleContext 测试上下文类型不兼容
+func TestExecuteStdIncompatibleContext(t *testing.T) {
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("std")
+
+	_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	if !errors.Is(err, ErrIncompatibleContext) {
+		t.Errorf("Expected ErrIncompatibleContext, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package pipeline
+
+import (
+	"sort"
+	"sync"
+)
+
+// StatsSink 执行统计的接收端，每次执行结束后调用 Record
+// AggregateStats、ExpvarSink、OpenMetricsSink 均实现该接口
+type StatsSink interface {
+	Record(stats *ExecutionStats)
+}
+
+// StatsSinkFunc 函数形式的统计接收端
+type StatsSinkFunc func(stats *ExecutionStats)
+
+// Record 实现 StatsSink
+func (f StatsSinkFunc) Record(stats *ExecutionStats) {
+	f(stats)
+}
+
+// WithStatsSink 添加统计接收端，在 AfterExecute 钩子之前收到本次执行的统计
+func (p *Pipeline[C, Option, Payload, Result]) WithStatsSink(sinks ...StatsSink) *Pipeline[C, Option, Payload, Result] {
+	p.statsSinks = append(p.statsSinks, sinks...)
+	return p
+}
+
+// aggregateSet 按管道名称分组的累计统计
+type aggregateSet struct {
+	mu   sync.Mutex
+	aggs map[string]*AggregateStats
+}
+
+// record 累计到对应管道的统计
+func (s *aggregateSet) record(stats *ExecutionStats) {
+	s.mu.Lock()
+	if s.aggs == nil {
+		s.aggs = make(map[string]*AggregateStats)
+	}
+	agg, ok := s.aggs[stats.PipelineName]
+	if !ok {
+		agg = NewAggregateStats(stats.PipelineName)
+		s.aggs[stats.PipelineName] = agg
+	}
+	s.mu.Unlock()
+
+	agg.Record(stats)
+}
+
+// list 按管道名称排序返回所有累计统计
+func (s *aggregateSet) list() []*AggregateStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	aggs := make([]*AggregateStats, 0, len(s.aggs))
+	for _, agg := range s.aggs {
+		aggs = append(aggs, agg)
+	}
+	sort.Slice(aggs, func(i, j int) bool { return aggs[i].PipelineName < aggs[j].PipelineName })
+	return aggs
+}
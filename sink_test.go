@@ -0,0 +1,97 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// expvarRuns 发布 expvar 的测试运行次数
+var expvarRuns atomic.Int64
+
+// expvarName expvar 名称在进程内全局唯一，每次运行（-count）使用不同的名称
+func expvarName(prefix string) string {
+	return fmt.Sprintf("%s_%d", prefix, expvarRuns.Add(1))
+}
+
+// TestStatsSinks 测试统计接收端收到每次执行的统计
+func TestStatsSinks(t *testing.T) {
+	agg := NewAggregateStats("order")
+	metrics := NewOpenMetricsSink()
+	name := expvarName("pipeline_test_stats")
+	vars, err := NewExpvarSink(name)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var recorded int
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("order").
+		WithLabels(map[string]string{"team": "payments", "hook": "ignored"}).
+		AddNamedHook("process", processHook).
+		WithStatsSink(agg, metrics, vars, StatsSinkFunc(func(stats *ExecutionStats) {
+			recorded++
+		}))
+
+	for i := 0; i < 2; i++ {
+		if _, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if recorded != 2 || agg.Executions() != 2 {
+		t.Errorf("Expected 2 recorded executions, got %d and %d", recorded, agg.Executions())
+	}
+
+	// OpenMetrics 文本
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	if rec.Header().Get("Content-Type") != OpenMetricsContentType {
+		t.Errorf("Expected OpenMetrics content type, got %s", rec.Header().Get("Content-Type"))
+	}
+	for _, want := range []string{
+		`pipeline_executions_total{pipeline="order",team="payments"} 2`,
+		`pipeline_hook_calls_total{pipeline="order",team="payments",hook="process"} 2`,
+		"# EOF\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, body)
+		}
+	}
+
+	// expvar 输出
+	var published map[string]struct {
+		Executions int `json:"executions"`
+	}
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &published); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if published["order"].Executions != 2 {
+		t.Errorf("Expected 2 executions in expvar, got %+v", published)
+	}
+}
+
+// TestExpvarSinkDuplicateName 测试重复发布同名 expvar 不会 panic
+func TestExpvarSinkDuplicateName(t *testing.T) {
+	name, foreign := expvarName("pipeline_test_duplicate"), expvarName("pipeline_test_foreign")
+	first, err := NewExpvarSink(name)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, err := NewExpvarSink(name)
+	if err != nil || second != first {
+		t.Errorf("Expected the existing sink to be reused, got %p, %v", second, err)
+	}
+
+	expvar.NewInt(foreign)
+	if _, err := NewExpvarSink(foreign); !errors.Is(err, ErrExpvarExists) {
+		t.Errorf("Expected ErrExpvarExists, got %v", err)
+	}
+}
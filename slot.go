@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"fmt"
+	"slices"
+)
+
+// slotKind 扩展插槽占位 Hook 的类型
+const slotKind = "slot"
+
+// DefineSlot 在当前位置定义命名扩展插槽，其他模块通过 FillSlot 在此插入 Hook，
+// 无需了解内部 Hook 的索引。插槽本身不执行、不记录 HookStat；名称重复时 panic
+func (p *Pipeline[C, Option, Payload, Result]) DefineSlot(name string) *Pipeline[C, Option, Payload, Result] {
+	if p.slotIndex(name) >= 0 {
+		panic(fmt.Sprintf("pipeline: slot '%s' already defined", name))
+	}
+	p.hooks = append(p.hooks, &Hook[C, Option, Payload, Result]{Name: name, kind: slotKind})
+	return p
+}
+
+// FillSlot 向插槽追加 Hook（多次填充按调用顺序排列），插槽未定义时 panic
+// 插入的 Hook 与直接添加的 Hook 相同，经过中间件并记录 HookStat
+func (p *Pipeline[C, Option, Payload, Result]) FillSlot(
+	name string,
+	hooks ...*Hook[C, Option, Payload, Result],
+) *Pipeline[C, Option, Payload, Result] {
+	i := p.slotIndex(name)
+	if i < 0 {
+		panic(fmt.Sprintf("pipeline: slot '%s' not defined", name))
+	}
+	p.hooks = slices.Insert(p.hooks, i, hooks...)
+	return p
+}
+
+// Slots 按定义顺序返回所有插槽名称
+func (p *Pipeline[C, Option, Payload, Result]) Slots() []string {
+	var names []string
+	for _, hook := range p.hooks {
+		if hook.isSlot() {
+			names = append(names, hook.Name)
+		}
+	}
+	return names
+}
+
+// slotIndex 返回插槽占位 Hook 的索引，不存在时返回 -1
+func (p *Pipeline[C, Option, Payload, Result]) slotIndex(name string) int {
+	return slices.IndexFunc(p.hooks, func(hook *Hook[C, Option, Payload, Result]) bool {
+		return hook.isSlot() && hook.Name == name
+	})
+}
+
+// isSlot 是否为扩展插槽占位 Hook
+func (h *Hook[C, Option, Payload, Result]) isSlot() bool {
+	return h.kind == slotKind
+}
@@ -0,0 +1,102 @@
+package pipeline
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestSlot 测试扩展插槽的定义与填充
+func TestSlot(t *testing.T) {
+	var wrapped []string
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("core").
+		Use(func(next HookHandler[sylph.Context, TestOption, TestPayload, TestResult]) HookHandler[sylph.Context, TestOption, TestPayload, TestResult] {
+			return func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+				name, _ := pipeCtx.CurrentHook()
+				wrapped = append(wrapped, name)
+				return next(ctx, pipeCtx)
+			}
+		}).
+		AddNamedHook("load", appendHook("load")).
+		DefineSlot("enrichment").
+		AddNamedHook("persist", appendHook("persist")).
+		DefineSlot("notify")
+
+	pipeline.
+		FillSlot("enrichment", &testHook{Name: "geo", Handler: appendHook("geo")}).
+		FillSlot("enrichment", &testHook{Name: "score", Handler: appendHook("score")})
+
+	if slots := pipeline.Slots(); !reflect.DeepEqual(slots, []string{"enrichment", "notify"}) {
+		t.Errorf("Expected slots [enrichment notify], got %v", slots)
+	}
+	if desc := pipeline.Describe(); !strings.Contains(desc, "notify (slot)") {
+		t.Errorf("Expected slot in description, got:\n%s", desc)
+	}
+
+	var stats *ExecutionStats
+	pipeline.OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+		stats = pipeCtx.Stats()
+	})
+
+	result, err := pipeline.Execute(newMockContext(), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"load", "geo", "score", "persist"}
+	if !reflect.DeepEqual(result.Output, expected) {
+		t.Errorf("Expected %v, got %v", expected, result.Output)
+	}
+	if !reflect.DeepEqual(wrapped, expected) {
+		t.Errorf("Expected middleware around slot hooks %v, got %v", expected, wrapped)
+	}
+	if len(stats.HookStats) != len(expected) {
+		t.Errorf("Expected no HookStat for slots, got %d stats", len(stats.HookStats))
+	}
+}
+
+// TestSlotPanics 测试插槽的构建期错误
+func TestSlotPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(p *Pipeline[sylph.Context, TestOption, TestPayload, TestResult])
+	}{
+		{"duplicate", func(p *Pipeline[sylph.Context, TestOption, TestPayload, TestResult]) {
+			p.DefineSlot("a").DefineSlot("a")
+		}},
+		{"undefined", func(p *Pipeline[sylph.Context, TestOption, TestPayload, TestResult]) {
+			p.FillSlot("missing", &testHook{Handler: appendHook("x")})
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			tc.fn(NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("x"))
+		})
+	}
+}
+
+// TestTemplateSlotAfterBuild 测试模板实例 Build 后仍可填充插槽
+func TestTemplateSlotAfterBuild(t *testing.T) {
+	pipeline := NewTemplate[sylph.Context, TestOption, TestPayload, TestResult]().
+		AddNamedHook("core", appendHook("core")).
+		DefineSlot(SlotPostProcess).
+		New("x").
+		Build().
+		FillSlot(SlotPostProcess, &testHook{Name: "audit", Handler: appendHook("audit")})
+
+	result, err := pipeline.Execute(newMockContext(), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Output, []string{"core", "audit"}) {
+		t.Errorf("Expected [core audit], got %v", result.Output)
+	}
+}
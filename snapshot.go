@@ -0,0 +1,72 @@
+package pipeline
+
+import "maps"
+
+// Cloner 共享数据中需要按值快照的类型实现该接口
+// 快照时调用 Clone 保存副本，回滚时再次 Clone，使同一快照可以多次 Restore
+type Cloner interface {
+	Clone() any
+}
+
+// Snapshot 管道上下文快照：共享数据、Hook 输出、Result 和中断标记
+// Result 深拷贝；共享数据只复制 map 本身，值保持原有引用（事务、客户端等指针回滚后仍是同一个对象），
+// 实现 Cloner 的值按 Clone 复制。快照可以多次 Restore
+type Snapshot[Result any] struct {
+	data    dataMap
+	outputs map[string]any
+	result  *Result
+	abort   bool
+}
+
+// Snapshot 创建当前上下文的快照
+func (p *PipeContext[Option, Payload, Result]) Snapshot() *Snapshot[Result] {
+	p.state.mu.RLock()
+	data := p.state.copyData()
+	outputs := maps.Clone(p.state.outputs)
+	abort := p.state.abort.Load()
+	p.state.mu.RUnlock()
+	cloneValues(&data)
+
+	return &Snapshot[Result]{
+		data:    data,
+		outputs: outputs,
+		result:  DeepCopy(p.Result),
+		abort:   abort,
+	}
+}
+
+// Restore 将上下文回滚到快照时的状态
+func (p *PipeContext[Option, Payload, Result]) Restore(s *Snapshot[Result]) {
+	if s == nil {
+		return
+	}
+
+	data := s.data.clone()
+	cloneValues(&data)
+
+	p.state.mu.Lock()
+	p.state.replaceData(data)
+	p.state.outputs = maps.Clone(s.outputs)
+	if p.state.abort.Load() && !s.abort {
+		p.state.abortCh = nil // 已关闭的中断信号随中断标记一起撤销
+	}
+	p.state.abort.Store(s.abort)
+	p.state.mu.Unlock()
+
+	if !s.abort {
+		p.stats.setAbortInfo(nil)
+	}
+
+	if result := DeepCopy(s.result); result != nil {
+		*p.Result = *result
+	}
+}
+
+// cloneValues 将共享数据副本中的 Cloner 值替换为 Clone 的结果
+func cloneValues(data *dataMap) {
+	for key, value := range data.all() {
+		if c, ok := value.(Cloner); ok {
+			data.set(key, c.Clone())
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package pipeline
+
+import "testing"
+
+// TestSnapshotRestore 测试上下文快照与回滚
+func TestSnapshotRestore(t *testing.T) {
+	pipeCtx := NewPipeContext[TestOption, TestPayload, TestResult]("test", nil, &TestPayload{}, nil)
+	pipeCtx.Set("count", 1)
+	pipeCtx.Result.Output = []string{"a"}
+
+	snap := pipeCtx.Snapshot()
+
+	pipeCtx.Set("count", 2)
+	pipeCtx.Set("extra", true)
+	pipeCtx.Result.Output = append(pipeCtx.Result.Output, "b")
+	pipeCtx.Abort()
+
+	for i := 0; i < 2; i++ {
+		pipeCtx.Restore(snap)
+
+		if v, _ := pipeCtx.Get("count"); v != 1 {
+			t.Errorf("Expected count 1, got %v", v)
+		}
+		if _, ok := pipeCtx.Get("extra"); ok {
+			t.Error("Expected extra key removed")
+		}
+		if len(pipeCtx.Result.Output) != 1 {
+			t.Errorf("Expected output [a], got %v", pipeCtx.Result.Output)
+		}
+		if pipeCtx.IsAborted() {
+			t.Error("Expected abort flag restored")
+		}
+
+		pipeCtx.Result.Output = append(pipeCtx.Result.Output, "c")
+	}
+}
+
+type testCounter struct {
+	n int
+}
+
+func (c *testCounter) Clone() any {
+	return &testCounter{n: c.n}
+}
+
+// TestSnapshotKeepsReferences 测试快照回滚后指针值保持同一对象，Cloner 值按副本回滚
+func TestSnapshotKeepsReferences(t *testing.T) {
+	pipeCtx := NewPipeContext[TestOption, TestPayload, TestResult]("test", nil, &TestPayload{}, nil)
+
+	tx := &testRepo{prefix: "tx"}
+	counter := &testCounter{n: 1}
+	pipeCtx.Set("tx", tx)
+	pipeCtx.Set("counter", counter)
+
+	snap := pipeCtx.Snapshot()
+	counter.n = 5
+	pipeCtx.Restore(snap)
+
+	if v, _ := pipeCtx.Get("tx"); v != tx {
+		t.Errorf("Expected the same tx pointer after restore, got %p want %p", v, tx)
+	}
+	if v, _ := pipeCtx.Get("counter"); v.(*testCounter).n != 1 {
+		t.Errorf("Expected cloned counter value 1, got %d", v.(*testCounter).n)
+	}
+}
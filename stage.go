@@ -0,0 +1,202 @@
+package pipeline
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Stage 将一组 Hook 作为命名阶段依次添加，统计、事件、日志和文档按“阶段 -> Hook”两级展示，
+// 便于阅读数十个 Hook 的管道。阶段只用于分组，不改变执行方式：Hook 仍按顺序执行并各自记录 HookStat。
+// 添加的是 Hook 的副本；名称为空时 panic
+func (p *Pipeline[C, Option, Payload, Result]) Stage(
+	name string,
+	hooks ...*Hook[C, Option, Payload, Result],
+) *Pipeline[C, Option, Payload, Result] {
+	if name == "" {
+		panic(fmt.Sprintf("pipeline: stage name is empty in pipeline '%s'", p.Name))
+	}
+	for _, hook := range hooks {
+		staged := hook.clone()
+		staged.stage = name
+		p.hooks = append(p.hooks, staged)
+	}
+	return p
+}
+
+// StageStat 阶段统计：连续属于同一阶段的 Hook 统计
+type StageStat struct {
+	Name      string        // 阶段名称（未分组的 Hook 为空）
+	Hooks     []HookStat    // 阶段内各 Hook 的统计
+	Duration  time.Duration // 从第一个 Hook 开始到最后一个 Hook 结束的时长
+	Error     error         // 第一个失败 Hook 的错误（含被跳过的错误）
+	StartTime time.Time     // 开始时间
+	EndTime   time.Time     // 结束时间
+}
+
+// Stages 将 Hook 统计按阶段分组（相邻且阶段相同的 Hook 为一组），按执行顺序返回
+func (s *ExecutionStats) Stages() []StageStat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stages []StageStat
+	for _, hook := range s.HookStats {
+		if n := len(stages); n == 0 || stages[n-1].Name != hook.Stage {
+			stages = append(stages, StageStat{Name: hook.Stage, StartTime: hook.StartTime})
+		}
+
+		stage := &stages[len(stages)-1]
+		stage.Hooks = append(stage.Hooks, hook)
+		if hook.StartTime.Before(stage.StartTime) {
+			stage.StartTime = hook.StartTime
+		}
+		if hook.EndTime.After(stage.EndTime) {
+			stage.EndTime = hook.EndTime
+		}
+		stage.Duration = stage.EndTime.Sub(stage.StartTime)
+		if stage.Error == nil {
+			stage.Error = hook.Error
+		}
+	}
+	return stages
+}
+
+// CurrentStage 返回当前执行的 Hook 所属的阶段（未分组时为空）
+func (p *PipeContext[Option, Payload, Result]) CurrentStage() string {
+	p.hookMu.RLock()
+	defer p.hookMu.RUnlock()
+	return p.hookStage
+}
+
+// StagePolicy 命名阶段的执行策略：阶段内 Hook 的默认超时、错误处理方式和中间件增减，
+// 与管道级设置组合（如 notification 阶段尽力而为、persistence 阶段快速失败）
+type StagePolicy[C Context, Option any, Payload any, Result any] struct {
+	timeout     time.Duration
+	bestEffort  bool
+	failFast    bool
+	middlewares []Middleware[C, Option, Payload, Result]
+	without     []string
+}
+
+// NewStagePolicy 创建阶段策略
+func NewStagePolicy[C Context, Option any, Payload any, Result any]() *StagePolicy[C, Option, Payload, Result] {
+	return &StagePolicy[C, Option, Payload, Result]{}
+}
+
+// WithTimeout 设置阶段内 Hook 的默认超时（Hook 自身设置了 Timeout 时以 Hook 为准）
+func (s *StagePolicy[C, Option, Payload, Result]) WithTimeout(timeout time.Duration) *StagePolicy[C, Option, Payload, Result] {
+	s.timeout = timeout
+	return s
+}
+
+// BestEffort 阶段内 Hook 失败时记录错误后继续执行（相当于每个 Hook 都设置了 SkipOnError）
+func (s *StagePolicy[C, Option, Payload, Result]) BestEffort() *StagePolicy[C, Option, Payload, Result] {
+	s.bestEffort, s.failFast = true, false
+	return s
+}
+
+// FailFast 阶段内任一 Hook 失败都中断管道（忽略 Hook 的 SkipOnError、SkipOnErrorIf 和 Continue 决定）
+func (s *StagePolicy[C, Option, Payload, Result]) FailFast() *StagePolicy[C, Option, Payload, Result] {
+	s.failFast, s.bestEffort = true, false
+	return s
+}
+
+// Use 追加只作用于阶段内 Hook 的中间件（位于管道中间件之内）
+func (s *StagePolicy[C, Option, Payload, Result]) Use(
+	middlewares ...Middleware[C, Option, Payload, Result],
+) *StagePolicy[C, Option, Payload, Result] {
+	s.middlewares = append(s.middlewares, middlewares...)
+	return s
+}
+
+// WithoutMiddleware 阶段内 Hook 不经过指定名称的管道中间件（见 UseNamed）
+func (s *StagePolicy[C, Option, Payload, Result]) WithoutMiddleware(names ...string) *StagePolicy[C, Option, Payload, Result] {
+	s.without = append(s.without, names...)
+	return s
+}
+
+// WithStagePolicy 为命名阶段设置执行策略（可在 Stage 之前或之后调用，重复设置时覆盖）；名称为空时 panic
+func (p *Pipeline[C, Option, Payload, Result]) WithStagePolicy(
+	name string,
+	policy *StagePolicy[C, Option, Payload, Result],
+) *Pipeline[C, Option, Payload, Result] {
+	if name == "" {
+		panic(fmt.Sprintf("pipeline: stage name is empty in pipeline '%s'", p.Name))
+	}
+	if p.stagePolicies == nil {
+		p.stagePolicies = make(map[string]*StagePolicy[C, Option, Payload, Result])
+	}
+	p.stagePolicies[name] = policy
+	return p
+}
+
+// stagePolicy 返回 Hook 所属阶段的策略，未分组或未设置时为 nil
+func (p *Pipeline[C, Option, Payload, Result]) stagePolicy(hook *Hook[C, Option, Payload, Result]) *StagePolicy[C, Option, Payload, Result] {
+	if hook.stage == "" {
+		return nil
+	}
+	return p.stagePolicies[hook.stage]
+}
+
+// hookTimeout 返回 Hook 生效的超时：Hook 自身的设置优先，其次是阶段的默认超时
+func (p *Pipeline[C, Option, Payload, Result]) hookTimeout(hook *Hook[C, Option, Payload, Result]) time.Duration {
+	if hook.Timeout > 0 {
+		return hook.Timeout
+	}
+	if policy := p.stagePolicy(hook); policy != nil {
+		return policy.timeout
+	}
+	return 0
+}
+
+// skips 按阶段的错误策略与 Hook 自身的设置判断错误是否跳过
+func (p *Pipeline[C, Option, Payload, Result]) skips(hook *Hook[C, Option, Payload, Result], err error) bool {
+	if policy := p.stagePolicy(hook); policy != nil {
+		switch {
+		case policy.failFast:
+			return false
+		case policy.bestEffort:
+			return true
+		}
+	}
+	return hook.skips(err)
+}
+
+// overridesMiddlewares 策略是否增减了中间件
+func (s *StagePolicy[C, Option, Payload, Result]) overridesMiddlewares() bool {
+	return s != nil && (len(s.middlewares) > 0 || len(s.without) > 0)
+}
+
+// excludes 是否排除指定名称的管道中间件
+func (s *StagePolicy[C, Option, Payload, Result]) excludes(name string) bool {
+	return s != nil && name != "" && slices.Contains(s.without, name)
+}
+
+// describe 返回 Describe 中展示的策略摘要（如 " [best-effort, timeout 2s]"），未设置时为空
+func (s *StagePolicy[C, Option, Payload, Result]) describe() string {
+	if s == nil {
+		return ""
+	}
+
+	var items []string
+	switch {
+	case s.bestEffort:
+		items = append(items, "best-effort")
+	case s.failFast:
+		items = append(items, "fail-fast")
+	}
+	if s.timeout > 0 {
+		items = append(items, "timeout "+s.timeout.String())
+	}
+	if len(s.middlewares) > 0 {
+		items = append(items, fmt.Sprintf("+%d middleware", len(s.middlewares)))
+	}
+	for _, name := range s.without {
+		items = append(items, "-"+name)
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(items, ", ") + "]"
+}
@@ -0,0 +1,180 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestStage 测试命名阶段在统计、上下文和描述中的两级展示
+func TestStage(t *testing.T) {
+	seen := make(map[string]string)
+	record := func(name string) HookHandler[sylph.Context, TestOption, TestPayload, TestResult] {
+		return func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			seen[name] = pipeCtx.CurrentStage()
+			return appendHook(name)(ctx, pipeCtx)
+		}
+	}
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("order").
+		Stage("validation",
+			&testHook{Name: "schema", Handler: record("schema")},
+			&testHook{Name: "stock", Handler: record("stock")}).
+		AddNamedHook("audit", record("audit")).
+		Stage("payment",
+			&testHook{Name: "charge", Handler: func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+				return errors.New("declined")
+			}, SkipOnError: true})
+
+	var stats *ExecutionStats
+	pipeline.OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+		stats = pipeCtx.Stats()
+	})
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{"schema": "validation", "stock": "validation", "audit": ""}
+	if !reflect.DeepEqual(seen, expected) {
+		t.Errorf("Expected current stages %v, got %v", expected, seen)
+	}
+	if stats.HookStats[1].Stage != "validation" || stats.HookStats[2].Stage != "" {
+		t.Errorf("Unexpected hook stages: %+v", stats.HookStats)
+	}
+
+	stages := stats.Stages()
+	var names []string
+	for _, stage := range stages {
+		names = append(names, stage.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"validation", "", "payment"}) {
+		t.Fatalf("Expected stages [validation  payment], got %q", names)
+	}
+	if len(stages[0].Hooks) != 2 || stages[0].Error != nil {
+		t.Errorf("Unexpected validation stage: %+v", stages[0])
+	}
+	if stages[0].StartTime != stages[0].Hooks[0].StartTime || stages[0].EndTime != stages[0].Hooks[1].EndTime {
+		t.Errorf("Expected stage to span its hooks, got %+v", stages[0])
+	}
+	if stages[2].Error == nil || stages[2].Error.Error() != "declined" {
+		t.Errorf("Expected skipped error in payment stage, got %v", stages[2].Error)
+	}
+
+	desc := pipeline.Describe()
+	if !strings.Contains(desc, "  stage validation:\n    [0] schema\n    [1] stock\n  [2] audit\n  stage payment:\n    [3] charge\n") {
+		t.Errorf("Expected staged description, got:\n%s", desc)
+	}
+}
+
+// TestStageDocs 测试文档按阶段分组
+func TestStageDocs(t *testing.T) {
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("order").
+		AddNamedHook("load", appendHook("load")).
+		Stage("validation", &testHook{Name: "schema", Handler: appendHook("schema")})
+
+	doc := pipeline.ExportDocs()
+	if doc.Hooks[0].Stage != "" || doc.Hooks[1].Stage != "validation" {
+		t.Errorf("Unexpected doc stages: %+v", doc.Hooks)
+	}
+
+	md := doc.Markdown()
+	if !strings.Contains(md, "## 1. load\n") || !strings.Contains(md, "## Stage: validation\n\n### 2. schema\n") {
+		t.Errorf("Expected staged markdown, got:\n%s", md)
+	}
+}
+
+// TestStagePanics 测试阶段名称为空时 panic
+func TestStagePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for empty stage name")
+		}
+	}()
+	NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("order").Stage("")
+}
+
+// TestStagePolicyErrors 测试阶段的错误策略覆盖 Hook 自身的设置
+func TestStagePolicyErrors(t *testing.T) {
+	fail := func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+		return errors.New("unavailable")
+	}
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("order").
+		Stage("notification", &testHook{Name: "email", Handler: fail}).
+		Stage("persistence", &testHook{Name: "save", Handler: fail, SkipOnError: true}).
+		AddNamedHook("done", appendHook("done")).
+		WithStagePolicy("notification", NewStagePolicy[sylph.Context, TestOption, TestPayload, TestResult]().BestEffort()).
+		WithStagePolicy("persistence", NewStagePolicy[sylph.Context, TestOption, TestPayload, TestResult]().FailFast())
+
+	_, err := pipeline.Execute(newMockContext(), &TestPayload{})
+	var pipeErr *PipeError
+	if !errors.As(err, &pipeErr) || pipeErr.HookName != "save" {
+		t.Fatalf("Expected fail-fast stage to abort at save, got %v", err)
+	}
+
+	if desc := pipeline.Describe(); !strings.Contains(desc, "stage notification: [best-effort]") {
+		t.Errorf("Expected policy in description, got:\n%s", desc)
+	}
+}
+
+// TestStagePolicyTimeout 测试阶段默认超时，Hook 自身的超时优先
+func TestStagePolicyTimeout(t *testing.T) {
+	wait := func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+			return nil
+		}
+	}
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("order").
+		Stage("external",
+			&Hook[Context, NoOption, TestPayload, TestResult]{Name: "slow", Handler: wait, Timeout: time.Second},
+			&Hook[Context, NoOption, TestPayload, TestResult]{Name: "quote", Handler: wait}).
+		WithStagePolicy("external", NewStagePolicy[Context, NoOption, TestPayload, TestResult]().WithTimeout(10*time.Millisecond))
+
+	_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	var pipeErr *PipeError
+	if !errors.Is(err, ErrHookTimeout) || !errors.As(err, &pipeErr) || pipeErr.HookName != "quote" {
+		t.Errorf("Expected stage timeout on quote, got %v", err)
+	}
+}
+
+// TestStagePolicyMiddlewares 测试阶段追加与排除中间件
+func TestStagePolicyMiddlewares(t *testing.T) {
+	var calls []string
+	trace := func(label string) Middleware[sylph.Context, TestOption, TestPayload, TestResult] {
+		return func(next HookHandler[sylph.Context, TestOption, TestPayload, TestResult]) HookHandler[sylph.Context, TestOption, TestPayload, TestResult] {
+			return func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+				name, _ := pipeCtx.CurrentHook()
+				calls = append(calls, label+":"+name)
+				return next(ctx, pipeCtx)
+			}
+		}
+	}
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("order").
+		UseNamed("audit", trace("audit")).
+		Use(trace("log")).
+		AddNamedHook("load", appendHook("load")).
+		Stage("notification", &testHook{Name: "email", Handler: appendHook("email")}).
+		WithStagePolicy("notification", NewStagePolicy[sylph.Context, TestOption, TestPayload, TestResult]().
+			WithoutMiddleware("audit").
+			Use(trace("stage")))
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"audit:load", "log:load", "log:email", "stage:email"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected %v, got %v", expected, calls)
+	}
+}
@@ -0,0 +1,383 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Standby 可绑定到管道的预热资源池（由 StandbyPool 实现）
+type Standby interface {
+	// Warm 创建资源直到空闲数量达到目标值，并启动后台补充与过期回收
+	Warm(ctx context.Context) error
+	// Close 关闭资源池并释放所有空闲资源
+	Close() error
+
+	checkout(ctx context.Context) (value any, checkin func(), err error)
+}
+
+// StandbyPool 预热资源池：预先创建每次执行都需要、但创建代价高的资源（如检出的连接、解析好的配置），
+// 执行时直接取用，避免在请求路径上创建造成的 p99 延迟尖刺。
+// 取出时做健康检查，空闲超过 MaxIdle 的资源被回收，取出后在后台补足到目标数量
+type StandbyPool[T any] struct {
+	create  func(ctx context.Context) (T, error)
+	check   func(ctx context.Context, v T) error
+	release func(v T) error
+	size    int
+	maxIdle time.Duration
+
+	mu      sync.Mutex
+	idle    []standbyEntry[T] // 空闲资源（末尾最新）
+	filling int               // 后台正在创建的数量
+	started bool
+	closed  bool
+	stats   StandbyStats
+
+	refill chan struct{}
+	ctx    context.Context // 后台创建使用，Close 时取消
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// standbyEntry 空闲资源及其归还时间
+type standbyEntry[T any] struct {
+	value T
+	since time.Time
+}
+
+// StandbyStats 资源池统计
+type StandbyStats struct {
+	Idle      int // 当前空闲数量
+	Hits      int // 取到预热资源的次数
+	Misses    int // 没有可用资源、在请求路径上创建的次数
+	Created   int // 创建成功的次数（含后台补充）
+	Failed    int // 创建失败的次数
+	Evicted   int // 空闲超时被回收的数量
+	Unhealthy int // 健康检查失败被丢弃的数量
+}
+
+// StandbyOption 资源池选项
+type StandbyOption[T any] func(*StandbyPool[T])
+
+// StandbySize 设置保持的空闲资源数量（默认 1）
+func StandbySize[T any](n int) StandbyOption[T] {
+	return func(p *StandbyPool[T]) {
+		p.size = n
+	}
+}
+
+// StandbyMaxIdle 设置资源的最长空闲时间，超过后被回收（默认不回收）
+func StandbyMaxIdle[T any](d time.Duration) StandbyOption[T] {
+	return func(p *StandbyPool[T]) {
+		p.maxIdle = d
+	}
+}
+
+// StandbyHealthCheck 设置取出资源时的健康检查，失败的资源被释放并尝试下一个
+func StandbyHealthCheck[T any](check func(ctx context.Context, v T) error) StandbyOption[T] {
+	return func(p *StandbyPool[T]) {
+		p.check = check
+	}
+}
+
+// StandbyRelease 设置资源被回收、丢弃或资源池关闭时的释放函数（如关闭连接）
+func StandbyRelease[T any](release func(v T) error) StandbyOption[T] {
+	return func(p *StandbyPool[T]) {
+		p.release = release
+	}
+}
+
+// NewStandbyPool 创建预热资源池，create 为资源的创建函数
+// 创建后不会立即创建资源，需调用 Warm（绑定到管道时在 OnInit 中自动调用）
+func NewStandbyPool[T any](create func(ctx context.Context) (T, error), opts ...StandbyOption[T]) *StandbyPool[T] {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &StandbyPool[T]{
+		create: create,
+		size:   1,
+		refill: make(chan struct{}, 1),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Warm 创建资源直到空闲数量达到目标值，并启动后台补充与过期回收；返回首个创建错误
+func (p *StandbyPool[T]) Warm(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrStandbyClosed
+	}
+	if !p.started {
+		p.started = true
+		p.wg.Add(1)
+		go p.maintain()
+	}
+	p.mu.Unlock()
+
+	return p.fill(ctx)
+}
+
+// Acquire 取出一个资源：优先使用最近归还的空闲资源，没有可用资源时在当前 goroutine 中创建
+// 使用完后调用 Put 归还，资源已损坏时调用 Discard
+func (p *StandbyPool[T]) Acquire(ctx context.Context) (T, error) {
+	defer p.signal()
+
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			var zero T
+			return zero, ErrStandbyClosed
+		}
+		n := len(p.idle)
+		if n == 0 {
+			p.stats.Misses++
+			p.mu.Unlock()
+			break
+		}
+		entry := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mu.Unlock()
+
+		if p.expired(entry, time.Now()) {
+			p.drop(entry.value, &p.stats.Evicted)
+			continue
+		}
+		if p.check != nil {
+			if err := p.check(ctx, entry.value); err != nil {
+				p.drop(entry.value, &p.stats.Unhealthy)
+				continue
+			}
+		}
+
+		p.mu.Lock()
+		p.stats.Hits++
+		p.mu.Unlock()
+		return entry.value, nil
+	}
+
+	return p.newValue(ctx)
+}
+
+// Put 归还资源；空闲数量已满或资源池已关闭时释放该资源
+func (p *StandbyPool[T]) Put(v T) {
+	p.mu.Lock()
+	if p.closed || len(p.idle) >= p.size {
+		p.mu.Unlock()
+		p.releaseValue(v)
+		return
+	}
+	p.idle = append(p.idle, standbyEntry[T]{value: v, since: time.Now()})
+	p.mu.Unlock()
+}
+
+// Discard 释放不再可用的资源（不归还）
+func (p *StandbyPool[T]) Discard(v T) {
+	p.releaseValue(v)
+	p.signal()
+}
+
+// Stats 返回资源池统计
+func (p *StandbyPool[T]) Stats() StandbyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := p.stats
+	stats.Idle = len(p.idle)
+	return stats
+}
+
+// Close 停止后台补充并释放所有空闲资源，重复调用返回 nil；之后取出资源返回 ErrStandbyClosed
+func (p *StandbyPool[T]) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	p.cancel()
+	p.wg.Wait()
+
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	var errs []error
+	for _, entry := range idle {
+		if p.release != nil {
+			if err := safeCleanup(func() error { return p.release(entry.value) }); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkout 实现 Standby
+func (p *StandbyPool[T]) checkout(ctx context.Context) (any, func(), error) {
+	v, err := p.Acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, func() { p.Put(v) }, nil
+}
+
+// maintain 后台补充资源并回收过期的空闲资源，直到 Close
+func (p *StandbyPool[T]) maintain() {
+	defer p.wg.Done()
+
+	var tick <-chan time.Time
+	if p.maxIdle > 0 {
+		ticker := time.NewTicker(max(p.maxIdle/2, time.Millisecond))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-tick:
+			p.evict()
+		case <-p.refill:
+		}
+		// 创建失败时等待下一次取出或定时器再重试
+		_ = p.fill(p.ctx)
+	}
+}
+
+// fill 创建资源直到空闲数量（含正在创建的）达到目标值
+func (p *StandbyPool[T]) fill(ctx context.Context) error {
+	for {
+		p.mu.Lock()
+		if p.closed || len(p.idle)+p.filling >= p.size {
+			p.mu.Unlock()
+			return nil
+		}
+		p.filling++
+		p.mu.Unlock()
+
+		v, err := p.newValue(ctx)
+
+		p.mu.Lock()
+		p.filling--
+		p.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		p.Put(v)
+	}
+}
+
+// evict 回收空闲超时的资源
+func (p *StandbyPool[T]) evict() {
+	now := time.Now()
+	p.mu.Lock()
+	var expired []T
+	kept := p.idle[:0]
+	for _, entry := range p.idle {
+		if p.expired(entry, now) {
+			expired = append(expired, entry.value)
+		} else {
+			kept = append(kept, entry)
+		}
+	}
+	clear(p.idle[len(kept):])
+	p.idle = kept
+	p.mu.Unlock()
+
+	for _, v := range expired {
+		p.drop(v, &p.stats.Evicted)
+	}
+}
+
+// expired 资源是否已空闲超时
+func (p *StandbyPool[T]) expired(entry standbyEntry[T], now time.Time) bool {
+	return p.maxIdle > 0 && now.Sub(entry.since) > p.maxIdle
+}
+
+// newValue 创建资源并记录统计
+func (p *StandbyPool[T]) newValue(ctx context.Context) (T, error) {
+	v, err := p.create(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		p.stats.Failed++
+		return v, fmt.Errorf("standby create: %w", err)
+	}
+	p.stats.Created++
+	return v, nil
+}
+
+// drop 释放资源并累加对应的统计
+func (p *StandbyPool[T]) drop(v T, counter *int) {
+	p.mu.Lock()
+	*counter++
+	p.mu.Unlock()
+	p.releaseValue(v)
+}
+
+// releaseValue 调用释放函数（忽略错误与 panic）
+func (p *StandbyPool[T]) releaseValue(v T) {
+	if p.release != nil {
+		_ = safeCleanup(func() error { return p.release(v) })
+	}
+}
+
+// signal 通知后台补充资源（未启动后台补充时无操作）
+func (p *StandbyPool[T]) signal() {
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+}
+
+// standbyBinding 绑定到管道的资源池
+type standbyBinding struct {
+	key  string
+	pool Standby
+}
+
+// WithStandby 每次执行前从资源池取出一个预热资源，以 key 存入共享数据（Hook 通过 Get/MustGet 读取），
+// 执行结束、清理函数执行完后归还。资源池在首次执行时（OnInit）预热，随管道 Close 关闭
+func (p *Pipeline[C, Option, Payload, Result]) WithStandby(key string, pool Standby) *Pipeline[C, Option, Payload, Result] {
+	p.standby = append(p.standby, standbyBinding{key: key, pool: pool})
+	p.OnInit(func(ctx C) error {
+		return pool.Warm(ctx)
+	})
+	p.OnFinalize(pool.Close)
+	return p
+}
+
+// checkoutStandby 取出所有绑定的资源写入 data（共享数据的初始内容），返回归还函数；任一失败时归还已取出的资源
+func (p *Pipeline[C, Option, Payload, Result]) checkoutStandby(ctx C, data *dataMap) (func(), error) {
+	if len(p.standby) == 0 {
+		return func() {}, nil
+	}
+
+	checkins := make([]func(), 0, len(p.standby))
+	checkinAll := func() {
+		for _, checkin := range checkins {
+			checkin()
+		}
+	}
+	for _, binding := range p.standby {
+		v, checkin, err := binding.pool.checkout(ctx)
+		if err != nil {
+			checkinAll()
+			return nil, fmt.Errorf("pipeline '%s' standby '%s': %w", p.Name, binding.key, err)
+		}
+		data.set(binding.key, v)
+		checkins = append(checkins, checkin)
+	}
+	return checkinAll, nil
+}
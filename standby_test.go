@@ -0,0 +1,148 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// standbyConn 测试用的预热资源
+type standbyConn struct {
+	id      int
+	broken  bool
+	release bool
+}
+
+// newConnPool 创建记录所有资源的资源池
+func newConnPool(opts ...StandbyOption[*standbyConn]) (*StandbyPool[*standbyConn], func() []*standbyConn) {
+	var mu sync.Mutex
+	var conns []*standbyConn
+	create := func(ctx context.Context) (*standbyConn, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		conn := &standbyConn{id: len(conns) + 1}
+		conns = append(conns, conn)
+		return conn, nil
+	}
+	opts = append(opts, StandbyRelease(func(conn *standbyConn) error {
+		mu.Lock()
+		defer mu.Unlock()
+		conn.release = true
+		return nil
+	}))
+	return NewStandbyPool(create, opts...), func() []*standbyConn {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*standbyConn(nil), conns...)
+	}
+}
+
+// TestStandbyPipeline 测试管道执行使用预热资源，执行结束后归还，Close 时释放
+func TestStandbyPipeline(t *testing.T) {
+	pool, conns := newConnPool(StandbySize[*standbyConn](2))
+	var used []int
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("checkout").
+		WithStandby("conn", pool).
+		AddHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			used = append(used, pipeCtx.MustGet("conn").(*standbyConn).id)
+			return nil
+		})
+
+	for i := 0; i < 3; i++ {
+		if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if used[0] != used[1] || used[1] != used[2] {
+		t.Errorf("Expected returned resource reused, got %v", used)
+	}
+	if stats := pool.Stats(); stats.Hits != 3 || stats.Misses != 0 || stats.Idle != 2 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+
+	if err := pipeline.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+	for _, conn := range conns() {
+		if !conn.release {
+			t.Errorf("Expected conn %d released on Close", conn.id)
+		}
+	}
+	if _, err := pool.Acquire(context.Background()); !errors.Is(err, ErrStandbyClosed) {
+		t.Errorf("Expected ErrStandbyClosed, got %v", err)
+	}
+}
+
+// TestStandbyHealthCheck 测试健康检查失败的资源被丢弃，并在后台补充
+func TestStandbyHealthCheck(t *testing.T) {
+	pool, conns := newConnPool(
+		StandbySize[*standbyConn](2),
+		StandbyHealthCheck(func(ctx context.Context, conn *standbyConn) error {
+			if conn.broken {
+				return errors.New("broken")
+			}
+			return nil
+		}))
+	defer pool.Close()
+
+	if err := pool.Warm(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, conn := range conns() {
+		conn.broken = true
+	}
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if conn.broken || conn.id != 3 {
+		t.Errorf("Expected fresh conn, got %+v", conn)
+	}
+	pool.Put(conn)
+
+	stats := pool.Stats()
+	if stats.Unhealthy != 2 || stats.Misses != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	waitFor(t, func() bool { return pool.Stats().Idle == 2 })
+}
+
+// TestStandbyMaxIdle 测试空闲超时的资源被回收并补充
+func TestStandbyMaxIdle(t *testing.T) {
+	var created atomic.Int32
+	pool := NewStandbyPool(func(ctx context.Context) (int32, error) {
+		return created.Add(1), nil
+	}, StandbyMaxIdle[int32](20*time.Millisecond))
+	defer pool.Close()
+
+	if err := pool.Warm(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	waitFor(t, func() bool { return pool.Stats().Evicted > 0 && pool.Stats().Idle == 1 })
+
+	v, err := pool.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v == 1 {
+		t.Errorf("Expected expired resource replaced, got %d", v)
+	}
+}
+
+// waitFor 等待条件成立（最多 1 秒）
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 1s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
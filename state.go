@@ -0,0 +1,46 @@
+package pipeline
+
+// StateKey 类型化的执行状态槽位，供中间件和扩展包保存自身的执行期状态。
+// 通常以包内未导出变量声明；槽位按 key 指针区分，不同包之间不会冲突，
+// 值与共享数据分开保存（Keys/Range/DumpData 不可见，不参与重试快照回滚），分支上下文共享同一份
+type StateKey[T any] struct {
+	name string
+}
+
+// NewStateKey 创建状态槽位，name 只用于调试输出
+func NewStateKey[T any](name string) *StateKey[T] {
+	return &StateKey[T]{name: name}
+}
+
+// String 返回槽位名称
+func (k *StateKey[T]) String() string {
+	return k.name
+}
+
+// LoadState 读取槽位中的值，未写入时 ok 为 false
+func LoadState[T any, Option any, Payload any, Result any](pipeCtx *PipeContext[Option, Payload, Result], key *StateKey[T]) (value T, ok bool) {
+	pipeCtx.state.mu.RLock()
+	v, found := pipeCtx.state.slots[key]
+	pipeCtx.state.mu.RUnlock()
+	if !found {
+		return value, false
+	}
+	return v.(T), true
+}
+
+// StoreState 写入槽位
+func StoreState[T any, Option any, Payload any, Result any](pipeCtx *PipeContext[Option, Payload, Result], key *StateKey[T], value T) {
+	pipeCtx.state.mu.Lock()
+	defer pipeCtx.state.mu.Unlock()
+	if pipeCtx.state.slots == nil {
+		pipeCtx.state.slots = make(map[any]any)
+	}
+	pipeCtx.state.slots[key] = value
+}
+
+// DeleteState 清除槽位
+func DeleteState[T any, Option any, Payload any, Result any](pipeCtx *PipeContext[Option, Payload, Result], key *StateKey[T]) {
+	pipeCtx.state.mu.Lock()
+	defer pipeCtx.state.mu.Unlock()
+	delete(pipeCtx.state.slots, key)
+}
@@ -0,0 +1,46 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+)
+
+// TestStateKey 测试类型化状态槽位与共享数据隔离、按 key 区分且分支共享
+func TestStateKey(t *testing.T) {
+	counter := NewStateKey[int]("counter")
+	other := NewStateKey[int]("counter")
+
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		AddNamedHook("store", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			if _, ok := LoadState(pipeCtx, counter); ok {
+				t.Error("Expected empty slot before store")
+			}
+			StoreState(pipeCtx, counter, 1)
+			if len(pipeCtx.Keys()) != 0 {
+				t.Errorf("Expected slots outside shared data, got %v", pipeCtx.Keys())
+			}
+			if _, ok := LoadState(pipeCtx, other); ok {
+				t.Error("Expected keys with the same name to stay distinct")
+			}
+			return nil
+		}).
+		AddParallel("branch", Parallel(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			n, _ := LoadState(pipeCtx, counter)
+			StoreState(pipeCtx, counter, n+1)
+			return nil
+		})).
+		AddNamedHook("check", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			if n, ok := LoadState(pipeCtx, counter); !ok || n != 2 {
+				t.Errorf("Expected branch write visible, got %d, %v", n, ok)
+			}
+			DeleteState(pipeCtx, counter)
+			if _, ok := LoadState(pipeCtx, counter); ok {
+				t.Error("Expected slot cleared after delete")
+			}
+			return nil
+		})
+
+	if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -1,30 +1,131 @@
 package pipeline
 
-import "time"
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
 
 // ExecutionStats 管道执行统计信息
 type ExecutionStats struct {
-	PipelineName  string        // 管道名称
-	HookStats     []HookStat    // 各个 Hook 的统计
-	TotalDuration time.Duration // 总执行时间
-	StartTime     time.Time     // 开始时间
-	EndTime       time.Time     // 结束时间
-	Success       bool          // 是否成功
-	Error         error         // 错误信息（如果有）
+	PipelineName  string            // 管道名称
+	ExecutionID   string            // 执行 ID（每次执行随机生成）
+	Seed          uint64            // pipeCtx.Rand() 的随机种子（重放时通过 ContextWithSeed 复现）
+	Sampled       bool              // 是否为剖析采样的执行（见 WithProfiling）
+	Labels        map[string]string // 管道标签
+	HookStats     []HookStat        // 各个 Hook 的统计
+	Iterations    []IterationStat   // 循环各次迭代的统计
+	TotalDuration time.Duration     // 总执行时间
+	StartTime     time.Time         // 开始时间
+	EndTime       time.Time         // 结束时间
+	Success       bool              // 是否成功
+	Error         error             // 错误信息（如果有）
+	ErrorClass    ErrorClass        // 失败的错误分类（成功时为空）
+	Severity      Severity          // 失败的严重程度（成功时为空）
+	AbortInfo     *AbortInfo        // 中断信息（未中断时为 nil）
+	Cancelled     bool              // 是否因上下文取消（或超过截止时间）而结束
+	Cancellations []CancelStat      // 被兄弟分支的失败或中断取消的并行分支
+	Zombies       int               // 超时或取消后被放弃、仍在运行的 Hook goroutine 数量
+
+	mu sync.Mutex // 保护并行分支同时追加统计
 }
 
 // HookStat Hook 执行统计
 type HookStat struct {
-	Name      string        // Hook 名称
-	Index     int           // Hook 索引
+	Name       string         // Hook 名称
+	Index      int            // Hook 索引
+	Stage      string         // 所属的阶段（见 Stage，未分组时为空）
+	Variant    string         // 实验步骤选中的变体（见 Experiment）
+	Duration   time.Duration  // 执行时长
+	Handler    time.Duration  // Hook 本体耗时（不含中间件、超时等包装与降级处理，重试时累加）
+	Error      error          // 错误（如果有）
+	Class      ErrorClass     // 错误分类（无错误时为空）
+	Cancelled  bool           // 是否因上下文取消而未执行或被中止
+	Skipped    bool           // 是否被跳过（如已执行过的 Once Hook）
+	Fallback   bool           // 是否走了降级处理
+	Panicked   bool           // 是否因 panic 失败（含降级处理之前的主处理 panic）
+	Deprecated bool           // 是否为弃用 Hook（见 WithDeprecated）
+	Cause      error          // 触发降级的主处理错误
+	Profile    *HookProfile   // 采样执行的细粒度耗时（见 WithProfiling，未采样时为 nil）
+	Fields     map[string]any // Hook 通过 AddLogField 添加的结构化字段
+	StartTime  time.Time      // 开始时间
+	EndTime    time.Time      // 结束时间
+}
+
+// MiddlewareDuration 中间件等包装的耗时（执行时长减去 Hook 本体耗时）
+func (h HookStat) MiddlewareDuration() time.Duration {
+	return max(h.Duration-h.Handler, 0)
+}
+
+// IterationStat 循环单次迭代统计
+type IterationStat struct {
+	Name      string        // 循环 Hook 名称
+	Iteration int           // 迭代序号（从 0 开始）
 	Duration  time.Duration // 执行时长
 	Error     error         // 错误（如果有）
-	StartTime time.Time     // 开始时间
-	EndTime   time.Time     // 结束时间
+}
+
+// CancelStat 被取消的并行分支
+type CancelStat struct {
+	Name  string    // 分支名称（"并行 Hook 名称/branch i"）
+	Cause error     // 取消原因（失败分支的错误或 ErrAborted）
+	At    time.Time // 取消时间
+}
+
+// AbortInfo 中断信息
+type AbortInfo struct {
+	Hook   string    // 调用 Abort 的 Hook 名称
+	Index  int       // 调用 Abort 的 Hook 索引
+	Reason string    // 中断原因
+	Err    error     // 中断原因错误（可选）
+	At     time.Time // 中断时间
+}
+
+// setAbortInfo 记录中断信息（nil 表示清除）
+func (s *ExecutionStats) setAbortInfo(info *AbortInfo) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.AbortInfo = info
+}
+
+// AddIterationStat 添加循环迭代统计
+func (s *ExecutionStats) AddIterationStat(stat IterationStat) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Iterations = append(s.Iterations, stat)
+}
+
+// AddCancellation 记录被取消的分支
+func (s *ExecutionStats) AddCancellation(stat CancelStat) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Cancellations = append(s.Cancellations, stat)
+}
+
+// addZombie 记录一个被放弃的 Hook goroutine
+func (s *ExecutionStats) addZombie() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Zombies++
 }
 
 // AddHookStat 添加 Hook 统计
 func (s *ExecutionStats) AddHookStat(stat HookStat) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.HookStats = append(s.HookStats, stat)
 }
 
@@ -45,6 +146,14 @@ func (s *ExecutionStats) MarkEnd(err error) {
 func NewExecutionStats(pipelineName string) *ExecutionStats {
 	return &ExecutionStats{
 		PipelineName: pipelineName,
+		ExecutionID:  newExecutionID(),
 		HookStats:    make([]HookStat, 0),
 	}
 }
+
+// newExecutionID 生成随机执行 ID
+func newExecutionID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,216 @@
+package pipeline
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// statsJSON ExecutionStats 的 JSON 结构（字段名保持稳定）
+type statsJSON struct {
+	Pipeline   string            `json:"pipeline"`
+	ID         string            `json:"execution_id"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Success    bool              `json:"success"`
+	Error      string            `json:"error,omitempty"`
+	StartTime  time.Time         `json:"start_time"`
+	EndTime    time.Time         `json:"end_time"`
+	DurationMs float64           `json:"duration_ms"`
+	DurationNs int64             `json:"duration_ns"`
+	Sampled    bool              `json:"sampled,omitempty"`
+	Hooks      []hookStatJSON    `json:"hooks"`
+	Iterations []iterationJSON   `json:"iterations,omitempty"`
+	Abort      *abortJSON        `json:"abort,omitempty"`
+}
+
+// hookStatJSON HookStat 的 JSON 结构
+type hookStatJSON struct {
+	Name       string         `json:"name"`
+	Index      int            `json:"index"`
+	Stage      string         `json:"stage,omitempty"`
+	Variant    string         `json:"variant,omitempty"`
+	DurationMs float64        `json:"duration_ms"`
+	DurationNs int64          `json:"duration_ns"`
+	HandlerMs  float64        `json:"handler_ms"`
+	HandlerNs  int64          `json:"handler_ns"`
+	Error      string         `json:"error,omitempty"`
+	Skipped    bool           `json:"skipped,omitempty"`
+	Fallback   bool           `json:"fallback,omitempty"`
+	Panicked   bool           `json:"panicked,omitempty"`
+	Deprecated bool           `json:"deprecated,omitempty"`
+	Cause      string         `json:"cause,omitempty"`
+	Fields     map[string]any `json:"fields,omitempty"`
+	Profile    *profileJSON   `json:"profile,omitempty"`
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    time.Time      `json:"end_time"`
+}
+
+// profileJSON HookProfile 的 JSON 结构
+type profileJSON struct {
+	HandlerMs float64     `json:"handler_ms"`
+	HandlerNs int64       `json:"handler_ns"`
+	Layers    []layerJSON `json:"layers,omitempty"`
+}
+
+// layerJSON LayerTime 的 JSON 结构
+type layerJSON struct {
+	Name       string  `json:"name"`
+	DurationMs float64 `json:"duration_ms"`
+	DurationNs int64   `json:"duration_ns"`
+}
+
+// iterationJSON IterationStat 的 JSON 结构
+type iterationJSON struct {
+	Name       string  `json:"name"`
+	Iteration  int     `json:"iteration"`
+	DurationMs float64 `json:"duration_ms"`
+	DurationNs int64   `json:"duration_ns"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// abortJSON AbortInfo 的 JSON 结构
+type abortJSON struct {
+	Hook   string    `json:"hook"`
+	Index  int       `json:"index"`
+	Reason string    `json:"reason,omitempty"`
+	Error  string    `json:"error,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// newProfileJSON 转换 HookProfile，未采样时为 nil
+func newProfileJSON(p *HookProfile) *profileJSON {
+	if p == nil {
+		return nil
+	}
+	out := &profileJSON{HandlerMs: millis(p.Handler), HandlerNs: p.Handler.Nanoseconds()}
+	for _, layer := range p.Layers {
+		out.Layers = append(out.Layers, layerJSON{
+			Name:       layer.Name,
+			DurationMs: millis(layer.Duration),
+			DurationNs: layer.Duration.Nanoseconds(),
+		})
+	}
+	return out
+}
+
+// MarshalJSON 以稳定的字段名序列化执行统计，耗时同时输出毫秒和纳秒，错误输出为字符串
+func (s *ExecutionStats) MarshalJSON() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := statsJSON{
+		Pipeline:   s.PipelineName,
+		ID:         s.ExecutionID,
+		Labels:     s.Labels,
+		Success:    s.Success,
+		Error:      errString(s.Error),
+		StartTime:  s.StartTime,
+		EndTime:    s.EndTime,
+		DurationMs: millis(s.TotalDuration),
+		DurationNs: s.TotalDuration.Nanoseconds(),
+		Sampled:    s.Sampled,
+		Hooks:      make([]hookStatJSON, 0, len(s.HookStats)),
+	}
+
+	for _, h := range s.HookStats {
+		out.Hooks = append(out.Hooks, hookStatJSON{
+			Name:       h.Name,
+			Index:      h.Index,
+			Stage:      h.Stage,
+			Variant:    h.Variant,
+			DurationMs: millis(h.Duration),
+			DurationNs: h.Duration.Nanoseconds(),
+			HandlerMs:  millis(h.Handler),
+			HandlerNs:  h.Handler.Nanoseconds(),
+			Error:      errString(h.Error),
+			Skipped:    h.Skipped,
+			Fallback:   h.Fallback,
+			Panicked:   h.Panicked,
+			Deprecated: h.Deprecated,
+			Cause:      errString(h.Cause),
+			Fields:     h.Fields,
+			Profile:    newProfileJSON(h.Profile),
+			StartTime:  h.StartTime,
+			EndTime:    h.EndTime,
+		})
+	}
+
+	for _, it := range s.Iterations {
+		out.Iterations = append(out.Iterations, iterationJSON{
+			Name:       it.Name,
+			Iteration:  it.Iteration,
+			DurationMs: millis(it.Duration),
+			DurationNs: it.Duration.Nanoseconds(),
+			Error:      errString(it.Error),
+		})
+	}
+
+	if s.AbortInfo != nil {
+		out.Abort = &abortJSON{
+			Hook:   s.AbortInfo.Hook,
+			Index:  s.AbortInfo.Index,
+			Reason: s.AbortInfo.Reason,
+			Error:  errString(s.AbortInfo.Err),
+			At:     s.AbortInfo.At,
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+// statsCSVHeader WriteCSV 输出的列
+var statsCSVHeader = []string{
+	"pipeline", "index", "name", "duration_ms", "duration_ns",
+	"skipped", "fallback", "error", "start_time", "end_time", "stage",
+	"handler_ns", "middleware_ns",
+}
+
+// WriteCSV 以 CSV 格式输出 Hook 级别的统计（每个 Hook 一行，含表头）
+func (s *ExecutionStats) WriteCSV(w io.Writer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(statsCSVHeader); err != nil {
+		return err
+	}
+
+	for _, h := range s.HookStats {
+		row := []string{
+			s.PipelineName,
+			strconv.Itoa(h.Index),
+			h.Name,
+			strconv.FormatFloat(millis(h.Duration), 'f', -1, 64),
+			strconv.FormatInt(h.Duration.Nanoseconds(), 10),
+			strconv.FormatBool(h.Skipped),
+			strconv.FormatBool(h.Fallback),
+			errString(h.Error),
+			h.StartTime.Format(time.RFC3339Nano),
+			h.EndTime.Format(time.RFC3339Nano),
+			h.Stage,
+			strconv.FormatInt(h.Handler.Nanoseconds(), 10),
+			strconv.FormatInt(h.MiddlewareDuration().Nanoseconds(), 10),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// millis 将耗时转换为毫秒（保留小数）
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// errString 错误转为字符串，nil 为空串
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestStatsMarshalJSON 测试执行统计的 JSON 导出
+func TestStatsMarshalJSON(t *testing.T) {
+	stats := NewExecutionStats("test")
+	stats.MarkStart()
+	stats.AddHookStat(HookStat{Name: "load", Index: 0, Duration: 1500 * time.Microsecond})
+	stats.AddHookStat(HookStat{Name: "save", Index: 1, Error: errors.New("db down")})
+	stats.MarkEnd(errors.New("db down"))
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var decoded struct {
+		Pipeline string `json:"pipeline"`
+		Error    string `json:"error"`
+		Hooks    []struct {
+			Name       string  `json:"name"`
+			DurationMs float64 `json:"duration_ms"`
+			DurationNs int64   `json:"duration_ns"`
+			Error      string  `json:"error"`
+		} `json:"hooks"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if decoded.Pipeline != "test" || decoded.Error != "db down" {
+		t.Errorf("Expected pipeline and error string, got %s", data)
+	}
+	if len(decoded.Hooks) != 2 || decoded.Hooks[0].DurationMs != 1.5 || decoded.Hooks[0].DurationNs != 1500000 {
+		t.Errorf("Expected hook durations in ms and ns, got %s", data)
+	}
+	if decoded.Hooks[1].Error != "db down" {
+		t.Errorf("Expected hook error string, got %s", data)
+	}
+}
+
+// TestStatsWriteCSV 测试 Hook 级别统计的 CSV 导出
+func TestStatsWriteCSV(t *testing.T) {
+	stats := NewExecutionStats("test")
+	stats.AddHookStat(HookStat{Name: "load", Index: 0, Duration: 2 * time.Millisecond})
+	stats.AddHookStat(HookStat{Name: "save", Index: 1, Error: errors.New("db down")})
+
+	var buf bytes.Buffer
+	if err := stats.WriteCSV(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d", len(rows))
+	}
+	if rows[1][2] != "load" || rows[1][3] != "2" {
+		t.Errorf("Expected load row with 2ms, got %v", rows[1])
+	}
+	if rows[2][7] != "db down" {
+		t.Errorf("Expected error column, got %v", rows[2])
+	}
+}
@@ -0,0 +1,57 @@
+package pipeline
+
+// StepFunc 纯函数步骤
+// 只读取 Payload，返回对 Result 的增量修改（delta），不直接接触 PipeContext，
+// 便于单独对步骤进行单元测试。delta 为 nil 表示不修改 Result。
+type StepFunc[C Context, Payload any, Result any] func(
+	ctx C,
+	payload *Payload,
+) (delta func(result *Result), err error)
+
+// CheckFunc 纯函数校验步骤
+// 只读取 Payload，返回 error 表示校验失败
+type CheckFunc[C Context, Payload any] func(ctx C, payload *Payload) error
+
+// StepHandler 将 StepFunc 适配为 HookHandler
+// 仅当步骤成功时才将 delta 应用到 Result 上
+func StepHandler[C Context, Option any, Payload any, Result any](
+	fn StepFunc[C, Payload, Result],
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+		delta, err := fn(ctx, pipeCtx.Payload)
+		if err != nil {
+			return err
+		}
+
+		if delta != nil {
+			delta(pipeCtx.Result)
+		}
+
+		return nil
+	}
+}
+
+// CheckHandler 将 CheckFunc 适配为 HookHandler
+func CheckHandler[C Context, Option any, Payload any, Result any](
+	fn CheckFunc[C, Payload],
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+		return fn(ctx, pipeCtx.Payload)
+	}
+}
+
+// AddStep 添加纯函数步骤
+func (p *Pipeline[C, Option, Payload, Result]) AddStep(
+	name string,
+	fn StepFunc[C, Payload, Result],
+) *Pipeline[C, Option, Payload, Result] {
+	return p.AddNamedHook(name, StepHandler[C, Option](fn))
+}
+
+// AddCheck 添加纯函数校验步骤
+func (p *Pipeline[C, Option, Payload, Result]) AddCheck(
+	name string,
+	fn CheckFunc[C, Payload],
+) *Pipeline[C, Option, Payload, Result] {
+	return p.AddNamedHook(name, CheckHandler[C, Option, Payload, Result](fn))
+}
@@ -0,0 +1,71 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestAddStep 测试纯函数步骤
+func TestAddStep(t *testing.T) {
+	appendData := func(ctx sylph.Context, payload *TestPayload) (func(*TestResult), error) {
+		data := payload.Data
+		return func(r *TestResult) {
+			r.Output = append(r.Output, data)
+		}, nil
+	}
+
+	noop := func(ctx sylph.Context, payload *TestPayload) (func(*TestResult), error) {
+		return nil, nil
+	}
+
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddCheck("validate", func(ctx sylph.Context, payload *TestPayload) error {
+			if payload.UserID <= 0 {
+				return errors.New("invalid user ID")
+			}
+			return nil
+		}).
+		AddStep("append", appendData).
+		AddStep("noop", noop)
+
+	result, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1, Data: "step"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.Output) != 1 || result.Output[0] != "step" {
+		t.Errorf("Expected output [step], got %v", result.Output)
+	}
+
+	_, err = pipeline.Execute(newMockContext(), &TestPayload{UserID: 0})
+	var pipeErr *PipeError
+	if !errors.As(err, &pipeErr) || pipeErr.HookName != "validate" {
+		t.Errorf("Expected failure at 'validate', got %v", err)
+	}
+}
+
+// TestStepErrorDiscardsDelta 测试步骤失败时不应用 delta
+func TestStepErrorDiscardsDelta(t *testing.T) {
+	failing := func(ctx sylph.Context, payload *TestPayload) (func(*TestResult), error) {
+		return func(r *TestResult) {
+			r.Output = append(r.Output, "should-not-apply")
+		}, errors.New("step failed")
+	}
+
+	var output []string
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHookWithOptions(NewHook(StepHandler[sylph.Context, TestOption](failing)).SkipOnError().Build()).
+		OnAfterExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult], err error) {
+			output = pipeCtx.Result.Output
+		})
+
+	if _, err := pipeline.Execute(newMockContext(), &TestPayload{UserID: 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(output) != 0 {
+		t.Errorf("Expected delta to be discarded, got %v", output)
+	}
+}
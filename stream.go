@@ -0,0 +1,71 @@
+package pipeline
+
+import "fmt"
+
+// streamBuffer ExecuteStream 更新通道的缓冲大小
+const streamBuffer = 16
+
+// ResultUpdate 流式执行中的一次更新
+// Hook 通过 pipeCtx.Emit 发出中间更新；最后一次更新 Done 为 true，携带最终 Result 和错误
+type ResultUpdate[Result any] struct {
+	Hook   string  // 发出更新的 Hook（最后一次更新为空）
+	Data   any     // Emit 的内容
+	Result *Result // 最终结果（仅最后一次更新）
+	Err    error   // 最终错误（仅最后一次更新）
+	Done   bool    // 是否为最后一次更新
+}
+
+// resultStream 流式执行的更新通道
+type resultStream[Result any] struct {
+	updates chan ResultUpdate[Result]
+	done    <-chan struct{} // 调用方上下文取消后不再发送
+}
+
+// send 发送更新，调用方上下文取消时放弃
+func (s *resultStream[Result]) send(update ResultUpdate[Result]) {
+	select {
+	case s.updates <- update:
+	case <-s.done:
+	}
+}
+
+// ExecuteStream 在后台执行管道，返回实时更新通道（如进度界面、SSE），Hook 通过 pipeCtx.Emit 推送中间更新。
+// 最后一次更新 Done 为 true 并携带最终 Result 和错误，之后通道关闭；Hook 的 panic 转换为最终错误。
+// 通道有缓冲，调用方消费过慢时 Emit 阻塞（背压）；ctx 取消后未送达的更新被丢弃、执行照常结束。
+// 管道已关闭时返回 ErrPipelineClosed；Close 会等待进行中的流式执行完成
+func (p *Pipeline[C, Option, Payload, Result]) ExecuteStream(
+	ctx C,
+	payload *Payload,
+) (<-chan ResultUpdate[Result], error) {
+	p.lifecycle.mu.Lock()
+	if p.lifecycle.closed.Load() {
+		p.lifecycle.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrPipelineClosed, p.Name)
+	}
+	p.lifecycle.inflight.Add(1)
+	p.lifecycle.mu.Unlock()
+
+	stream := &resultStream[Result]{
+		updates: make(chan ResultUpdate[Result], streamBuffer),
+		done:    ctx.Done(),
+	}
+
+	go func() {
+		defer p.lifecycle.inflight.Done()
+		defer close(stream.updates)
+
+		result, err := p.executeRecovered(ctx, payload, stream)
+		stream.send(ResultUpdate[Result]{Result: result, Err: err, Done: true})
+	}()
+
+	return stream.updates, nil
+}
+
+// Emit 向流式执行（ExecuteStream）的调用方推送中间更新，非流式执行时为空操作
+func (p *PipeContext[Option, Payload, Result]) Emit(update any) {
+	if p.stream == nil {
+		return
+	}
+	name, _ := p.CurrentHook()
+	p.stream.send(ResultUpdate[Result]{Hook: name, Data: update})
+}
@@ -0,0 +1,82 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestExecuteStream 测试流式执行推送中间更新和最终结果
+func TestExecuteStream(t *testing.T) {
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		AddNamedHook("load", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			pipeCtx.Emit(50)
+			return nil
+		}).
+		AddNamedHook("save", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			pipeCtx.Emit(100)
+			pipeCtx.Result.Output = append(pipeCtx.Result.Output, "saved")
+			return nil
+		})
+
+	updates, err := pipeline.ExecuteStream(WrapContext(context.Background()), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var got []ResultUpdate[TestResult]
+	for update := range updates {
+		got = append(got, update)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("Expected 2 updates and a final one, got %+v", got)
+	}
+	if got[0].Hook != "load" || got[0].Data != 50 || got[1].Hook != "save" || got[1].Data != 100 {
+		t.Errorf("Unexpected progress updates %+v", got[:2])
+	}
+	final := got[2]
+	if !final.Done || final.Err != nil || len(final.Result.Output) != 1 {
+		t.Errorf("Unexpected final update %+v", final)
+	}
+
+	// 非流式执行时 Emit 为空操作
+	if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+// TestExecuteStreamCancel 测试调用方取消后执行照常结束
+func TestExecuteStreamCancel(t *testing.T) {
+	emitted := make(chan struct{})
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		AddNamedHook("chatty", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			for i := 0; i < 100; i++ {
+				pipeCtx.Emit(i) // 无人消费，缓冲满后等待取消
+			}
+			close(emitted)
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := pipeline.ExecuteStream(WrapContext(ctx), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	<-updates // Hook 已开始执行
+	cancel()
+
+	select {
+	case <-emitted:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Emit to stop blocking after cancel")
+	}
+	if err := pipeline.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	if _, err := pipeline.ExecuteStream(WrapContext(context.Background()), &TestPayload{}); !errors.Is(err, ErrPipelineClosed) {
+		t.Errorf("Expected ErrPipelineClosed, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package pipeline
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// WithStrictData 开启共享数据严格模式（调试用）：
+// Hook 读取并行组中其他分支写入的键（并行组尚未结束），或管道结束后仍写入共享数据时 panic，
+// 并报告相关的 Hook 名称，尽早暴露执行顺序上的问题
+func (p *Pipeline[C, Option, Payload, Result]) WithStrictData() *Pipeline[C, Option, Payload, Result] {
+	p.strictData = true
+	return p
+}
+
+// strictData 严格模式下共享数据的写入记录
+type strictData struct {
+	pipeline  string
+	mu        sync.Mutex
+	writers   map[string]dataWriter // 键 -> 最后一次写入
+	completed bool                  // 管道是否已结束
+}
+
+// dataWriter 共享数据的写入者
+type dataWriter struct {
+	hook  string
+	scope *dataScope
+}
+
+// dataScope 数据作用域：管道主流程或并行分支，分支的 parent 为派生它的作用域
+type dataScope struct {
+	name   string
+	parent *dataScope
+	done   atomic.Bool // 所在并行组已结束
+}
+
+// newStrictData 创建严格模式记录
+func newStrictData(pipeline string) *strictData {
+	return &strictData{pipeline: pipeline, writers: make(map[string]dataWriter)}
+}
+
+// complete 标记管道结束，之后的写入会 panic（s 为 nil 时为空操作）
+func (s *strictData) complete() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.completed = true
+}
+
+// encloses 判断 s 是否为 other 自身或其祖先
+func (s *dataScope) encloses(other *dataScope) bool {
+	for scope := other; scope != nil; scope = scope.parent {
+		if scope == s {
+			return true
+		}
+	}
+	return false
+}
+
+// finish 标记作用域结束（s 为 nil 时为空操作）
+func (s *dataScope) finish() {
+	if s != nil {
+		s.done.Store(true)
+	}
+}
+
+// beginScope 为并行分支创建子作用域（未开启严格模式时返回 nil）
+func (p *PipeContext[Option, Payload, Result]) beginScope(name string) *dataScope {
+	if p.state.strict == nil {
+		return nil
+	}
+	p.scope = &dataScope{name: name, parent: p.scope}
+	return p.scope
+}
+
+// dataHook 当前访问共享数据的 Hook（并行分支中为分支名称）
+func (p *PipeContext[Option, Payload, Result]) dataHook() string {
+	if p.scope != nil && p.scope.parent != nil {
+		return p.scope.name
+	}
+	name, _ := p.CurrentHook()
+	return name
+}
+
+// checkWrite 严格模式下记录写入，管道结束后写入时 panic
+func (p *PipeContext[Option, Payload, Result]) checkWrite(key string) {
+	strict := p.state.strict
+	if strict == nil {
+		return
+	}
+
+	hook := p.dataHook()
+	strict.mu.Lock()
+	defer strict.mu.Unlock()
+	if strict.completed {
+		panic(fmt.Sprintf("pipeline '%s': hook '%s' wrote key '%s' after the pipeline completed",
+			strict.pipeline, hook, key))
+	}
+	strict.writers[key] = dataWriter{hook: hook, scope: p.scope}
+}
+
+// checkRead 严格模式下读取其他未结束并行分支写入的键时 panic
+func (p *PipeContext[Option, Payload, Result]) checkRead(key string) {
+	strict := p.state.strict
+	if strict == nil {
+		return
+	}
+
+	strict.mu.Lock()
+	writer, ok := strict.writers[key]
+	strict.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	// 写入者与读取者的共同祖先之下，写入者所在的各层并行组都必须已结束
+	for scope := writer.scope; scope != nil && !scope.encloses(p.scope); scope = scope.parent {
+		if !scope.done.Load() {
+			panic(fmt.Sprintf("pipeline '%s': hook '%s' read key '%s' written by unfinished parallel hook '%s'",
+				strict.pipeline, p.dataHook(), key, writer.hook))
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestStrictDataParallelRead 测试读取未结束并行分支写入的键
+func TestStrictDataParallelRead(t *testing.T) {
+	written := make(chan struct{})
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("strict").
+		WithStrictData().
+		AddHookWithOptions(Parallel[Context, NoOption, TestPayload, TestResult]().
+			Branch(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+				pipeCtx.Set("price", 10)
+				close(written)
+				time.Sleep(20 * time.Millisecond)
+				return nil
+			}).
+			Branch(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+				<-written
+				pipeCtx.Get("price")
+				return nil
+			}).
+			Build("fanout"))
+
+	_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expected PanicError, got %v", err)
+	}
+	msg := panicErr.Error()
+	for _, want := range []string{"'fanout/branch 1' read key 'price'", "unfinished parallel hook 'fanout/branch 0'"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected panic to contain %q, got %s", want, msg)
+		}
+	}
+}
+
+// TestStrictDataAllowsOrderedAccess 测试按顺序的读写不受影响
+func TestStrictDataAllowsOrderedAccess(t *testing.T) {
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("strict").
+		WithStrictData().
+		AddNamedHook("load", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			pipeCtx.Set("user", "u1")
+			return nil
+		}).
+		AddHookWithOptions(Parallel[Context, NoOption, TestPayload, TestResult]().
+			Branch(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+				pipeCtx.MustGet("user") // 上游写入
+				pipeCtx.Set("a", 1)
+				pipeCtx.Get("a") // 本分支写入
+				return nil
+			}).
+			Branch(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+				pipeCtx.Set("b", 2)
+				return nil
+			}).
+			Build("fanout")).
+		AddNamedHook("merge", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			pipeCtx.MustGet("a") // 并行组已结束
+			pipeCtx.MustGet("b")
+			return nil
+		})
+
+	if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+// TestStrictDataWriteAfterCompletion 测试管道结束后写入共享数据
+func TestStrictDataWriteAfterCompletion(t *testing.T) {
+	var leaked *SimplePipeContext[TestPayload, TestResult]
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("strict").
+		WithStrictData().
+		AddNamedHook("leak", func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+			leaked = pipeCtx
+			return nil
+		})
+
+	if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(r.(string), "hook 'leak' wrote key 'late' after the pipeline completed") {
+			t.Errorf("Expected write-after-completion panic, got %v", r)
+		}
+	}()
+	leaked.Set("late", 1)
+}
@@ -0,0 +1,59 @@
+// Package sylphctx 将 sylph.Context 的请求头与 JWT 声明注入 PipeContext，
+// 业务 Hook 通过 pipeCtx.Request() / pipeCtx.Identity() 读取，不再直接依赖 sylph。
+package sylphctx
+
+import (
+	"github.com/sylphbyte/sylph"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// 注册 sylph.Context 的超时派生，使 Hook 超时可以取消 sylph 上下文并保留其具体类型
+func init() {
+	pipe.RegisterContextDeriver(sylph.WithTimeout)
+}
+
+// headerCarrier 能提供请求头的上下文
+type headerCarrier interface {
+	TakeHeader() sylph.IHeader
+}
+
+// claimCarrier 能提供 JWT 声明的上下文
+type claimCarrier interface {
+	JwtClaim() sylph.IJwtClaim
+}
+
+// Inject 返回 OnBeforeExecute 回调：执行开始时从 ctx 读取请求头和 JWT 声明并注入 PipeContext
+// ctx 未提供对应方法或值为空时跳过
+func Inject[C pipe.Context, Option any, Payload any, Result any]() func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) {
+	return func(ctx C, pipeCtx *pipe.PipeContext[Option, Payload, Result]) {
+		if carrier, ok := any(ctx).(headerCarrier); ok {
+			if header := carrier.TakeHeader(); header != nil {
+				pipeCtx.SetRequest(pipe.RequestInfo{
+					Endpoint: string(header.Endpoint()),
+					Path:     header.Path(),
+					Mark:     header.Mark(),
+					TraceID:  header.TraceId(),
+					Ref:      header.Ref(),
+				})
+			}
+		}
+
+		if carrier, ok := any(ctx).(claimCarrier); ok {
+			if claim := carrier.JwtClaim(); claim != nil {
+				pipeCtx.SetIdentity(pipe.Identity{
+					ID:     claim.TakeId(),
+					Token:  claim.TakeToken(),
+					Issuer: claim.TakeIssuer(),
+				})
+			}
+		}
+	}
+}
+
+// Enable 为管道启用注入
+func Enable[C pipe.Context, Option any, Payload any, Result any](
+	p *pipe.Pipeline[C, Option, Payload, Result],
+) *pipe.Pipeline[C, Option, Payload, Result] {
+	return p.OnBeforeExecute(Inject[C, Option, Payload, Result]())
+}
@@ -0,0 +1,55 @@
+package sylphctx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+type testClaim struct{}
+
+func (testClaim) TakeId() string            { return "u1" }
+func (testClaim) TakeToken() string         { return "token" }
+func (testClaim) TakeIssuer() string        { return "auth" }
+func (testClaim) IssuerIs(name string) bool { return name == "auth" }
+
+// testContext 提供请求头和 JWT 声明的 pipe.Context
+type testContext struct {
+	pipe.Context
+}
+
+func (testContext) TakeHeader() sylph.IHeader {
+	return &sylph.Header{EndpointVal: sylph.EndpointWeb, PathVal: "/orders", TraceIdVal: "trace-1"}
+}
+
+func (testContext) JwtClaim() sylph.IJwtClaim { return testClaim{} }
+
+// TestInject 测试请求头和身份注入
+func TestInject(t *testing.T) {
+	var (
+		request  pipe.RequestInfo
+		identity pipe.Identity
+	)
+
+	pipeline := Enable(pipe.NewPipeline[testContext, pipe.NoOption, struct{}, struct{}]("test")).
+		AddHook(func(ctx testContext, pipeCtx *pipe.PipeContext[pipe.NoOption, struct{}, struct{}]) error {
+			request, _ = pipeCtx.Request()
+			identity, _ = pipeCtx.Identity()
+			return nil
+		})
+
+	ctx := testContext{Context: pipe.WrapContext(context.Background())}
+	if _, err := pipeline.Execute(ctx, &struct{}{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if request.Path != "/orders" || request.TraceID != "trace-1" || request.Endpoint != "web" {
+		t.Errorf("Unexpected request info: %+v", request)
+	}
+	if identity.ID != "u1" || identity.Issuer != "auth" {
+		t.Errorf("Unexpected identity: %+v", identity)
+	}
+}
@@ -0,0 +1,193 @@
+package pipeline
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// 常用的模板插槽名称
+const (
+	SlotPreProcess  = "pre-process"  // 标准校验之后、核心处理之前
+	SlotPostProcess = "post-process" // 核心处理之后、审计等收尾步骤之前
+)
+
+// Template 管道模板：统一定义中间件、生命周期钩子和标准步骤，
+// 具体管道由 New 实例化并在命名插槽处插入自己的 Hook，保持大量管道结构一致
+type Template[C Context, Option any, Payload any, Result any] struct {
+	setup []func(p *Pipeline[C, Option, Payload, Result]) // 实例化时按注册顺序执行的配置
+	steps []*Hook[C, Option, Payload, Result]             // 标准 Hook 与插槽占位（按顺序）
+	slots []string                                        // 已定义的插槽名称
+}
+
+// NewTemplate 创建管道模板
+func NewTemplate[C Context, Option any, Payload any, Result any]() *Template[C, Option, Payload, Result] {
+	return &Template[C, Option, Payload, Result]{}
+}
+
+// Configure 注册实例化时对管道执行的配置（如 WithLogger、WithClassifier 等）
+func (t *Template[C, Option, Payload, Result]) Configure(
+	fn func(p *Pipeline[C, Option, Payload, Result]),
+) *Template[C, Option, Payload, Result] {
+	t.setup = append(t.setup, fn)
+	return t
+}
+
+// Use 为所有实例使用中间件
+func (t *Template[C, Option, Payload, Result]) Use(
+	middlewares ...Middleware[C, Option, Payload, Result],
+) *Template[C, Option, Payload, Result] {
+	return t.Configure(func(p *Pipeline[C, Option, Payload, Result]) {
+		p.Use(middlewares...)
+	})
+}
+
+// UseNamed 为所有实例使用命名中间件
+func (t *Template[C, Option, Payload, Result]) UseNamed(
+	name string,
+	middleware Middleware[C, Option, Payload, Result],
+	opts ...MiddlewareOption,
+) *Template[C, Option, Payload, Result] {
+	return t.Configure(func(p *Pipeline[C, Option, Payload, Result]) {
+		p.UseNamed(name, middleware, opts...)
+	})
+}
+
+// UseFor 为所有实例使用只作用于选中 Hook 的中间件
+func (t *Template[C, Option, Payload, Result]) UseFor(
+	matcher HookMatcher,
+	middlewares ...Middleware[C, Option, Payload, Result],
+) *Template[C, Option, Payload, Result] {
+	return t.Configure(func(p *Pipeline[C, Option, Payload, Result]) {
+		p.UseFor(matcher, middlewares...)
+	})
+}
+
+// OnBeforeExecute 为所有实例注册执行前钩子
+func (t *Template[C, Option, Payload, Result]) OnBeforeExecute(
+	fn func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]),
+) *Template[C, Option, Payload, Result] {
+	return t.Configure(func(p *Pipeline[C, Option, Payload, Result]) {
+		p.OnBeforeExecute(fn)
+	})
+}
+
+// OnAfterExecute 为所有实例注册执行后钩子
+func (t *Template[C, Option, Payload, Result]) OnAfterExecute(
+	fn func(ctx C, pipeCtx *PipeContext[Option, Payload, Result], err error),
+) *Template[C, Option, Payload, Result] {
+	return t.Configure(func(p *Pipeline[C, Option, Payload, Result]) {
+		p.OnAfterExecute(fn)
+	})
+}
+
+// OnError 为所有实例注册错误处理钩子
+func (t *Template[C, Option, Payload, Result]) OnError(
+	fn func(ctx C, hookName string, err error),
+) *Template[C, Option, Payload, Result] {
+	return t.Configure(func(p *Pipeline[C, Option, Payload, Result]) {
+		p.OnError(fn)
+	})
+}
+
+// AddNamedHook 添加所有实例共有的标准步骤
+func (t *Template[C, Option, Payload, Result]) AddNamedHook(
+	name string,
+	handler HookHandler[C, Option, Payload, Result],
+) *Template[C, Option, Payload, Result] {
+	return t.AddHookWithOptions(&Hook[C, Option, Payload, Result]{Name: name, Handler: handler})
+}
+
+// AddHookWithOptions 添加带配置的标准步骤
+func (t *Template[C, Option, Payload, Result]) AddHookWithOptions(
+	hook *Hook[C, Option, Payload, Result],
+) *Template[C, Option, Payload, Result] {
+	t.steps = append(t.steps, hook)
+	return t
+}
+
+// DefineSlot 在当前位置定义命名插槽，实例在此插入自己的 Hook
+// 插槽保留在实例管道中，Build 之后仍可通过 Pipeline.FillSlot 填充；名称重复时 panic
+func (t *Template[C, Option, Payload, Result]) DefineSlot(name string) *Template[C, Option, Payload, Result] {
+	if slices.Contains(t.slots, name) {
+		panic(fmt.Sprintf("pipeline: slot '%s' already defined", name))
+	}
+	t.slots = append(t.slots, name)
+	t.steps = append(t.steps, &Hook[C, Option, Payload, Result]{Name: name, kind: slotKind})
+	return t
+}
+
+// New 从模板实例化管道
+func (t *Template[C, Option, Payload, Result]) New(
+	name string,
+	opts ...OptionHandler[Option],
+) *TemplateInstance[C, Option, Payload, Result] {
+	return &TemplateInstance[C, Option, Payload, Result]{
+		template: t,
+		name:     name,
+		opts:     opts,
+		fills:    make(map[string][]*Hook[C, Option, Payload, Result]),
+	}
+}
+
+// TemplateInstance 模板实例构建器
+type TemplateInstance[C Context, Option any, Payload any, Result any] struct {
+	template *Template[C, Option, Payload, Result]
+	name     string
+	opts     []OptionHandler[Option]
+	fills    map[string][]*Hook[C, Option, Payload, Result]
+	setup    []func(p *Pipeline[C, Option, Payload, Result])
+}
+
+// Fill 向插槽追加 Hook，插槽未在模板中定义时 panic
+func (i *TemplateInstance[C, Option, Payload, Result]) Fill(
+	slot string,
+	hooks ...*Hook[C, Option, Payload, Result],
+) *TemplateInstance[C, Option, Payload, Result] {
+	if !slices.Contains(i.template.slots, slot) {
+		panic(fmt.Sprintf("pipeline: slot '%s' not defined", slot))
+	}
+	i.fills[slot] = append(i.fills[slot], hooks...)
+	return i
+}
+
+// Configure 追加仅作用于本实例的配置（Build 时在模板配置之后执行）
+func (i *TemplateInstance[C, Option, Payload, Result]) Configure(
+	fn func(p *Pipeline[C, Option, Payload, Result]),
+) *TemplateInstance[C, Option, Payload, Result] {
+	i.setup = append(i.setup, fn)
+	return i
+}
+
+// Build 按模板顺序展开标准步骤与插槽，返回具体管道
+// 每次调用返回新管道，标准步骤在每个实例中是独立副本（Once 等状态不在实例间共享）
+func (i *TemplateInstance[C, Option, Payload, Result]) Build() *Pipeline[C, Option, Payload, Result] {
+	p := NewPipeline[C, Option, Payload, Result](i.name, i.opts...)
+	for _, fn := range i.template.setup {
+		fn(p)
+	}
+	for _, fn := range i.setup {
+		fn(p)
+	}
+
+	// 配置中添加的 Hook 排在模板步骤之后
+	hooks := make([]*Hook[C, Option, Payload, Result], 0, len(i.template.steps)+len(p.hooks))
+	for _, step := range i.template.steps {
+		hooks = append(hooks, step.clone())
+	}
+	p.hooks = append(hooks, p.hooks...)
+
+	for _, slot := range i.template.slots {
+		p.FillSlot(slot, i.fills[slot]...)
+	}
+	return p
+}
+
+// clone 浅拷贝 Hook，Once 状态重新初始化
+func (h *Hook[C, Option, Payload, Result]) clone() *Hook[C, Option, Payload, Result] {
+	cp := *h
+	if h.once != nil {
+		cp.once = new(sync.Once)
+	}
+	return &cp
+}
@@ -0,0 +1,66 @@
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sylphbyte/sylph"
+)
+
+type testHook = Hook[sylph.Context, TestOption, TestPayload, TestResult]
+
+// TestTemplate 测试模板实例化与插槽填充
+func TestTemplate(t *testing.T) {
+	var before int
+	template := NewTemplate[sylph.Context, TestOption, TestPayload, TestResult]().
+		OnBeforeExecute(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) {
+			before++
+		}).
+		AddNamedHook("validate", appendHook("validate")).
+		DefineSlot(SlotPreProcess).
+		AddNamedHook("core", appendHook("core")).
+		DefineSlot(SlotPostProcess).
+		AddHookWithOptions(NewHook(appendHook("audit")).WithName("audit").Once().Build())
+
+	order := template.New("order").
+		Fill(SlotPreProcess, &testHook{Name: "enrich", Handler: appendHook("enrich")}).
+		Fill(SlotPostProcess, &testHook{Name: "notify", Handler: appendHook("notify")}).
+		Build()
+	refund := template.New("refund").Build()
+
+	result, err := order.Execute(newMockContext(), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"validate", "enrich", "core", "notify", "audit"}
+	if !reflect.DeepEqual(result.Output, expected) {
+		t.Errorf("Expected %v, got %v", expected, result.Output)
+	}
+
+	// Once 状态不在实例间共享
+	result, err = refund.Execute(newMockContext(), &TestPayload{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected = []string{"validate", "core", "audit"}
+	if !reflect.DeepEqual(result.Output, expected) {
+		t.Errorf("Expected %v, got %v", expected, result.Output)
+	}
+
+	if before != 2 {
+		t.Errorf("Expected template lifecycle hook on every instance, got %d calls", before)
+	}
+}
+
+// TestTemplateUnknownSlot 测试填充未定义插槽时 panic
+func TestTemplateUnknownSlot(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for unknown slot")
+		}
+	}()
+
+	NewTemplate[sylph.Context, TestOption, TestPayload, TestResult]().
+		New("x").
+		Fill("missing", &testHook{Handler: appendHook("x")})
+}
@@ -0,0 +1,143 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ContextDeriver 由父上下文派生带超时的同类型上下文
+type ContextDeriver[C Context] func(parent C, timeout time.Duration) (C, context.CancelFunc)
+
+// contextDerivers 按上下文类型注册的派生函数
+var contextDerivers sync.Map
+
+// RegisterContextDeriver 为上下文类型 C 注册超时派生函数（如 sylph.WithTimeout）
+// 派生结果保留调用方的具体上下文类型；sylph.Context 的派生函数由 sylphctx 包注册
+func RegisterContextDeriver[C Context](deriver ContextDeriver[C]) {
+	contextDerivers.Store(reflect.TypeOf((*C)(nil)).Elem(), deriver)
+}
+
+// ContextWithTimeout 派生带超时的上下文
+// 优先使用为 C 注册的派生函数；ctx 由 WrapContext/WrapContextWithLogger 创建时直接派生同类型的上下文。
+// 两者都不满足时 ok 为 false 并返回原 ctx：不会用其他类型替换调用方的上下文，以免丢失其具体方法
+func ContextWithTimeout[C Context](ctx C, timeout time.Duration) (derived C, cancel context.CancelFunc, ok bool) {
+	if deriver, found := contextDerivers.Load(reflect.TypeOf((*C)(nil)).Elem()); found {
+		derived, cancel = deriver.(ContextDeriver[C])(ctx, timeout)
+		return derived, cancel, true
+	}
+
+	if adapter, isAdapter := any(ctx).(*stdContextAdapter); isAdapter {
+		child, cancel := context.WithTimeout(adapter.Context, timeout)
+		if derived, ok := any(&stdContextAdapter{Context: child, Logger: adapter.Logger}).(C); ok {
+			return derived, cancel, true
+		}
+		cancel()
+	}
+
+	return ctx, func() {}, false
+}
+
+// maxContextTimeout ctx 没有截止时间时，通过超时派生函数派生可取消上下文使用的超时
+const maxContextTimeout = 100 * 365 * 24 * time.Hour
+
+// ContextWithCancel 派生可取消的上下文，派生规则与 ContextWithTimeout 相同
+// 通过注册的派生函数派生时沿用 ctx 的截止时间（ctx 没有截止时间时使用足够长的超时）
+func ContextWithCancel[C Context](ctx C) (derived C, cancel context.CancelFunc, ok bool) {
+	if _, found := contextDerivers.Load(reflect.TypeOf((*C)(nil)).Elem()); !found {
+		if adapter, isAdapter := any(ctx).(*stdContextAdapter); isAdapter {
+			child, cancel := context.WithCancel(adapter.Context)
+			if derived, ok := any(&stdContextAdapter{Context: child, Logger: adapter.Logger}).(C); ok {
+				return derived, cancel, true
+			}
+			cancel()
+		}
+	}
+
+	timeout := maxContextTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	return ContextWithTimeout(ctx, timeout)
+}
+
+// TimeoutHandler 为 Handler 添加超时控制
+// 超时后取消传给 Handler 的上下文并返回 ErrHookTimeout、触发 OnTimeout。
+// grace > 0 时最多再等待 grace 让 Handler 响应取消后返回；grace 为 0 时立即返回，
+// 未响应取消的 Handler 会在后台 goroutine 中继续运行直到自行结束（其结果被丢弃），记为僵尸 Hook（见 OnZombie）。
+// C 无法派生带超时的上下文时（见 ContextWithTimeout）退回计时器：Handler 使用原 ctx，收不到超时取消，
+// 超时后同样返回 ErrHookTimeout，仍在运行的 Handler 记为僵尸 Hook
+func TimeoutHandler[C Context, Option any, Payload any, Result any](
+	handler HookHandler[C, Option, Payload, Result],
+	timeout time.Duration,
+	grace time.Duration,
+) HookHandler[C, Option, Payload, Result] {
+	return func(ctx C, pipeCtx *PipeContext[Option, Payload, Result]) error {
+		child, cancel, ok := ContextWithTimeout(ctx, timeout)
+		defer cancel()
+		var expired <-chan time.Time
+		if ok {
+			expired = contextExpired(child)
+		} else {
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			expired = timer.C
+		}
+
+		done := make(chan error, 1)
+		panicked := make(chan any, 1)
+		exited := make(chan struct{})
+		go func() {
+			defer close(exited)
+			defer func() {
+				if r := recover(); r != nil {
+					panicked <- r
+				}
+			}()
+			done <- handler(child, pipeCtx)
+		}()
+
+		select {
+		case err := <-done:
+			// Hook 因响应超时取消而返回错误时同样视为超时
+			if err == nil || child.Err() == nil || ctx.Err() != nil {
+				return err
+			}
+			pipeCtx.NotifyTimeout(timeout)
+			return fmt.Errorf("%w after %v", ErrHookTimeout, timeout)
+		case r := <-panicked:
+			panic(r)
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-expired:
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		cancel()
+		pipeCtx.NotifyTimeout(timeout)
+
+		if grace > 0 {
+			select {
+			case <-exited:
+			case <-time.After(grace):
+			}
+		}
+		select {
+		case <-exited:
+		default:
+			pipeCtx.reportZombie(ErrHookTimeout, exited)
+		}
+		return fmt.Errorf("%w after %v", ErrHookTimeout, timeout)
+	}
+}
+
+// contextExpired 将上下文结束转换为时间通道
+func contextExpired(ctx context.Context) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	context.AfterFunc(ctx, func() { ch <- time.Now() })
+	return ch
+}
@@ -0,0 +1,159 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestHookTimeoutCancels 测试 Hook 超时会取消传给 Hook 的上下文
+func TestHookTimeoutCancels(t *testing.T) {
+	cancelled := make(chan error, 1)
+
+	hook := NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+		<-ctx.Done()
+		cancelled <- ctx.Err()
+		return ctx.Err()
+	}).WithName("slow").WithTimeout(20 * time.Millisecond).Build()
+
+	var timeouts int
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		OnTimeout(func(ctx Context, event TimeoutEvent) { timeouts++ }).
+		AddHookWithOptions(hook)
+
+	_, err := pipeline.ExecuteStd(context.Background(), &TestPayload{})
+	if !errors.Is(err, ErrHookTimeout) {
+		t.Fatalf("Expected ErrHookTimeout, got %v", err)
+	}
+
+	select {
+	case err := <-cancelled:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected hook context deadline exceeded, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected hook context to be cancelled")
+	}
+
+	if timeouts != 1 {
+		t.Errorf("Expected 1 timeout event, got %d", timeouts)
+	}
+}
+
+// underivedContext 未注册派生函数的自定义上下文
+type underivedContext struct {
+	Context
+}
+
+// derivedContext 已注册派生函数的自定义上下文
+type derivedContext struct {
+	Context
+	tenant string
+}
+
+func init() {
+	RegisterContextDeriver(func(ctx derivedContext, timeout time.Duration) (derivedContext, context.CancelFunc) {
+		child, cancel, _ := ContextWithTimeout(ctx.Context, timeout)
+		return derivedContext{Context: child, tenant: ctx.tenant}, cancel
+	})
+}
+
+// TestHookTimeoutNoDeriver 测试无法派生的上下文类型退回计时器超时
+func TestHookTimeoutNoDeriver(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	var calls int
+	fast := NewHook(func(ctx underivedContext, pipeCtx *PipeContext[NoOption, TestPayload, TestResult]) error {
+		calls++
+		return nil
+	}).WithName("fast").WithTimeout(time.Second).Build()
+	slow := NewHook(func(ctx underivedContext, pipeCtx *PipeContext[NoOption, TestPayload, TestResult]) error {
+		<-release
+		return nil
+	}).WithName("slow").WithTimeout(20 * time.Millisecond).Build()
+
+	pipeline := NewPipeline[underivedContext, NoOption, TestPayload, TestResult]("test").AddHookWithOptions(fast)
+	if _, err := pipeline.Execute(underivedContext{WrapContext(context.Background())}, &TestPayload{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected hook to run once, got %d calls", calls)
+	}
+
+	pipeline.AddHookWithOptions(slow)
+	_, err := pipeline.Execute(underivedContext{WrapContext(context.Background())}, &TestPayload{})
+	if !errors.Is(err, ErrHookTimeout) {
+		t.Fatalf("Expected ErrHookTimeout, got %v", err)
+	}
+}
+
+// TestHookTimeoutSylphContext 测试未导入 sylphctx 时 sylph.Context 的 Hook 超时仍然生效
+func TestHookTimeoutSylphContext(t *testing.T) {
+	pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("test").
+		AddHookWithOptions(NewHook(appendHook("load")).WithName("load").WithTimeout(time.Second).Build()).
+		AddHookWithOptions(NewHook(func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+			time.Sleep(100 * time.Millisecond)
+			return nil
+		}).WithName("slow").WithTimeout(20 * time.Millisecond).Build())
+
+	_, err := pipeline.Execute(newMockContext(), &TestPayload{})
+	var pipeErr *PipeError
+	if !errors.As(err, &pipeErr) || pipeErr.HookName != "slow" || !errors.Is(err, ErrHookTimeout) {
+		t.Fatalf("Expected timeout from 'slow', got %v", err)
+	}
+}
+
+// TestHookTimeoutKeepsContextType 测试注册派生函数后 Hook 拿到的仍是调用方的具体上下文类型
+func TestHookTimeoutKeepsContextType(t *testing.T) {
+	hook := NewHook(func(ctx derivedContext, pipeCtx *PipeContext[NoOption, TestPayload, TestResult]) error {
+		if ctx.tenant != "t1" {
+			t.Errorf("Expected tenant t1, got %q", ctx.tenant)
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}).WithName("slow").WithTimeout(20 * time.Millisecond).Build()
+
+	pipeline := NewPipeline[derivedContext, NoOption, TestPayload, TestResult]("test").AddHookWithOptions(hook)
+	_, err := pipeline.Execute(derivedContext{Context: WrapContext(context.Background()), tenant: "t1"}, &TestPayload{})
+	if !errors.Is(err, ErrHookTimeout) {
+		t.Fatalf("Expected ErrHookTimeout, got %v", err)
+	}
+}
+
+// TestZombieHook 测试超时后仍在运行的 Hook 被记为僵尸并在结束后从计数中移除
+func TestZombieHook(t *testing.T) {
+	release := make(chan struct{})
+	hook := NewHook(func(ctx Context, pipeCtx *SimplePipeContext[TestPayload, TestResult]) error {
+		<-release // 不响应取消
+		return nil
+	}).WithName("stuck").WithTimeout(10 * time.Millisecond).Build()
+
+	var zombies []ZombieEvent
+	var stats *ExecutionStats
+	pipeline := NewSimplePipeline[TestPayload, TestResult]("test").
+		OnZombie(func(ctx Context, event ZombieEvent) { zombies = append(zombies, event) }).
+		WithStatsSink(StatsSinkFunc(func(s *ExecutionStats) { stats = s })).
+		AddHookWithOptions(hook)
+
+	if _, err := pipeline.ExecuteStd(context.Background(), &TestPayload{}); !errors.Is(err, ErrHookTimeout) {
+		t.Fatalf("Expected ErrHookTimeout, got %v", err)
+	}
+	if len(zombies) != 1 || zombies[0].Hook != "stuck" || !errors.Is(zombies[0].Reason, ErrHookTimeout) {
+		t.Errorf("Expected one zombie event for stuck, got %+v", zombies)
+	}
+	if stats.Zombies != 1 || pipeline.Zombies() != 1 {
+		t.Errorf("Expected 1 zombie, got %d in stats and %d running", stats.Zombies, pipeline.Zombies())
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for pipeline.Zombies() != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if pipeline.Zombies() != 0 {
+		t.Errorf("Expected zombie count to drop after the hook exits, got %d", pipeline.Zombies())
+	}
+}
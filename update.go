@@ -0,0 +1,86 @@
+package pipeline
+
+import "fmt"
+
+// CompareAndSet 当 key 的当前值等于 old 时写入 new，返回是否写入（并发安全）
+// old 为 nil 表示 key 不存在；old 必须是可比较的类型，否则 panic
+func (p *PipeContext[Option, Payload, Result]) CompareAndSet(key string, old, new any) bool {
+	p.checkWrite(key)
+	_, swapped := p.state.updateData(key, func(current any, ok bool) (any, bool) {
+		if !ok {
+			return new, old == nil
+		}
+		return new, old != nil && current == old
+	})
+	if swapped {
+		p.recordData(key, new)
+	}
+	return swapped
+}
+
+// Update 以原子方式读取并替换 key 的值，返回写入的新值（并发安全）
+// key 不存在时 old 为 nil；fn 在持有锁时调用，不能再访问共享数据
+func (p *PipeContext[Option, Payload, Result]) Update(key string, fn func(old any) any) any {
+	p.checkWrite(key)
+	value, _ := p.state.updateData(key, func(current any, ok bool) (any, bool) {
+		return fn(current), true
+	})
+	p.recordData(key, value)
+	return value
+}
+
+// Incr 将 key 的 int64 计数加 n 并返回新值（key 不存在时从 0 开始，并发安全）
+// key 已存在但不是 int64 时 panic
+func (p *PipeContext[Option, Payload, Result]) Incr(key string, n int64) int64 {
+	p.checkWrite(key)
+	value, _ := p.state.updateData(key, func(current any, ok bool) (any, bool) {
+		if !ok {
+			return n, true
+		}
+		count, isInt := current.(int64)
+		if !isInt {
+			panic(fmt.Sprintf("pipeline: key '%s' holds %T, not an int64 counter", key, current))
+		}
+		return count + n, true
+	})
+	p.recordData(key, value)
+	return value.(int64)
+}
+
+// recordData 将共享数据写入记录到事件存储（未配置时为空操作）
+func (p *PipeContext[Option, Payload, Result]) recordData(key string, value any) {
+	if p.state.journal != nil {
+		name, index := p.CurrentHook()
+		p.state.journal.dataChanged(EventDataSet, name, index, key, value)
+	}
+}
+
+// updateData 在锁内读取 key 并按 fn 的结果写入，fn 返回 false 时不写入；返回写入（或保持）的值与是否写入
+func (s *sharedState) updateData(key string, fn func(current any, ok bool) (any, bool)) (any, bool) {
+	defer s.notify(key)
+	if s.shards != nil {
+		shard := s.shards.shard(key)
+		shard.mu.Lock()
+		defer shard.mu.Unlock()
+		if shard.m == nil {
+			shard.m = make(map[string]any)
+		}
+		current, ok := shard.m[key]
+		value, write := fn(current, ok)
+		if !write {
+			return current, false
+		}
+		shard.m[key] = value
+		return value, true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.data.get(key)
+	value, write := fn(current, ok)
+	if !write {
+		return current, false
+	}
+	s.data.set(key, value)
+	return value, true
+}
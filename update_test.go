@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestCompareAndSet 测试按当前值条件写入
+func TestCompareAndSet(t *testing.T) {
+	pipeCtx := NewPipeContext[TestOption, TestPayload, TestResult]("test", nil, &TestPayload{}, nil)
+
+	if !pipeCtx.CompareAndSet("leader", nil, "a") {
+		t.Error("Expected set on missing key")
+	}
+	if pipeCtx.CompareAndSet("leader", nil, "b") || pipeCtx.CompareAndSet("leader", "x", "b") {
+		t.Error("Expected no set on mismatched value")
+	}
+	if !pipeCtx.CompareAndSet("leader", "a", "b") || pipeCtx.MustGet("leader") != "b" {
+		t.Errorf("Expected swap to b, got %v", pipeCtx.MustGet("leader"))
+	}
+}
+
+// TestAtomicUpdate 测试并发的 Update 和 Incr 不丢失写入（含分片存储）
+func TestAtomicUpdate(t *testing.T) {
+	for _, sharded := range []bool{false, true} {
+		pipeCtx := NewPipeContext[TestOption, TestPayload, TestResult]("test", nil, &TestPayload{}, nil)
+		if sharded {
+			pipeCtx.state.shards = newShardedData(&pipeCtx.state.data)
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					pipeCtx.Incr("count", 1)
+					pipeCtx.Update("seen", func(old any) any {
+						ids, _ := old.([]int)
+						return append(ids, j)
+					})
+				}
+			}()
+		}
+		wg.Wait()
+
+		if count := pipeCtx.MustGet("count"); count != int64(800) {
+			t.Errorf("sharded=%v: expected count 800, got %v", sharded, count)
+		}
+		if seen := pipeCtx.MustGet("seen").([]int); len(seen) != 800 {
+			t.Errorf("sharded=%v: expected 800 updates, got %d", sharded, len(seen))
+		}
+	}
+
+	pipeCtx := NewPipeContext[TestOption, TestPayload, TestResult]("test", nil, &TestPayload{}, nil)
+	pipeCtx.Set("count", 1)
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic on non-int64 counter")
+		}
+	}()
+	pipeCtx.Incr("count", 1)
+}
@@ -0,0 +1,27 @@
+package pipeline
+
+import "errors"
+
+// Validate 检查管道配置，返回发现的全部问题（无问题时返回 nil）：
+//   - 声明的读取键没有被之前的 Hook 写入，也不在 WithProvidedKeys 中（ErrMissingDataKey）
+//   - 开启 EnforceSunset 时已过下线日期的弃用 Hook（ErrHookSunset）
+func (p *Pipeline[C, Option, Payload, Result]) Validate() error {
+	var errs []error
+	errs = append(errs, p.contractErrors()...)
+	errs = append(errs, p.sunsetErrors()...)
+	return errors.Join(errs...)
+}
+
+// walkHooks 依次访问所有 Hook，包括复合 Hook 分组中的子 Hook
+func (p *Pipeline[C, Option, Payload, Result]) walkHooks(fn func(hook *Hook[C, Option, Payload, Result])) {
+	var walk func(hooks []*Hook[C, Option, Payload, Result])
+	walk = func(hooks []*Hook[C, Option, Payload, Result]) {
+		for _, hook := range hooks {
+			fn(hook)
+			for _, group := range hook.groups {
+				walk(group.hooks)
+			}
+		}
+	}
+	walk(p.hooks)
+}
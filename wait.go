@@ -0,0 +1,62 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+)
+
+// WaitFor 阻塞直到 key 被写入（其他并行分支或异步 Hook 调用 Set 等），返回写入的值；
+// key 已存在时立即返回。ctx 取消时返回包装了 ctx.Err() 的错误。
+// 写入与读取之间已经同步，严格模式下不视为读取未结束并行分支的数据
+func (p *PipeContext[Option, Payload, Result]) WaitFor(ctx context.Context, key string) (any, error) {
+	for {
+		ready := p.state.waitKey(key)
+		value, ok := p.state.loadData(key)
+		if ok {
+			p.state.unwait()
+			return value, nil
+		}
+
+		select {
+		case <-ready:
+			p.state.unwait()
+		case <-ctx.Done():
+			p.state.unwait()
+			return nil, fmt.Errorf("wait for key '%s': %w", key, ctx.Err())
+		}
+	}
+}
+
+// waitKey 登记等待者，返回 key 下次写入时关闭的通道
+func (s *sharedState) waitKey(key string) <-chan struct{} {
+	s.waitMu.Lock()
+	defer s.waitMu.Unlock()
+	s.waiting.Add(1)
+	if s.waiters == nil {
+		s.waiters = make(map[string]chan struct{})
+	}
+	ch, ok := s.waiters[key]
+	if !ok {
+		ch = make(chan struct{})
+		s.waiters[key] = ch
+	}
+	return ch
+}
+
+// unwait 注销一次 waitKey 的登记
+func (s *sharedState) unwait() {
+	s.waiting.Add(-1)
+}
+
+// notify 唤醒等待 key 的 WaitFor（没有等待者时只有一次原子读取）
+func (s *sharedState) notify(key string) {
+	if s.waiting.Load() == 0 {
+		return
+	}
+	s.waitMu.Lock()
+	defer s.waitMu.Unlock()
+	if ch, ok := s.waiters[key]; ok {
+		close(ch)
+		delete(s.waiters, key)
+	}
+}
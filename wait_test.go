@@ -0,0 +1,59 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sylphbyte/sylph"
+)
+
+// TestWaitFor 测试并行分支之间的生产者/消费者协调
+func TestWaitFor(t *testing.T) {
+	for _, concurrent := range []bool{false, true} {
+		var used any
+		pipeline := NewPipeline[sylph.Context, TestOption, TestPayload, TestResult]("fanout").
+			WithStrictData().
+			AddParallel("branches", Parallel(
+				func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+					token, err := pipeCtx.WaitFor(ctx, "token")
+					used = token
+					return err
+				},
+				func(ctx sylph.Context, pipeCtx *PipeContext[TestOption, TestPayload, TestResult]) error {
+					time.Sleep(20 * time.Millisecond)
+					pipeCtx.Set("token", "t-1")
+					return nil
+				},
+			))
+		if concurrent {
+			pipeline.WithConcurrentDataStore()
+		}
+
+		if _, err := pipeline.Execute(newMockContext(), &TestPayload{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if used != "t-1" {
+			t.Errorf("concurrent=%v: expected consumer to see token, got %v", concurrent, used)
+		}
+	}
+}
+
+// TestWaitForCancel 测试已存在的键立即返回，ctx 取消时返回错误
+func TestWaitForCancel(t *testing.T) {
+	pipeCtx := NewPipeContext[TestOption, TestPayload, TestResult]("test", nil, &TestPayload{}, nil)
+	pipeCtx.Set("ready", 1)
+	if v, err := pipeCtx.WaitFor(context.Background(), "ready"); err != nil || v != 1 {
+		t.Errorf("Expected immediate value, got %v, %v", v, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := pipeCtx.WaitFor(ctx, "never"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if pipeCtx.state.waiting.Load() != 0 {
+		t.Errorf("Expected waiter unregistered, got %d", pipeCtx.state.waiting.Load())
+	}
+}
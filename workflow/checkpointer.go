@@ -0,0 +1,54 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Checkpointer 工作流实例持久化接口
+type Checkpointer[Data any] interface {
+	// Save 保存实例（覆盖同一工作流下同 ID 的实例）
+	Save(ctx context.Context, inst *Instance[Data]) error
+	// Load 读取实例，不存在时返回 ErrInstanceNotFound
+	Load(ctx context.Context, workflow, id string) (*Instance[Data], error)
+}
+
+// MemoryCheckpointer 基于内存的 Checkpointer（用于测试和单机场景）
+type MemoryCheckpointer[Data any] struct {
+	mu        sync.RWMutex
+	instances map[string]Instance[Data]
+}
+
+// NewMemoryCheckpointer 创建内存 Checkpointer
+func NewMemoryCheckpointer[Data any]() *MemoryCheckpointer[Data] {
+	return &MemoryCheckpointer[Data]{
+		instances: make(map[string]Instance[Data]),
+	}
+}
+
+// Save 保存实例快照
+func (m *MemoryCheckpointer[Data]) Save(ctx context.Context, inst *Instance[Data]) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := *inst
+	snapshot.History = append([]Transition(nil), inst.History...)
+	m.instances[inst.Workflow+"/"+inst.ID] = snapshot
+	return nil
+}
+
+// Load 读取实例快照
+func (m *MemoryCheckpointer[Data]) Load(ctx context.Context, workflow, id string) (*Instance[Data], error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshot, ok := m.instances[workflow+"/"+id]
+	if !ok {
+		return nil, fmt.Errorf("workflow '%s' instance '%s': %w", workflow, id, ErrInstanceNotFound)
+	}
+
+	inst := snapshot
+	inst.History = append([]Transition(nil), snapshot.History...)
+	return &inst, nil
+}
@@ -0,0 +1,355 @@
+// Package workflow 在管道之上提供状态机（工作流）层。
+//
+// 每个状态可以配置一个进入时执行的管道（Entry），状态之间的迁移由
+// 管道的执行结果（成功/失败）或外部事件驱动，实例状态通过 Checkpointer 持久化。
+//
+//	wf := workflow.New[pipe.Context, Order]("approval", workflow.NewMemoryCheckpointer[Order]())
+//	wf.State("draft").On("submit", "review")
+//	wf.State("review").Entry(reviewPipeline).OnSuccess("approved").OnFailure("rejected")
+//	wf.State("approved").Final()
+//	wf.State("rejected").Final()
+//
+//	inst, err := wf.Start(ctx, "order-1", &order)
+//	inst, err = wf.Fire(ctx, "order-1", "submit")
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+// DefaultMaxSteps 单次 Start/Fire 中自动迁移的最大次数（防止状态环路）
+const DefaultMaxSteps = 100
+
+var (
+	// ErrUnknownState 状态未定义
+	ErrUnknownState = errors.New("unknown state")
+	// ErrInvalidTransition 当前状态不接受该事件
+	ErrInvalidTransition = errors.New("invalid transition")
+	// ErrInstanceNotFound 实例不存在
+	ErrInstanceNotFound = errors.New("instance not found")
+	// ErrInstanceExists 实例已存在
+	ErrInstanceExists = errors.New("instance already exists")
+	// ErrMaxStepsExceeded 自动迁移次数超过上限
+	ErrMaxStepsExceeded = errors.New("max workflow steps exceeded")
+	// ErrInstanceBusy 实例正在被另一次 Start/Fire 处理
+	ErrInstanceBusy = errors.New("instance busy")
+)
+
+// 迁移触发类型
+const (
+	TriggerStart   = "start"   // 实例创建
+	TriggerSuccess = "success" // Entry 管道执行成功
+	TriggerFailure = "failure" // Entry 管道执行失败
+)
+
+// Transition 一次状态迁移记录
+type Transition struct {
+	From    string    // 迁移前状态（创建时为空）
+	To      string    // 迁移后状态
+	Trigger string    // 触发原因：start / success / failure / 事件名
+	Error   string    // 失败迁移时的错误信息
+	At      time.Time // 迁移时间
+}
+
+// Instance 工作流实例
+type Instance[Data any] struct {
+	ID        string       // 实例 ID
+	Workflow  string       // 工作流名称
+	State     string       // 当前状态
+	Data      *Data        // 业务数据（Entry 管道的输入与输出）
+	LastError string       // 最近一次未处理的 Entry 失败
+	History   []Transition // 迁移历史
+	UpdatedAt time.Time    // 更新时间
+}
+
+// Workflow 工作流定义
+type Workflow[C pipe.Context, Data any] struct {
+	Name     string
+	initial  string
+	order    []string
+	states   map[string]*State[C, Data]
+	store    Checkpointer[Data]
+	maxSteps int
+
+	mu     sync.Mutex          // 只保护 active，Entry 管道执行期间不持有
+	active map[string]struct{} // 正在处理的实例 ID
+}
+
+// State 状态定义
+type State[C pipe.Context, Data any] struct {
+	Name      string
+	entry     pipe.Executor[C, Data, Data]
+	onSuccess string
+	onFailure string
+	events    map[string]string
+	final     bool
+}
+
+// New 创建工作流
+func New[C pipe.Context, Data any](name string, store Checkpointer[Data]) *Workflow[C, Data] {
+	if store == nil {
+		store = NewMemoryCheckpointer[Data]()
+	}
+	return &Workflow[C, Data]{
+		Name:     name,
+		states:   make(map[string]*State[C, Data]),
+		store:    store,
+		maxSteps: DefaultMaxSteps,
+		active:   make(map[string]struct{}),
+	}
+}
+
+// State 定义（或获取）状态，第一个定义的状态为初始状态
+func (w *Workflow[C, Data]) State(name string) *State[C, Data] {
+	if s, ok := w.states[name]; ok {
+		return s
+	}
+
+	s := &State[C, Data]{Name: name, events: make(map[string]string)}
+	w.states[name] = s
+	w.order = append(w.order, name)
+	if w.initial == "" {
+		w.initial = name
+	}
+	return s
+}
+
+// Initial 设置初始状态
+func (w *Workflow[C, Data]) Initial(name string) *Workflow[C, Data] {
+	w.initial = name
+	return w
+}
+
+// MaxSteps 设置单次调用中自动迁移的最大次数
+func (w *Workflow[C, Data]) MaxSteps(n int) *Workflow[C, Data] {
+	w.maxSteps = n
+	return w
+}
+
+// Entry 设置进入状态时执行的管道（Payload 与 Result 均为 Data）
+func (s *State[C, Data]) Entry(exec pipe.Executor[C, Data, Data]) *State[C, Data] {
+	s.entry = exec
+	return s
+}
+
+// OnSuccess Entry 管道成功后自动迁移到的状态
+func (s *State[C, Data]) OnSuccess(to string) *State[C, Data] {
+	s.onSuccess = to
+	return s
+}
+
+// OnFailure Entry 管道失败后自动迁移到的状态
+func (s *State[C, Data]) OnFailure(to string) *State[C, Data] {
+	s.onFailure = to
+	return s
+}
+
+// On 外部事件触发的迁移
+func (s *State[C, Data]) On(event, to string) *State[C, Data] {
+	s.events[event] = to
+	return s
+}
+
+// Final 标记为终止状态
+func (s *State[C, Data]) Final() *State[C, Data] {
+	s.final = true
+	return s
+}
+
+// Validate 校验工作流定义（所有迁移目标状态均已定义）
+func (w *Workflow[C, Data]) Validate() error {
+	if w.initial == "" {
+		return fmt.Errorf("workflow '%s': no states defined", w.Name)
+	}
+	if _, ok := w.states[w.initial]; !ok {
+		return fmt.Errorf("workflow '%s': initial state '%s': %w", w.Name, w.initial, ErrUnknownState)
+	}
+
+	for _, name := range w.order {
+		s := w.states[name]
+		targets := []string{s.onSuccess, s.onFailure}
+		for _, to := range s.events {
+			targets = append(targets, to)
+		}
+		for _, to := range targets {
+			if to == "" {
+				continue
+			}
+			if _, ok := w.states[to]; !ok {
+				return fmt.Errorf("workflow '%s': state '%s' transitions to '%s': %w", w.Name, name, to, ErrUnknownState)
+			}
+		}
+	}
+	return nil
+}
+
+// Start 创建实例并进入初始状态
+// 同一实例同时只允许一次 Start/Fire，冲突时返回 ErrInstanceBusy；不同实例互不阻塞
+func (w *Workflow[C, Data]) Start(ctx C, id string, data *Data) (*Instance[Data], error) {
+	if err := w.acquire(id); err != nil {
+		return nil, err
+	}
+	defer w.release(id)
+
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := w.store.Load(ctx, w.Name, id); err == nil {
+		return nil, fmt.Errorf("workflow '%s' instance '%s': %w", w.Name, id, ErrInstanceExists)
+	} else if !errors.Is(err, ErrInstanceNotFound) {
+		return nil, err
+	}
+
+	inst := &Instance[Data]{ID: id, Workflow: w.Name, Data: data}
+	return inst, w.enter(ctx, inst, w.initial, TriggerStart, "")
+}
+
+// Fire 向实例发送外部事件（同一实例正在处理时返回 ErrInstanceBusy）
+func (w *Workflow[C, Data]) Fire(ctx C, id, event string) (*Instance[Data], error) {
+	if err := w.acquire(id); err != nil {
+		return nil, err
+	}
+	defer w.release(id)
+
+	inst, err := w.store.Load(ctx, w.Name, id)
+	if err != nil {
+		return nil, err
+	}
+
+	state, ok := w.states[inst.State]
+	if !ok {
+		return inst, fmt.Errorf("workflow '%s' state '%s': %w", w.Name, inst.State, ErrUnknownState)
+	}
+
+	to, ok := state.events[event]
+	if !ok {
+		return inst, fmt.Errorf("workflow '%s' state '%s' event '%s': %w", w.Name, inst.State, event, ErrInvalidTransition)
+	}
+
+	return inst, w.enter(ctx, inst, to, event, "")
+}
+
+// acquire 占用实例，已被占用时返回 ErrInstanceBusy
+func (w *Workflow[C, Data]) acquire(id string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, busy := w.active[id]; busy {
+		return fmt.Errorf("workflow '%s' instance '%s': %w", w.Name, id, ErrInstanceBusy)
+	}
+	w.active[id] = struct{}{}
+	return nil
+}
+
+// release 释放实例占用
+func (w *Workflow[C, Data]) release(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.active, id)
+}
+
+// Get 读取实例
+func (w *Workflow[C, Data]) Get(ctx C, id string) (*Instance[Data], error) {
+	return w.store.Load(ctx, w.Name, id)
+}
+
+// enter 迁移到目标状态并执行 Entry 管道，按执行结果继续自动迁移
+func (w *Workflow[C, Data]) enter(ctx C, inst *Instance[Data], to, trigger, errMsg string) error {
+	for step := 0; ; step++ {
+		if step >= w.maxSteps {
+			return fmt.Errorf("workflow '%s' instance '%s': %w", w.Name, inst.ID, ErrMaxStepsExceeded)
+		}
+
+		state, ok := w.states[to]
+		if !ok {
+			return fmt.Errorf("workflow '%s' state '%s': %w", w.Name, to, ErrUnknownState)
+		}
+
+		now := time.Now()
+		inst.History = append(inst.History, Transition{
+			From: inst.State, To: to, Trigger: trigger, Error: errMsg, At: now,
+		})
+		inst.State = to
+		inst.LastError = ""
+		inst.UpdatedAt = now
+
+		if err := w.store.Save(ctx, inst); err != nil {
+			return err
+		}
+
+		if state.entry == nil || state.final {
+			return nil
+		}
+
+		result, err := state.entry.Execute(ctx, inst.Data)
+		if err == nil {
+			if result != nil {
+				inst.Data = result
+			}
+			if state.onSuccess == "" {
+				inst.UpdatedAt = time.Now()
+				return w.store.Save(ctx, inst)
+			}
+			to, trigger, errMsg = state.onSuccess, TriggerSuccess, ""
+			continue
+		}
+
+		if state.onFailure == "" {
+			inst.LastError = err.Error()
+			inst.UpdatedAt = time.Now()
+			if saveErr := w.store.Save(ctx, inst); saveErr != nil {
+				return saveErr
+			}
+			return err
+		}
+		to, trigger, errMsg = state.onFailure, TriggerFailure, err.Error()
+	}
+}
+
+// DOT 以 Graphviz DOT 格式导出状态图
+func (w *Workflow[C, Data]) DOT() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "digraph %q {\n", w.Name)
+	b.WriteString("  rankdir=LR;\n")
+	if w.initial != "" {
+		fmt.Fprintf(&b, "  __start [shape=point];\n  __start -> %q;\n", w.initial)
+	}
+
+	for _, name := range w.order {
+		s := w.states[name]
+
+		shape := "box"
+		if s.final {
+			shape = "doublecircle"
+		}
+		fmt.Fprintf(&b, "  %q [shape=%s];\n", name, shape)
+
+		if s.onSuccess != "" {
+			fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", name, s.onSuccess, TriggerSuccess)
+		}
+		if s.onFailure != "" {
+			fmt.Fprintf(&b, "  %q -> %q [label=%q, style=dashed];\n", name, s.onFailure, TriggerFailure)
+		}
+
+		events := make([]string, 0, len(s.events))
+		for event := range s.events {
+			events = append(events, event)
+		}
+		sort.Strings(events)
+		for _, event := range events {
+			fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", name, s.events[event], event)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,147 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	pipe "github.com/sylphbyte/pipeline"
+)
+
+type order struct {
+	Amount   int
+	Reviewed bool
+}
+
+func newApprovalWorkflow() *Workflow[pipe.Context, order] {
+	review := pipe.NewTransformPipeline[order]("review").
+		AddHook(func(ctx pipe.Context, pipeCtx *pipe.PipeContext[pipe.NoOption, order, order]) error {
+			pipeCtx.Result.Reviewed = true
+			if pipeCtx.Result.Amount > 1000 {
+				return errors.New("amount too large")
+			}
+			return nil
+		})
+
+	wf := New[pipe.Context, order]("approval", nil)
+	wf.State("draft").On("submit", "review")
+	wf.State("review").Entry(review).OnSuccess("approved").OnFailure("rejected")
+	wf.State("approved").Final()
+	wf.State("rejected").On("resubmit", "draft").Final()
+	return wf
+}
+
+// TestWorkflowTransitions 测试事件与管道结果驱动的迁移
+func TestWorkflowTransitions(t *testing.T) {
+	ctx := pipe.WrapContext(context.Background())
+	wf := newApprovalWorkflow()
+
+	if _, err := wf.Start(ctx, "o1", &order{Amount: 10}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	inst, err := wf.Fire(ctx, "o1", "submit")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if inst.State != "approved" || !inst.Data.Reviewed {
+		t.Errorf("Expected approved and reviewed, got %s, %+v", inst.State, inst.Data)
+	}
+
+	if len(inst.History) != 3 || inst.History[2].Trigger != TriggerSuccess {
+		t.Errorf("Unexpected history: %+v", inst.History)
+	}
+
+	if _, err := wf.Fire(ctx, "o1", "submit"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected ErrInvalidTransition, got %v", err)
+	}
+
+	if _, err := wf.Start(ctx, "o2", &order{Amount: 5000}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	inst, err = wf.Fire(ctx, "o2", "submit")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if inst.State != "rejected" || inst.History[2].Error != "pipeline 'review' failed at hook 'workflow.newApprovalWorkflow.func1' (index 0): amount too large" {
+		t.Errorf("Expected rejected with error, got %s, %+v", inst.State, inst.History[2])
+	}
+
+	stored, err := wf.Get(ctx, "o2")
+	if err != nil || stored.State != "rejected" {
+		t.Errorf("Expected persisted rejected state, got %v, %v", stored, err)
+	}
+}
+
+// TestWorkflowValidateAndDOT 测试定义校验与可视化
+func TestWorkflowValidateAndDOT(t *testing.T) {
+	wf := newApprovalWorkflow()
+	if err := wf.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	dot := wf.DOT()
+	for _, want := range []string{`"draft" -> "review" [label="submit"]`, `"approved" [shape=doublecircle]`} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("Expected DOT to contain %q, got:\n%s", want, dot)
+		}
+	}
+
+	wf.State("draft").On("cancel", "cancelled")
+	if err := wf.Validate(); !errors.Is(err, ErrUnknownState) {
+		t.Errorf("Expected ErrUnknownState, got %v", err)
+	}
+}
+
+// TestWorkflowInstanceIsolation 测试实例级占用：慢实例不阻塞其他实例，同一实例并发调用返回 ErrInstanceBusy
+func TestWorkflowInstanceIsolation(t *testing.T) {
+	ctx := pipe.WrapContext(context.Background())
+
+	entered := make(chan struct{})
+	unblock := make(chan struct{})
+	slow := pipe.NewTransformPipeline[order]("slow").
+		AddHook(func(ctx pipe.Context, pipeCtx *pipe.PipeContext[pipe.NoOption, order, order]) error {
+			if pipeCtx.Result.Amount > 100 {
+				close(entered)
+				<-unblock
+			}
+			return nil
+		})
+
+	wf := New[pipe.Context, order]("slow", nil)
+	wf.State("draft").On("submit", "review")
+	wf.State("review").Entry(slow).OnSuccess("done")
+	wf.State("done").Final()
+
+	if _, err := wf.Start(ctx, "slow", &order{Amount: 500}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := wf.Fire(ctx, "slow", "submit")
+		done <- err
+	}()
+	<-entered
+
+	if _, err := wf.Fire(ctx, "slow", "submit"); !errors.Is(err, ErrInstanceBusy) {
+		t.Errorf("Expected ErrInstanceBusy, got %v", err)
+	}
+
+	inst, err := wf.Start(ctx, "fast", &order{Amount: 1})
+	if err == nil {
+		inst, err = wf.Fire(ctx, "fast", "submit")
+	}
+	if err != nil || inst.State != "done" {
+		t.Errorf("Expected other instance to proceed, got %v, %v", inst, err)
+	}
+
+	close(unblock)
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}